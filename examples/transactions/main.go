@@ -45,7 +45,7 @@ func main() {
 	// The message contains the transaction.
 
 	wg.Add(1)
-	err = ws.AddUnconfirmedAddedHandlers(customerAcc.Address, func(transaction sdk.Transaction) bool {
+	_, err = ws.AddUnconfirmedAddedHandlers(customerAcc.Address, func(transaction sdk.Transaction) bool {
 		defer wg.Done()
 		fmt.Printf("UnconfirmedAdded Tx Content: %s \n", transaction.GetAbstractTransaction().TransactionHash)
 		return true
@@ -60,7 +60,7 @@ func main() {
 	//// address is included in a block. The message contains the transaction.
 
 	wg.Add(1)
-	err = ws.AddConfirmedAddedHandlers(customerAcc.Address, func(transaction sdk.Transaction) bool {
+	_, err = ws.AddConfirmedAddedHandlers(customerAcc.Address, func(transaction sdk.Transaction) bool {
 		defer wg.Done()
 		fmt.Printf("ConfirmedAdded Tx Content: %s \n", transaction.GetAbstractTransaction().TransactionHash)
 		fmt.Println("Successful transfer!")
@@ -75,7 +75,7 @@ func main() {
 	//The message contains the error message and the transaction hash.
 
 	wg.Add(1)
-	err = ws.AddStatusHandlers(customerAcc.Address, func(info *sdk.StatusInfo) bool {
+	_, err = ws.AddStatusHandlers(customerAcc.Address, func(info *sdk.StatusInfo) bool {
 		defer wg.Done()
 		fmt.Printf("Content: %v \n", info.Hash)
 		panic(fmt.Sprint("Status: ", info.Status))
@@ -136,7 +136,7 @@ func main() {
 	// The message contains the block information.
 
 	wg.Add(1)
-	err = ws.AddBlockHandlers(func(info *sdk.BlockInfo) bool {
+	_, err = ws.AddBlockHandlers(func(info *sdk.BlockInfo) bool {
 		defer wg.Done()
 		fmt.Printf("Block received with height: %v \n", info.Height)
 		return true
@@ -8,10 +8,11 @@ require (
 	github.com/gorilla/websocket v1.4.0
 	github.com/json-iterator/go v1.1.6
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
-	github.com/modern-go/reflect2 v1.0.1 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pkg/errors v0.8.1
 	github.com/proximax-storage/go-xpx-crypto v0.0.0-20191023142918-e02e2652d78e
 	github.com/proximax-storage/go-xpx-utils v0.0.0-20190604083640-90d06ff8a19f
 	github.com/stretchr/testify v1.3.0
+	github.com/tyler-smith/go-bip39 v1.0.2
 	golang.org/x/crypto v0.0.0-20190513172903-22d7a77e9e5f
 )
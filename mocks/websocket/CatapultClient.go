@@ -2,9 +2,11 @@
 
 package mocks
 
+import context "context"
 import mock "github.com/stretchr/testify/mock"
 import sdk "github.com/proximax-storage/go-xpx-chain-sdk/sdk"
 import subscribers "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+import websocket "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
 
 // CatapultClient is an autogenerated mock type for the CatapultClient type
 type CatapultClient struct {
@@ -12,7 +14,7 @@ type CatapultClient struct {
 }
 
 // AddBlockHandlers provides a mock function with given fields: handlers
-func (_m *CatapultClient) AddBlockHandlers(handlers ...subscribers.BlockHandler) error {
+func (_m *CatapultClient) AddBlockHandlers(handlers ...subscribers.BlockHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -21,18 +23,27 @@ func (_m *CatapultClient) AddBlockHandlers(handlers ...subscribers.BlockHandler)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(...subscribers.BlockHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(...subscribers.BlockHandler) *websocket.Subscription); ok {
 		r0 = rf(handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(...subscribers.BlockHandler) error); ok {
+		r1 = rf(handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddConfirmedAddedHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+func (_m *CatapultClient) AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -42,18 +53,27 @@ func (_m *CatapultClient) AddConfirmedAddedHandlers(address *sdk.Address, handle
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.ConfirmedAddedHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.ConfirmedAddedHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.ConfirmedAddedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddCosignatureHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error {
+func (_m *CatapultClient) AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -63,18 +83,27 @@ func (_m *CatapultClient) AddCosignatureHandlers(address *sdk.Address, handlers
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.CosignatureHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.CosignatureHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.CosignatureHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddDriveStateHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) error {
+func (_m *CatapultClient) AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -84,18 +113,27 @@ func (_m *CatapultClient) AddDriveStateHandlers(address *sdk.Address, handlers .
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.DriveStateHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.DriveStateHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.DriveStateHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddPartialAddedHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error {
+func (_m *CatapultClient) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -105,18 +143,27 @@ func (_m *CatapultClient) AddPartialAddedHandlers(address *sdk.Address, handlers
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.PartialAddedHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.PartialAddedHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.PartialAddedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddPartialRemovedHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) error {
+func (_m *CatapultClient) AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -126,18 +173,27 @@ func (_m *CatapultClient) AddPartialRemovedHandlers(address *sdk.Address, handle
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.PartialRemovedHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.PartialRemovedHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.PartialRemovedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddStatusHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) error {
+func (_m *CatapultClient) AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -147,18 +203,27 @@ func (_m *CatapultClient) AddStatusHandlers(address *sdk.Address, handlers ...su
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.StatusHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.StatusHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.StatusHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddUnconfirmedAddedHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
+func (_m *CatapultClient) AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -168,18 +233,27 @@ func (_m *CatapultClient) AddUnconfirmedAddedHandlers(address *sdk.Address, hand
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.UnconfirmedAddedHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.UnconfirmedAddedHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.UnconfirmedAddedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AddUnconfirmedRemovedHandlers provides a mock function with given fields: address, handlers
-func (_m *CatapultClient) AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) error {
+func (_m *CatapultClient) AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) (*websocket.Subscription, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -189,14 +263,23 @@ func (_m *CatapultClient) AddUnconfirmedRemovedHandlers(address *sdk.Address, ha
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.UnconfirmedRemovedHandler) error); ok {
+	var r0 *websocket.Subscription
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.UnconfirmedRemovedHandler) *websocket.Subscription); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*websocket.Subscription)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.UnconfirmedRemovedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Close provides a mock function with given fields:
@@ -217,3 +300,518 @@ func (_m *CatapultClient) Close() error {
 func (_m *CatapultClient) Listen() {
 	_m.Called()
 }
+
+// RemoveBlockHandlers provides a mock function with given fields:
+func (_m *CatapultClient) RemoveBlockHandlers() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveConfirmedAddedHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemoveConfirmedAddedHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveCosignatureHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemoveCosignatureHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveDriveStateHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemoveDriveStateHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemovePartialAddedHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemovePartialAddedHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemovePartialRemovedHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemovePartialRemovedHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveStatusHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemoveStatusHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveUnconfirmedAddedHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemoveUnconfirmedAddedHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveUnconfirmedRemovedHandlers provides a mock function with given fields: address
+func (_m *CatapultClient) RemoveUnconfirmedRemovedHandlers(address *sdk.Address) error {
+	ret := _m.Called(address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unsubscribe provides a mock function with given fields: address, channel
+func (_m *CatapultClient) Unsubscribe(address *sdk.Address, channel websocket.Path) error {
+	ret := _m.Called(address, channel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.Path) error); ok {
+		r0 = rf(address, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeBlocks provides a mock function with given fields: ctx, opts
+func (_m *CatapultClient) SubscribeBlocks(ctx context.Context, opts ...websocket.StreamOption) (<-chan *sdk.BlockInfo, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context, ...websocket.StreamOption) <-chan *sdk.BlockInfo); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.BlockInfo)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeConfirmed provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribeConfirmed(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan sdk.Transaction, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan sdk.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan sdk.Transaction); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan sdk.Transaction)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeUnconfirmedAdded provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribeUnconfirmedAdded(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan sdk.Transaction, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan sdk.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan sdk.Transaction); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan sdk.Transaction)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeUnconfirmedRemoved provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribeUnconfirmedRemoved(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan *sdk.UnconfirmedRemoved, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.UnconfirmedRemoved
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan *sdk.UnconfirmedRemoved); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.UnconfirmedRemoved)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribePartialAdded provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribePartialAdded(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan *sdk.AggregateTransaction, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.AggregateTransaction
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan *sdk.AggregateTransaction); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.AggregateTransaction)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribePartialRemoved provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribePartialRemoved(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan *sdk.PartialRemovedInfo, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.PartialRemovedInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan *sdk.PartialRemovedInfo); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.PartialRemovedInfo)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeStatus provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribeStatus(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan *sdk.StatusInfo, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.StatusInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan *sdk.StatusInfo); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.StatusInfo)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeCosignature provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribeCosignature(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan *sdk.SignerInfo, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.SignerInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan *sdk.SignerInfo); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.SignerInfo)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeDriveState provides a mock function with given fields: ctx, address, opts
+func (_m *CatapultClient) SubscribeDriveState(ctx context.Context, address *sdk.Address, opts ...websocket.StreamOption) (<-chan *sdk.DriveStateInfo, *websocket.Stream, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *sdk.DriveStateInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *sdk.Address, ...websocket.StreamOption) <-chan *sdk.DriveStateInfo); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *sdk.DriveStateInfo)
+		}
+	}
+
+	var r1 *websocket.Stream
+	if rf, ok := ret.Get(1).(func(context.Context, *sdk.Address, ...websocket.StreamOption) *websocket.Stream); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*websocket.Stream)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *sdk.Address, ...websocket.StreamOption) error); ok {
+		r2 = rf(ctx, address, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Metrics provides a mock function with given fields:
+func (_m *CatapultClient) Metrics() map[websocket.Path]websocket.TopicMetrics {
+	ret := _m.Called()
+
+	var r0 map[websocket.Path]websocket.TopicMetrics
+	if rf, ok := ret.Get(0).(func() map[websocket.Path]websocket.TopicMetrics); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[websocket.Path]websocket.TopicMetrics)
+		}
+	}
+
+	return r0
+}
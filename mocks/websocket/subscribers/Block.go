@@ -11,7 +11,7 @@ type Block struct {
 }
 
 // AddHandlers provides a mock function with given fields: handlers
-func (_m *Block) AddHandlers(handlers ...subscribers.BlockHandler) error {
+func (_m *Block) AddHandlers(handlers ...subscribers.BlockHandler) ([]*subscribers.BlockHandler, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -20,14 +20,23 @@ func (_m *Block) AddHandlers(handlers ...subscribers.BlockHandler) error {
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(...subscribers.BlockHandler) error); ok {
+	var r0 []*subscribers.BlockHandler
+	if rf, ok := ret.Get(0).(func(...subscribers.BlockHandler) []*subscribers.BlockHandler); ok {
 		r0 = rf(handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*subscribers.BlockHandler)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(...subscribers.BlockHandler) error); ok {
+		r1 = rf(handlers...)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // GetHandlers provides a mock function with given fields:
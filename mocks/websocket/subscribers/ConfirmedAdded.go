@@ -12,7 +12,7 @@ type ConfirmedAdded struct {
 }
 
 // AddHandlers provides a mock function with given fields: address, handlers
-func (_m *ConfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+func (_m *ConfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) ([]*subscribers.ConfirmedAddedHandler, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -22,14 +22,23 @@ func (_m *ConfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscrib
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.ConfirmedAddedHandler) error); ok {
+	var r0 []*subscribers.ConfirmedAddedHandler
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.ConfirmedAddedHandler) []*subscribers.ConfirmedAddedHandler); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*subscribers.ConfirmedAddedHandler)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.ConfirmedAddedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // GetAddresses provides a mock function with given fields:
@@ -12,7 +12,7 @@ type UnconfirmedAdded struct {
 }
 
 // AddHandlers provides a mock function with given fields: address, handlers
-func (_m *UnconfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
+func (_m *UnconfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) ([]*subscribers.UnconfirmedAddedHandler, error) {
 	_va := make([]interface{}, len(handlers))
 	for _i := range handlers {
 		_va[_i] = handlers[_i]
@@ -22,14 +22,23 @@ func (_m *UnconfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscr
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.UnconfirmedAddedHandler) error); ok {
+	var r0 []*subscribers.UnconfirmedAddedHandler
+	if rf, ok := ret.Get(0).(func(*sdk.Address, ...subscribers.UnconfirmedAddedHandler) []*subscribers.UnconfirmedAddedHandler); ok {
 		r0 = rf(address, handlers...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*subscribers.UnconfirmedAddedHandler)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sdk.Address, ...subscribers.UnconfirmedAddedHandler) error); ok {
+		r1 = rf(address, handlers...)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // GetAddresses provides a mock function with given fields:
@@ -28,7 +28,7 @@ func (a *AccountService) GetAccountProperties(ctx context.Context, address *Addr
 
 	dto := &accountPropertiesDTO{}
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, "GetAccountProperties"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +57,7 @@ func (a *AccountService) GetAccountsProperties(ctx context.Context, addresses ..
 
 	dtos := accountPropertiesDTOs(make([]*accountPropertiesDTO, 0))
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodPost, accountsPropertiesRoute, addrs, &dtos)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, "GetAccountsProperties"}, http.MethodPost, accountsPropertiesRoute, addrs, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +82,7 @@ func (a *AccountService) GetAccountInfo(ctx context.Context, address *Address) (
 
 	dto := &accountInfoDTO{}
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, "GetAccountInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +99,27 @@ func (a *AccountService) GetAccountsInfo(ctx context.Context, addresses ...*Addr
 		return nil, ErrEmptyAddressesIds
 	}
 
+	accountInfos := make([]*AccountInfo, 0, len(addresses))
+
+	// node accepts limited number of addresses in one request
+	err := a.client.forEachBatch(len(addresses), func(from, to int) error {
+		infos, err := a.getAccountsInfo(ctx, addresses[from:to])
+		if err != nil {
+			return err
+		}
+
+		accountInfos = append(accountInfos, infos...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accountInfos, nil
+}
+
+func (a *AccountService) getAccountsInfo(ctx context.Context, addresses []*Address) ([]*AccountInfo, error) {
 	addrs := struct {
 		Messages []string `json:"addresses"`
 	}{
@@ -111,7 +132,7 @@ func (a *AccountService) GetAccountsInfo(ctx context.Context, addresses ...*Addr
 
 	dtos := accountInfoDTOs(make([]*accountInfoDTO, 0))
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodPost, accountsRoute, addrs, &dtos)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, "GetAccountsInfo"}, http.MethodPost, accountsRoute, addrs, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +153,7 @@ func (a *AccountService) GetMultisigAccountInfo(ctx context.Context, address *Ad
 
 	dto := &multisigAccountInfoDTO{}
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, "GetMultisigAccountInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +174,7 @@ func (a *AccountService) GetMultisigAccountGraphInfo(ctx context.Context, addres
 
 	dto := &multisigAccountGraphInfoDTOS{}
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, "GetMultisigAccountGraphInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +196,7 @@ func (ref *AccountService) GetAccountNames(ctx context.Context, addr ...*Address
 
 	dtos := accountNamesDTOs(make([]*accountNamesDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, accountNamesRoute, &addresses{addr}, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetAccountNames"}, http.MethodPost, accountNamesRoute, &addresses{addr}, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -189,29 +210,29 @@ func (ref *AccountService) GetAccountNames(ctx context.Context, addr ...*Address
 
 // returns an array of confirmed Transaction's for which passed account is sender or receiver.
 func (a *AccountService) Transactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption) ([]Transaction, error) {
-	return a.findTransactions(ctx, account, opt, accountTransactionsRoute)
+	return a.findTransactions(ctx, "Transactions", account, opt, accountTransactionsRoute)
 }
 
 // returns an array of Transaction's for which passed account is receiver
 func (a *AccountService) IncomingTransactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption) ([]Transaction, error) {
-	return a.findTransactions(ctx, account, opt, incomingTransactionsRoute)
+	return a.findTransactions(ctx, "IncomingTransactions", account, opt, incomingTransactionsRoute)
 }
 
 // returns an array of Transaction's for which passed account is sender
 func (a *AccountService) OutgoingTransactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption) ([]Transaction, error) {
-	return a.findTransactions(ctx, account, opt, outgoingTransactionsRoute)
+	return a.findTransactions(ctx, "OutgoingTransactions", account, opt, outgoingTransactionsRoute)
 }
 
 // returns an array of confirmed Transaction's for which passed account is sender or receiver.
 // unconfirmed transactions are those transactions that have not yet been included in a block.
 // they are not guaranteed to be included in any block.
 func (a *AccountService) UnconfirmedTransactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption) ([]Transaction, error) {
-	return a.findTransactions(ctx, account, opt, unconfirmedTransactionsRoute)
+	return a.findTransactions(ctx, "UnconfirmedTransactions", account, opt, unconfirmedTransactionsRoute)
 }
 
 // returns an array of AggregateTransaction's where passed account is signer or cosigner
 func (a *AccountService) AggregateBondedTransactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption) ([]*AggregateTransaction, error) {
-	txs, err := a.findTransactions(ctx, account, opt, aggregateTransactionsRoute)
+	txs, err := a.findTransactions(ctx, "AggregateBondedTransactions", account, opt, aggregateTransactionsRoute)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +245,7 @@ func (a *AccountService) AggregateBondedTransactions(ctx context.Context, accoun
 	return atxs, nil
 }
 
-func (a *AccountService) findTransactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption, path string) ([]Transaction, error) {
+func (a *AccountService) findTransactions(ctx context.Context, operation string, account *PublicAccount, opt *AccountTransactionsOption, path string) ([]Transaction, error) {
 	if account == nil {
 		return nil, ErrNilAccount
 	}
@@ -236,7 +257,7 @@ func (a *AccountService) findTransactions(ctx context.Context, account *PublicAc
 		return nil, err
 	}
 
-	resp, err := a.client.doNewRequest(ctx, http.MethodGet, u, nil, &b)
+	resp, err := a.client.doNewRequest(ctx, RequestOperation{a.name, operation}, http.MethodGet, u, nil, &b)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,15 @@ import (
 
 const EmptyPublicKey = "0000000000000000000000000000000000000000000000000000000000000000"
 
+// Signer is a source of signatures for a single account.
+// It allows to keep private key outside of the process, e.g. in HSM, remote signing daemon or hardware wallet
+type Signer interface {
+	// returns PublicAccount which signatures are produced for
+	SignerAccount() *PublicAccount
+	// signs passed data and returns ed25519 signature of it
+	SignBytes(data []byte) (*Signature, error)
+}
+
 type Account struct {
 	*PublicAccount
 	*crypto.KeyPair
@@ -23,19 +32,45 @@ type Account struct {
 }
 
 func (a *Account) Sign(tx Transaction) (*SignedTransaction, error) {
-	return signTransactionWith(tx, a)
+	return signTransactionWith(tx, a, a.generationHash)
 }
 
 // sign AggregateTransaction with current Account and with every passed cosignatory Account's
 // returns announced Aggregate SignedTransaction
 func (a *Account) SignWithCosignatures(tx *AggregateTransaction, cosignatories []*Account) (*SignedTransaction, error) {
-	return signTransactionWithCosignatures(tx, a, cosignatories)
+	signers := make([]Signer, len(cosignatories))
+	for i, cos := range cosignatories {
+		signers[i] = cos
+	}
+
+	return signTransactionWithCosignatures(tx, a, signers, a.generationHash)
 }
 
 func (a *Account) SignCosignatureTransaction(tx *CosignatureTransaction) (*CosignatureSignedTransaction, error) {
 	return signCosignatureTransaction(a, tx)
 }
 
+// returns generation hash of network which current Account signs transactions for
+func (a *Account) GenerationHash() *Hash {
+	return a.generationHash
+}
+
+// returns PublicAccount of current Account
+func (a *Account) SignerAccount() *PublicAccount {
+	return a.PublicAccount
+}
+
+// signs passed data with private key of current Account
+func (a *Account) SignBytes(data []byte) (*Signature, error) {
+	s := crypto.NewSignerFromKeyPair(a.KeyPair, nil)
+	sb, err := s.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytesToSignature(sb.Bytes())
+}
+
 func (a *Account) EncryptMessage(message string, recipientPublicAccount *PublicAccount) (*SecureMessage, error) {
 	rpk, err := crypto.NewPublicKeyfromHex(recipientPublicAccount.PublicKey)
 
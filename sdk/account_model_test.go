@@ -5,6 +5,14 @@
 package sdk
 
 import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,3 +82,169 @@ func TestEncryptMessageAndDecryptMessage(t *testing.T) {
 
 	assert.Equal(t, message, plainMessage.Message())
 }
+
+// socketSigner is a stand-in for a remote signing daemon, private key is kept by process behind unix socket
+type socketSigner struct {
+	sync.Mutex
+	conn    net.Conn
+	account *PublicAccount
+}
+
+func (s *socketSigner) SignerAccount() *PublicAccount {
+	return s.account
+}
+
+func (s *socketSigner) SignBytes(data []byte) (*Signature, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(data)))
+
+	if _, err := s.conn.Write(append(size, data...)); err != nil {
+		return nil, err
+	}
+
+	signature := &Signature{}
+	if _, err := io.ReadFull(s.conn, signature[:]); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+func serveSigner(t *testing.T, l net.Listener, acc *Account) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		size := make([]byte, 4)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return
+		}
+
+		data := make([]byte, binary.LittleEndian.Uint32(size))
+		if _, err := io.ReadFull(conn, data); err != nil {
+			return
+		}
+
+		signature, err := acc.SignBytes(data)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if _, err := conn.Write(signature[:]); err != nil {
+			return
+		}
+	}
+}
+
+func newSocketSigner(t *testing.T, privateKey string) (*socketSigner, *Account, func()) {
+	acc, err := NewAccountFromPrivateKey(privateKey, MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	dir, err := ioutil.TempDir("", "signer")
+	assert.Nilf(t, err, "ioutil.TempDir returned error: %s", err)
+
+	l, err := net.Listen("unix", filepath.Join(dir, "signer.sock"))
+	assert.Nilf(t, err, "net.Listen returned error: %s", err)
+
+	go serveSigner(t, l, acc)
+
+	conn, err := net.Dial("unix", l.Addr().String())
+	assert.Nilf(t, err, "net.Dial returned error: %s", err)
+
+	pa, err := NewAccountFromPublicKey(acc.PublicAccount.PublicKey, MijinTest)
+	assert.Nilf(t, err, "NewAccountFromPublicKey returned error: %s", err)
+
+	return &socketSigner{conn: conn, account: pa}, acc, func() {
+		conn.Close()
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSignTransaction_SocketSigner(t *testing.T) {
+	signer, acc, closeFn := newSocketSigner(t, "2a2b1f5d366a5dd5dc56c3c757cf4fe6c66e2787087692cf329d7a49a594658b")
+	defer closeFn()
+
+	tx, err := NewTransferTransaction(
+		fakeDeadline,
+		NewAddress("SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC", MijinTest),
+		[]*Mosaic{Xpx(10000)},
+		NewPlainMessage("test-message"),
+		MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	expected, err := acc.Sign(tx)
+	assert.Nilf(t, err, "Account.Sign returned error: %s", err)
+
+	stx, err := SignTransaction(signer, tx, GenerationHash)
+	assert.Nilf(t, err, "SignTransaction returned error: %s", err)
+
+	assert.Equal(t, expected, stx)
+}
+
+func TestSignTransactionWithCosignatures_SocketSigner(t *testing.T) {
+	signer, acc, closeSigner := newSocketSigner(t, "2a2b1f5d366a5dd5dc56c3c757cf4fe6c66e2787087692cf329d7a49a594658b")
+	defer closeSigner()
+
+	cosigner, cosAcc, closeCosigner := newSocketSigner(t, "b8afae6f4ad13a1b8aad047b488e0738a437c7389d4ff30c359ac068910c1d59")
+	defer closeCosigner()
+
+	ttx, err := NewTransferTransaction(
+		fakeDeadline,
+		NewAddress("SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC", MijinTest),
+		[]*Mosaic{},
+		NewPlainMessage("test-message"),
+		MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+	ttx.ToAggregate(cosAcc.PublicAccount)
+
+	atx, err := NewCompleteAggregateTransaction(fakeDeadline, []Transaction{ttx}, MijinTest)
+	assert.Nilf(t, err, "NewCompleteAggregateTransaction returned error: %s", err)
+
+	expected, err := acc.SignWithCosignatures(atx, []*Account{cosAcc})
+	assert.Nilf(t, err, "Account.SignWithCosignatures returned error: %s", err)
+
+	stx, err := SignTransactionWithCosignatures(signer, atx, []Signer{cosigner}, GenerationHash)
+	assert.Nilf(t, err, "SignTransactionWithCosignatures returned error: %s", err)
+
+	assert.Equal(t, expected.Hash, stx.Hash)
+	assert.Equal(t, strings.ToUpper(expected.Payload), strings.ToUpper(stx.Payload))
+
+	atx.TransactionInfo = TransactionInfo{TransactionHash: stx.Hash}
+	ctx, err := NewCosignatureTransaction(atx)
+	assert.Nilf(t, err, "NewCosignatureTransaction returned error: %s", err)
+
+	expectedCosignature, err := cosAcc.SignCosignatureTransaction(ctx)
+	assert.Nilf(t, err, "Account.SignCosignatureTransaction returned error: %s", err)
+
+	cosignature, err := SignCosignatureTransaction(cosigner, ctx)
+	assert.Nilf(t, err, "SignCosignatureTransaction returned error: %s", err)
+
+	assert.Equal(t, expectedCosignature, cosignature)
+}
+
+func TestSignTransaction_InvalidSigner(t *testing.T) {
+	tx, err := NewTransferTransaction(
+		fakeDeadline,
+		NewAddress("SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC", MijinTest),
+		[]*Mosaic{},
+		NewPlainMessage(""),
+		MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	_, err = SignTransaction(nil, tx, GenerationHash)
+	assert.Equal(t, ErrNilSigner, err)
+
+	_, err = SignTransaction(&socketSigner{account: &PublicAccount{PublicKey: "AB"}}, tx, GenerationHash)
+	assert.Equal(t, ErrInvalidSignerPublicKey, err)
+}
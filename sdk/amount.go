@@ -0,0 +1,176 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// returns raw Amount parsed from decimal string for mosaic with passed divisibility,
+// e.g. "12.345" is 12345000 for divisibility 6.
+// Amounts with non-zero digits beyond divisibility or which exceed max value of amount are rejected
+func ParseAmount(s string, divisibility uint8) (Amount, error) {
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return 0, ErrInvalidAmount
+	}
+
+	// zeros beyond divisibility don't change amount
+	if len(fraction) > int(divisibility) {
+		if strings.TrimRight(fraction[divisibility:], "0") != "" {
+			return 0, ErrAmountPrecision
+		}
+
+		fraction = fraction[:divisibility]
+	}
+
+	fraction += strings.Repeat("0", int(divisibility)-len(fraction))
+
+	amount := uint64(0)
+	for _, c := range integer + fraction {
+		hi, lo := bits.Mul64(amount, 10)
+		lo, carry := bits.Add64(lo, uint64(c-'0'), 0)
+		if hi != 0 || carry != 0 {
+			return 0, ErrAmountOverflow
+		}
+
+		amount = lo
+	}
+
+	return Amount(amount), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// returns decimal string of raw Amount for mosaic with passed divisibility, e.g. 12345000 is "12.345000" for divisibility 6.
+// All fractional digits are written, so string is parsed by ParseAmount to the same Amount
+func FormatAmount(amount Amount, divisibility uint8) string {
+	s := strconv.FormatUint(uint64(amount), 10)
+	if divisibility == 0 {
+		return s
+	}
+
+	if len(s) <= int(divisibility) {
+		s = strings.Repeat("0", int(divisibility)-len(s)+1) + s
+	}
+
+	point := len(s) - int(divisibility)
+
+	return s[:point] + "." + s[point:]
+}
+
+// returns Mosaic with raw amount of passed decimal amount, divisibility is taken from passed MosaicInfo
+func NewMosaicFromDecimal(assetId AssetId, amount string, info *MosaicInfo) (*Mosaic, error) {
+	if info == nil || info.Properties == nil {
+		return nil, ErrNilMosaicProperties
+	}
+
+	a, err := ParseAmount(amount, info.Properties.Divisibility)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMosaic(assetId, a)
+}
+
+// returns AssetId from name of namespace or hex id of mosaic
+func parseAssetId(s string) (AssetId, error) {
+	if len(s) == 2*BaseInt64Size {
+		if id, err := strconv.ParseUint(s, 16, 64); err == nil {
+			return NewMosaicId(id)
+		}
+	}
+
+	return NewNamespaceIdFromName(s)
+}
+
+// returns Mosaic parsed from decimal amount followed by mosaic, e.g. "12.345 prx.xpx".
+// Mosaic is either name of namespace linked to mosaic or hex id of mosaic, divisibility is taken from MosaicInfo
+func (ref *ResolverService) ParseMosaic(ctx context.Context, s string) (*Mosaic, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return nil, ErrInvalidMosaicString
+	}
+
+	assetId, err := parseAssetId(fields[1])
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := ref.GetMosaicInfoByAssetId(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMosaicFromDecimal(assetId, fields[0], info)
+}
+
+// returns decimal amount of Mosaic followed by its name, e.g. "12.345000 prx.xpx".
+// Name of mosaic is the first namespace linked to it, hex id of mosaic is used if mosaic doesn't have names
+func (ref *ResolverService) FormatMosaic(ctx context.Context, mosaic *Mosaic) (string, error) {
+	if mosaic == nil {
+		return "", ErrNilMosaic
+	}
+
+	info, err := ref.GetMosaicInfoByAssetId(ctx, mosaic.AssetId)
+	if err != nil {
+		return "", err
+	}
+
+	if info.Properties == nil {
+		return "", ErrNilMosaicProperties
+	}
+
+	name, err := ref.assetName(ctx, mosaic.AssetId)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatAmount(mosaic.Amount, info.Properties.Divisibility) + " " + name, nil
+}
+
+func (ref *ResolverService) assetName(ctx context.Context, assetId AssetId) (string, error) {
+	switch id := assetId.(type) {
+	case *NamespaceId:
+		names, err := ref.NamespaceService.GetNamespaceNames(ctx, []*NamespaceId{id})
+		if err != nil {
+			return "", err
+		}
+
+		for _, name := range names {
+			if name.NamespaceId.Id() == id.Id() {
+				return name.FullName, nil
+			}
+		}
+	case *MosaicId:
+		names, err := ref.MosaicService.GetMosaicsNames(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		for _, name := range names {
+			if name.MosaicId.Id() == id.Id() && len(name.Names) > 0 {
+				return name.Names[0], nil
+			}
+		}
+	}
+
+	return uint64ToHex(assetId.Id()), nil
+}
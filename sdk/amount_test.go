@@ -0,0 +1,130 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMosaicNameJson = `[
+   {
+      "mosaicId":[
+         298950589,
+         1817567325
+      ],
+      "names":[
+         "prx.test"
+      ]
+   }
+]`
+
+func TestParseAmount(t *testing.T) {
+	maxAmount := uint64(1<<64 - 1)
+
+	tests := []struct {
+		s            string
+		divisibility uint8
+		amount       Amount
+		err          error
+	}{
+		{"12.345", 6, 12345000, nil},
+		{"12", 6, 12000000, nil},
+		{".5", 6, 500000, nil},
+		{"7.", 2, 700, nil},
+		{"0.000001", 6, 1, nil},
+		{"1.2300", 2, 123, nil},
+		{"42", 0, 42, nil},
+		{"18446744073709551615", 0, Amount(maxAmount), nil},
+		{"18446744073709.551615", 6, Amount(maxAmount), nil},
+		{"18446744073709551616", 0, 0, ErrAmountOverflow},
+		{"18446744073709.551616", 6, 0, ErrAmountOverflow},
+		{"0.0000001", 6, 0, ErrAmountPrecision},
+		{"1.5", 0, 0, ErrAmountPrecision},
+		{"", 6, 0, ErrInvalidAmount},
+		{".", 6, 0, ErrInvalidAmount},
+		{"-1", 6, 0, ErrInvalidAmount},
+		{"1e6", 6, 0, ErrInvalidAmount},
+		{"1.2.3", 6, 0, ErrInvalidAmount},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseAmount(test.s, test.divisibility)
+		assert.Equal(t, test.err, err, test.s)
+		assert.Equal(t, test.amount, amount, test.s)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	maxAmount := uint64(1<<64 - 1)
+
+	tests := []struct {
+		amount       Amount
+		divisibility uint8
+		s            string
+	}{
+		{12345000, 6, "12.345000"},
+		{1, 6, "0.000001"},
+		{0, 6, "0.000000"},
+		{123, 2, "1.23"},
+		{42, 0, "42"},
+		{Amount(maxAmount), 6, "18446744073709.551615"},
+	}
+
+	for _, test := range tests {
+		s := FormatAmount(test.amount, test.divisibility)
+		assert.Equal(t, test.s, s)
+
+		amount, err := ParseAmount(s, test.divisibility)
+		assert.Nilf(t, err, "ParseAmount returned error: %s", err)
+		assert.Equal(t, test.amount, amount)
+	}
+}
+
+func TestResolverService_ParseMosaic(t *testing.T) {
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     fmt.Sprintf(mosaicRoute, testMosaicPathID),
+		RespBody: testMosaicInfoJson,
+	})
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+
+	mosaic, err := client.Resolve.ParseMosaic(ctx, "12.345 "+testMosaicPathID)
+	assert.Nilf(t, err, "ResolverService.ParseMosaic returned error: %s", err)
+	assert.Equal(t, mosaicCorr.MosaicId.Id(), mosaic.AssetId.Id())
+	assert.Equal(t, Amount(12345000), mosaic.Amount)
+
+	_, err = client.Resolve.ParseMosaic(ctx, "0.0000001 "+testMosaicPathID)
+	assert.Equal(t, ErrAmountPrecision, err)
+
+	_, err = client.Resolve.ParseMosaic(ctx, "12.345")
+	assert.Equal(t, ErrInvalidMosaicString, err)
+}
+
+func TestResolverService_FormatMosaic(t *testing.T) {
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     fmt.Sprintf(mosaicRoute, testMosaicPathID),
+		RespBody: testMosaicInfoJson,
+	})
+	defer mockServ.Close()
+
+	mockServ.AddRouter(&mock.Router{
+		Path:     mosaicNamesRoute,
+		RespBody: testMosaicNameJson,
+		ReqJsonBodyStruct: struct {
+			MosaicIds []string `json:"mosaicIds"`
+		}{},
+	})
+
+	client := mockServ.getPublicTestClientUnsafe()
+
+	s, err := client.Resolve.FormatMosaic(ctx, &Mosaic{mosaicCorr.MosaicId, 12345000})
+	assert.Nilf(t, err, "ResolverService.FormatMosaic returned error: %s", err)
+	assert.Equal(t, "12.345000 prx.test", s)
+}
@@ -21,11 +21,15 @@ func (b *BlockchainService) GetBlockByHeight(ctx context.Context, height Height)
 		return nil, ErrNilOrZeroHeight
 	}
 
+	if v, ok := b.client.Cache.get(blockCacheOperation, height.String()); ok {
+		return v.(*BlockInfo), nil
+	}
+
 	u := fmt.Sprintf(blockByHeightRoute, height)
 
 	dto := &blockInfoDTO{}
 
-	resp, err := b.client.doNewRequest(ctx, http.MethodGet, u, nil, &dto)
+	resp, err := b.client.doNewRequest(ctx, RequestOperation{b.name, "GetBlockByHeight"}, http.MethodGet, u, nil, &dto)
 	if err != nil {
 		return nil, err
 	}
@@ -34,20 +38,37 @@ func (b *BlockchainService) GetBlockByHeight(ctx context.Context, height Height)
 		return nil, err
 	}
 
-	return dto.toStruct()
+	block, err := dto.toStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	if b.client.Cache.isConfirmed(ctx, block.Height) {
+		b.client.Cache.set(blockCacheOperation, block.Height.String(), block)
+	}
+
+	return block, nil
 }
 
-// returns Transaction's inside of block at passed height
+// returns Transaction's inside of block at passed height.
+// Node returns only the first page of transactions, use BlockTransactionsIterator to get all of them
 func (b *BlockchainService) GetBlockTransactions(ctx context.Context, height Height) ([]Transaction, error) {
+	return b.getBlockTransactions(ctx, height, nil)
+}
+
+func (b *BlockchainService) getBlockTransactions(ctx context.Context, height Height, opt *AccountTransactionsOption) ([]Transaction, error) {
 	if height == 0 {
 		return nil, ErrNilOrZeroHeight
 	}
 
-	url := net.NewUrl(fmt.Sprintf(blockGetTransactionRoute, height))
+	u, err := addOptions(fmt.Sprintf(blockGetTransactionRoute, height), opt)
+	if err != nil {
+		return nil, err
+	}
 
 	var data bytes.Buffer
 
-	resp, err := b.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, &data)
+	resp, err := b.client.doNewRequest(ctx, RequestOperation{b.name, "GetBlockTransactions"}, http.MethodGet, u, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +95,7 @@ func (b *BlockchainService) GetBlocksByHeightWithLimit(ctx context.Context, heig
 
 	dtos := blockInfoDTOs(make([]*blockInfoDTO, 0))
 
-	resp, err := b.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, &dtos)
+	resp, err := b.client.doNewRequest(ctx, RequestOperation{b.name, "GetBlocksByHeightWithLimit"}, http.MethodGet, url.Encode(), nil, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +104,22 @@ func (b *BlockchainService) GetBlocksByHeightWithLimit(ctx context.Context, heig
 		return nil, err
 	}
 
-	return dtos.toStruct()
+	blocks, err := dtos.toStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	if b.client.Cache.enabled(blockCacheOperation) {
+		b.client.Cache.isConfirmed(ctx, height)
+
+		for _, block := range blocks {
+			if b.client.Cache.confirmed(block.Height) {
+				b.client.Cache.set(blockCacheOperation, block.Height.String(), block)
+			}
+		}
+	}
+
+	return blocks, nil
 }
 
 func (b *BlockchainService) GetBlockchainHeight(ctx context.Context) (Height, error) {
@@ -91,7 +127,7 @@ func (b *BlockchainService) GetBlockchainHeight(ctx context.Context) (Height, er
 		Height uint64DTO `json:"height"`
 	}{}
 
-	resp, err := b.client.doNewRequest(ctx, http.MethodGet, blockHeightRoute, nil, &bh)
+	resp, err := b.client.doNewRequest(ctx, RequestOperation{b.name, "GetBlockchainHeight"}, http.MethodGet, blockHeightRoute, nil, &bh)
 	if err != nil {
 		return 0, err
 	}
@@ -100,12 +136,15 @@ func (b *BlockchainService) GetBlockchainHeight(ctx context.Context) (Height, er
 		return 0, err
 	}
 
-	return bh.Height.toStruct(), nil
+	height := bh.Height.toStruct()
+	b.client.Cache.observeHeight(height)
+
+	return height, nil
 }
 
 func (b *BlockchainService) GetBlockchainScore(ctx context.Context) (*ChainScore, error) {
 	cs := &chainScoreDTO{}
-	resp, err := b.client.doNewRequest(ctx, http.MethodGet, blockScoreRoute, nil, &cs)
+	resp, err := b.client.doNewRequest(ctx, RequestOperation{b.name, "GetBlockchainScore"}, http.MethodGet, blockScoreRoute, nil, &cs)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +158,7 @@ func (b *BlockchainService) GetBlockchainScore(ctx context.Context) (*ChainScore
 
 func (b *BlockchainService) GetBlockchainStorage(ctx context.Context) (*BlockchainStorageInfo, error) {
 	bstorage := &BlockchainStorageInfo{}
-	resp, err := b.client.doNewRequest(ctx, http.MethodGet, blockStorageRoute, nil, &bstorage)
+	resp, err := b.client.doNewRequest(ctx, RequestOperation{b.name, "GetBlockchainStorage"}, http.MethodGet, blockStorageRoute, nil, &bstorage)
 	if err != nil {
 		return nil, err
 	}
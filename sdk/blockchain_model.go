@@ -29,6 +29,8 @@ type BlockInfo struct {
 	Beneficiary            *PublicAccount
 	FeeInterest            uint32
 	FeeInterestDenominator uint32
+	// is true when websocket client delivers block fetched through REST after reconnection
+	Backfilled bool
 }
 
 func (b *BlockInfo) String() string {
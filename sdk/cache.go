@@ -0,0 +1,344 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultCacheCapacity = 10000
+	// time to live of data which can be changed by transactions, e.g. aliases of namespaces
+	DefaultCacheMutableTTL = time.Minute
+	// blocks deeper than maxRollbackBlocks of network are never rolled back
+	DefaultCacheConfirmationDepth = Height(40)
+	// height of blockchain isn't requested by cache more often than once per block
+	cacheHeightRefreshInterval = time.Second * 15
+)
+
+// Cache stores results of REST requests, implementations should be safe for concurrent use
+type Cache interface {
+	// returns value of key and true if value is present and not expired
+	Get(key string) (interface{}, bool)
+	// stores value of key, value expires after ttl. Value never expires if ttl is 0
+	Set(key string, value interface{}, ttl time.Duration)
+	Delete(key string)
+	// removes all values
+	Purge()
+}
+
+// LRUCache is an in-memory Cache which evicts the least recently used value when capacity is reached
+type LRUCache struct {
+	sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type lruEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// returns LRUCache which keeps at most capacity values, DefaultCacheCapacity is used if capacity is not positive
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = DefaultCacheCapacity
+	}
+
+	return &LRUCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache) Get(key string) (interface{}, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry := &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRUCache) Delete(key string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+func (c *LRUCache) Purge() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// returns number of values in cache including expired ones
+func (c *LRUCache) Len() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRUCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
+
+// CacheConfig describes which results of Client are cached
+type CacheConfig struct {
+	// storage of cached values, in-memory LRUCache is used if it is nil
+	Cache Cache
+	// operations whose results are cached with time to live of results, results never expire if time to live is 0.
+	// results of other operations are never cached
+	Rules map[RequestOperation]time.Duration
+	// number of blocks on top of block after which block can't be rolled back.
+	// blocks, transactions and network config at heights which are not deep enough are not cached
+	ConfirmationDepth Height
+}
+
+// returns CacheConfig which caches immutable chain data forever and namespaces and mosaics for DefaultCacheMutableTTL
+func DefaultCacheConfig() *CacheConfig {
+	return &CacheConfig{
+		Cache: NewLRUCache(DefaultCacheCapacity),
+		Rules: map[RequestOperation]time.Duration{
+			blockCacheOperation:         0,
+			transactionCacheOperation:   0,
+			networkConfigCacheOperation: 0,
+			namespaceNameCacheOperation: 0,
+			namespaceInfoCacheOperation: DefaultCacheMutableTTL,
+			mosaicInfoCacheOperation:    DefaultCacheMutableTTL,
+		},
+		ConfirmationDepth: DefaultCacheConfirmationDepth,
+	}
+}
+
+// operations under which results are cached, batch requests store every result under operation of single request
+var (
+	blockCacheOperation         = RequestOperation{BlockchainServiceName, "GetBlockByHeight"}
+	transactionCacheOperation   = RequestOperation{TransactionServiceName, "GetTransaction"}
+	networkConfigCacheOperation = RequestOperation{NetworkServiceName, "GetNetworkConfigAtHeight"}
+	namespaceNameCacheOperation = RequestOperation{NamespaceServiceName, "GetNamespaceNames"}
+	namespaceInfoCacheOperation = RequestOperation{NamespaceServiceName, "GetNamespaceInfo"}
+	mosaicInfoCacheOperation    = RequestOperation{MosaicServiceName, "GetMosaicInfo"}
+)
+
+// ChainCache is a read-through cache of Client, it is enabled by Config.Cache.
+// Cached values are shared between callers and shouldn't be modified
+type ChainCache struct {
+	client *Client
+	config *CacheConfig
+
+	heightMutex   sync.RWMutex
+	height        Height
+	heightUpdated time.Time
+}
+
+func newChainCache(client *Client, config *CacheConfig) *ChainCache {
+	if config != nil && config.Cache == nil {
+		copied := *config
+		copied.Cache = NewLRUCache(DefaultCacheCapacity)
+		config = &copied
+	}
+
+	return &ChainCache{client: client, config: config}
+}
+
+// removes cached info of namespace, it should be called when alias of namespace is changed
+func (c *ChainCache) InvalidateNamespace(nsId *NamespaceId) {
+	if nsId == nil {
+		return
+	}
+
+	c.delete(namespaceInfoCacheOperation, nsId.toHexString())
+}
+
+// removes cached info of mosaic, it should be called when supply of mosaic is changed
+func (c *ChainCache) InvalidateMosaic(mosaicId *MosaicId) {
+	if mosaicId == nil {
+		return
+	}
+
+	c.delete(mosaicInfoCacheOperation, mosaicId.toHexString())
+}
+
+// removes cached namespaces and mosaics which are changed by passed confirmed transaction.
+// It can be used as handler of confirmed transactions of websocket client
+func (c *ChainCache) InvalidateTransaction(tx Transaction) {
+	switch t := tx.(type) {
+	case *AddressAliasTransaction:
+		c.InvalidateNamespace(t.NamespaceId)
+	case *MosaicAliasTransaction:
+		c.InvalidateNamespace(t.NamespaceId)
+		c.InvalidateMosaic(t.MosaicId)
+	case *RegisterNamespaceTransaction:
+		c.InvalidateNamespace(t.NamespaceId)
+	case *MosaicDefinitionTransaction:
+		c.InvalidateMosaic(t.MosaicId)
+	case *MosaicSupplyChangeTransaction:
+		c.invalidateAsset(t.AssetId)
+	case *AggregateTransaction:
+		for _, inner := range t.InnerTransactions {
+			c.InvalidateTransaction(inner)
+		}
+	}
+}
+
+// removes all cached values
+func (c *ChainCache) Purge() {
+	if c.config == nil {
+		return
+	}
+
+	c.config.Cache.Purge()
+}
+
+func (c *ChainCache) invalidateAsset(assetId AssetId) {
+	switch id := assetId.(type) {
+	case *MosaicId:
+		c.InvalidateMosaic(id)
+	case *NamespaceId:
+		// mosaic is known only if alias of namespace is cached
+		if v, ok := c.get(namespaceInfoCacheOperation, id.toHexString()); ok {
+			if info := v.(*NamespaceInfo); info.Alias != nil {
+				c.InvalidateMosaic(info.Alias.MosaicId())
+			}
+		}
+	}
+}
+
+// returns true if results of passed operation are cached
+func (c *ChainCache) enabled(op RequestOperation) bool {
+	if c.config == nil {
+		return false
+	}
+
+	_, ok := c.config.Rules[op]
+
+	return ok
+}
+
+func (c *ChainCache) get(op RequestOperation, id string) (interface{}, bool) {
+	if !c.enabled(op) {
+		return nil, false
+	}
+
+	return c.config.Cache.Get(cacheKey(op, id))
+}
+
+func (c *ChainCache) set(op RequestOperation, id string, value interface{}) {
+	if !c.enabled(op) {
+		return
+	}
+
+	c.config.Cache.Set(cacheKey(op, id), value, c.config.Rules[op])
+}
+
+func (c *ChainCache) delete(op RequestOperation, id string) {
+	if c.config == nil {
+		return
+	}
+
+	c.config.Cache.Delete(cacheKey(op, id))
+}
+
+// stores the latest known height of blockchain
+func (c *ChainCache) observeHeight(height Height) {
+	c.heightMutex.Lock()
+	defer c.heightMutex.Unlock()
+
+	c.heightUpdated = time.Now()
+
+	if height > c.height {
+		c.height = height
+	}
+}
+
+// returns true if block at passed height can't be rolled back according to the latest known height of blockchain
+func (c *ChainCache) confirmed(height Height) bool {
+	if c.config == nil || height == 0 {
+		return false
+	}
+
+	c.heightMutex.RLock()
+	defer c.heightMutex.RUnlock()
+
+	return c.height >= height+c.config.ConfirmationDepth
+}
+
+// returns true if block at passed height can't be rolled back.
+// height of blockchain is requested when known height is not enough to confirm block and it wasn't updated for a block time
+func (c *ChainCache) isConfirmed(ctx context.Context, height Height) bool {
+	if c.config == nil || height == 0 {
+		return false
+	}
+
+	if c.confirmed(height) {
+		return true
+	}
+
+	c.heightMutex.RLock()
+	stale := time.Since(c.heightUpdated) > cacheHeightRefreshInterval
+	c.heightMutex.RUnlock()
+
+	if !stale {
+		return false
+	}
+
+	if _, err := c.client.Blockchain.GetBlockchainHeight(ctx); err != nil {
+		return false
+	}
+
+	return c.confirmed(height)
+}
+
+func cacheKey(op RequestOperation, id string) string {
+	return op.String() + "/" + strings.ToUpper(id)
+}
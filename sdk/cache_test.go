@@ -0,0 +1,209 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cacheServer serves fixed bodies and counts requests by path
+type cacheServer struct {
+	sync.Mutex
+	*httptest.Server
+	bodies   map[string]string
+	requests map[string]int
+}
+
+func newCacheServer(bodies map[string]string) *cacheServer {
+	s := &cacheServer{bodies: bodies, requests: make(map[string]int)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		s.requests[r.URL.Path]++
+		body, ok := s.bodies[r.URL.Path]
+		s.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+
+	return s
+}
+
+func (s *cacheServer) count(path string) int {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.requests[path]
+}
+
+func (s *cacheServer) client(t *testing.T) *Client {
+	conf, err := NewConfigWithReputation([]string{s.URL}, PublicTest, &defaultRepConfig, DefaultWebsocketReconnectionTimeout, nil, DefaultFeeCalculationStrategy)
+	assert.Nilf(t, err, "NewConfigWithReputation returned error: %s", err)
+
+	conf.RetryPolicy = NoRetryPolicy()
+	conf.Cache = DefaultCacheConfig()
+
+	return NewClient(nil, conf)
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	cache.Set("a", 1, 0)
+	cache.Set("b", 2, 0)
+
+	v, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// "b" is the least recently used value
+	cache.Set("c", 3, 0)
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Set("d", 4, time.Millisecond)
+	time.Sleep(time.Millisecond * 5)
+	_, ok = cache.Get("d")
+	assert.False(t, ok)
+
+	cache.Delete("c")
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+
+	cache.Purge()
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestChainCache_Disabled(t *testing.T) {
+	server := newCacheServer(map[string]string{
+		"/mosaic/" + testMosaicPathID: testMosaicInfoJson,
+	})
+	defer server.Close()
+
+	client := server.client(t)
+	client.config.Cache = nil
+	client.Cache = newChainCache(client, nil)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Mosaic.GetMosaicInfo(ctx, mosaicCorr.MosaicId)
+		assert.Nilf(t, err, "MosaicService.GetMosaicInfo returned error: %s", err)
+	}
+
+	assert.Equal(t, 2, server.count("/mosaic/"+testMosaicPathID))
+
+	// invalidation of disabled cache does nothing
+	client.Cache.InvalidateMosaic(mosaicCorr.MosaicId)
+	client.Cache.Purge()
+}
+
+func TestChainCache_MosaicInfo(t *testing.T) {
+	path := "/mosaic/" + testMosaicPathID
+	server := newCacheServer(map[string]string{
+		path:         testMosaicInfoJson,
+		mosaicsRoute: "[" + testMosaicInfoJson + "]",
+	})
+	defer server.Close()
+
+	client := server.client(t)
+
+	for i := 0; i < 3; i++ {
+		info, err := client.Mosaic.GetMosaicInfo(ctx, mosaicCorr.MosaicId)
+		assert.Nilf(t, err, "MosaicService.GetMosaicInfo returned error: %s", err)
+		assert.Equal(t, mosaicCorr.MosaicId, info.MosaicId)
+	}
+
+	assert.Equal(t, 1, server.count(path))
+
+	// batch request takes mosaics from cache
+	infos, err := client.Mosaic.GetMosaicInfos(ctx, []*MosaicId{mosaicCorr.MosaicId})
+	assert.Nilf(t, err, "MosaicService.GetMosaicInfos returned error: %s", err)
+	assert.Len(t, infos, 1)
+	assert.Equal(t, 0, server.count(mosaicsRoute))
+
+	client.Cache.InvalidateTransaction(&MosaicSupplyChangeTransaction{AssetId: mosaicCorr.MosaicId})
+
+	_, err = client.Mosaic.GetMosaicInfo(ctx, mosaicCorr.MosaicId)
+	assert.Nilf(t, err, "MosaicService.GetMosaicInfo returned error: %s", err)
+	assert.Equal(t, 2, server.count(path))
+}
+
+func TestChainCache_NamespaceInfo(t *testing.T) {
+	path := fmt.Sprintf(namespaceRoute, testNamespaceId.toHexString())
+	server := newCacheServer(map[string]string{
+		path: tplInfo,
+	})
+	defer server.Close()
+
+	client := server.client(t)
+
+	for i := 0; i < 2; i++ {
+		info, err := client.Namespace.GetNamespaceInfo(ctx, testNamespaceId)
+		assert.Nilf(t, err, "NamespaceService.GetNamespaceInfo returned error: %s", err)
+		assert.Equal(t, testNamespaceId, info.NamespaceId)
+	}
+
+	assert.Equal(t, 1, server.count(path))
+
+	// alias of namespace is re-linked
+	client.Cache.InvalidateTransaction(&AggregateTransaction{
+		InnerTransactions: []Transaction{
+			&MosaicAliasTransaction{AliasTransaction: AliasTransaction{NamespaceId: testNamespaceId}},
+		},
+	})
+
+	_, err := client.Namespace.GetNamespaceInfo(ctx, testNamespaceId)
+	assert.Nilf(t, err, "NamespaceService.GetNamespaceInfo returned error: %s", err)
+	assert.Equal(t, 2, server.count(path))
+}
+
+func TestChainCache_Block(t *testing.T) {
+	path := fmt.Sprintf(blockByHeightRoute, Height(1))
+	server := newCacheServer(map[string]string{
+		path:             blockInfoJSON,
+		blockHeightRoute: `{"height": [10, 0]}`,
+	})
+	defer server.Close()
+
+	client := server.client(t)
+
+	// block isn't deep enough to be cached
+	for i := 0; i < 2; i++ {
+		_, err := client.Blockchain.GetBlockByHeight(ctx, 1)
+		assert.Nilf(t, err, "BlockchainService.GetBlockByHeight returned error: %s", err)
+	}
+
+	assert.Equal(t, 2, server.count(path))
+	// height is requested once per block time
+	assert.Equal(t, 1, server.count(blockHeightRoute))
+
+	server.Lock()
+	server.bodies[blockHeightRoute] = `{"height": [100, 0]}`
+	server.Unlock()
+
+	_, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "BlockchainService.GetBlockchainHeight returned error: %s", err)
+
+	for i := 0; i < 2; i++ {
+		block, err := client.Blockchain.GetBlockByHeight(ctx, 1)
+		assert.Nilf(t, err, "BlockchainService.GetBlockByHeight returned error: %s", err)
+		assert.Equal(t, Height(1), block.Height)
+	}
+
+	assert.Equal(t, 3, server.count(path))
+}
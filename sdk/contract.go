@@ -24,9 +24,9 @@ func (ref *ContractService) GetContractsInfo(ctx context.Context, contractPubKey
 
 	dtos := contractInfoDTOs(make([]*contractInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, contractsInfoRoute, pubKeys, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetContractsInfo"}, http.MethodPost, contractsInfoRoute, pubKeys, &dtos)
 	if err != nil {
-		return nil, errors.Wrapf(err, "within POST request %s", contractsInfoRoute)
+		return nil, fmt.Errorf("within POST request %s: %w", contractsInfoRoute, err)
 	}
 
 	if err = handleResponseStatusCode(resp, map[int]error{409: ErrArgumentNotValid}); err != nil {
@@ -50,9 +50,9 @@ func (ref *ContractService) GetContractsByAddress(ctx context.Context, address s
 
 	dtos := contractInfoDTOs(make([]*contractInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetContractsByAddress"}, http.MethodGet, url.Encode(), nil, &dtos)
 	if err != nil {
-		return nil, errors.Wrapf(err, "within GET request %s", url.Encode())
+		return nil, fmt.Errorf("within GET request %s: %w", url.Encode(), err)
 	}
 
 	if err = handleResponseStatusCode(resp, map[int]error{409: ErrArgumentNotValid}); err != nil {
@@ -4,7 +4,13 @@
 
 package sdk
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
 
 type RespErr struct {
 	msg string
@@ -18,11 +24,166 @@ func (r *RespErr) Error() string {
 	return r.msg
 }
 
+// ValidationError describes rule of network which transaction violates
+type ValidationError struct {
+	EntityType EntityType
+	// path to the invalid field, e.g. "Mosaics[1]" or "InnerTransactions[0].Message"
+	Field string
+	// one of validation errors which describes violated rule
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s of %s transaction: %s", e.Field, e.EntityType, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ApiErrorClass is a class of Catapult REST API errors
+type ApiErrorClass uint8
+
+const (
+	UnknownApiError ApiErrorClass = iota
+	NotFoundApiError
+	InvalidArgumentApiError
+	RateLimitedApiError
+	ConflictApiError
+	ServerApiError
+)
+
+func (c ApiErrorClass) String() string {
+	switch c {
+	case NotFoundApiError:
+		return "NotFound"
+	case InvalidArgumentApiError:
+		return "InvalidArgument"
+	case RateLimitedApiError:
+		return "RateLimited"
+	case ConflictApiError:
+		return "Conflict"
+	case ServerApiError:
+		return "ServerError"
+	default:
+		return "Unknown"
+	}
+}
+
+// returns sentinel error of class, ApiError of class matches it with errors.Is
+func (c ApiErrorClass) sentinel() error {
+	switch c {
+	case NotFoundApiError:
+		return ErrResourceNotFound
+	case InvalidArgumentApiError:
+		return ErrArgumentNotValid
+	case RateLimitedApiError:
+		return ErrRateLimited
+	case ConflictApiError:
+		return ErrConflict
+	case ServerApiError:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// ApiError is an error response of Catapult REST API:
+//
+//	{"code": "ResourceNotFound", "message": "no resource exists with id 'SAONSOGFZZHNEIBRYXHDTDTBR2YSAXKTITRFHG2Y'"}
+//
+// use errors.Is with ErrResourceNotFound, ErrArgumentNotValid, ErrRateLimited, ErrConflict or ErrServerError to check class of error
+type ApiError struct {
+	StatusCode int
+	// code of error returned by node, e.g. "ResourceNotFound" or "InvalidArgument". It is empty if body is not json
+	Code string
+	// message of error returned by node or the whole body if body is not json
+	Message string
+	Class   ApiErrorClass
+	// delay requested by node with Retry-After header, requests to node are paused for this time
+	RetryAfter time.Duration
+}
+
+// HttpError is an old name of ApiError
+//
+// Deprecated: use ApiError
+type HttpError = ApiError
+
+type apiErrorDTO struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// returns ApiError parsed from body of response with passed status code
+func newApiError(statusCode int, body []byte) *ApiError {
+	e := &ApiError{StatusCode: statusCode}
+
+	dto := apiErrorDTO{}
+	if err := json.Unmarshal(body, &dto); err == nil && (dto.Code != "" || dto.Message != "") {
+		e.Code, e.Message = dto.Code, dto.Message
+	} else {
+		e.Message = strings.TrimSpace(string(body))
+	}
+
+	e.Class = classifyApiError(statusCode, e.Code)
+
+	return e
+}
+
+func classifyApiError(statusCode int, code string) ApiErrorClass {
+	switch code {
+	case "ResourceNotFound":
+		return NotFoundApiError
+	case "InvalidArgument", "InvalidContent", "BadRequest":
+		return InvalidArgumentApiError
+	}
+
+	switch {
+	case statusCode == http.StatusNotFound:
+		return NotFoundApiError
+	case statusCode == http.StatusBadRequest:
+		return InvalidArgumentApiError
+	case statusCode == http.StatusTooManyRequests:
+		return RateLimitedApiError
+	case statusCode == http.StatusConflict:
+		return ConflictApiError
+	case statusCode >= http.StatusInternalServerError:
+		return ServerApiError
+	default:
+		return UnknownApiError
+	}
+}
+
+func (e *ApiError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("sdk do request: %d %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("sdk do request: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// returns true if target is sentinel error of class of e.
+// ErrInvalidRequest matches invalid argument errors with status code 400
+func (e *ApiError) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+
+	if target == ErrInvalidRequest {
+		return e.Class == InvalidArgumentApiError && e.StatusCode == http.StatusBadRequest
+	}
+
+	return target == e.Class.sentinel()
+}
+
 // Catapult REST API errors
 var (
 	ErrResourceNotFound              = newRespError("resource is not found")
 	ErrArgumentNotValid              = newRespError("argument is not valid")
 	ErrInvalidRequest                = newRespError("request is not valid")
+	ErrRateLimited                   = newRespError("too many requests")
+	ErrConflict                      = newRespError("request conflicts with state of resource")
+	ErrServerError                   = newRespError("internal error of node")
 	ErrInternalError                 = newRespError("response is nil")
 	ErrNotAcceptedResponseStatusCode = newRespError("not accepted response status code")
 )
@@ -87,7 +248,75 @@ var (
 	ErrNoChanges         = errors.New("transaction should contain changes")
 )
 
+// Transaction payload errors
+var (
+	ErrPayloadTooShort         = errors.New("transaction payload is too short")
+	ErrPayloadSizeMismatch     = errors.New("transaction payload size doesn't match size of transaction")
+	ErrUnsupportedPayloadType  = errors.New("transaction type of payload is not supported")
+	ErrInvalidCosignaturesSize = errors.New("size of aggregate cosignatures is invalid")
+)
+
+// Signer errors
+var (
+	ErrNilSigner              = errors.New("signer should not be nil")
+	ErrInvalidSignerPublicKey = errors.New("public key of signer is invalid")
+)
+
+// Wallet errors
+var (
+	ErrInvalidMnemonic          = errors.New("mnemonic is invalid")
+	ErrInvalidSeedLength        = errors.New("seed length should be between 16 and 64 bytes")
+	ErrInvalidDerivationPath    = errors.New("derivation path is invalid")
+	ErrNonHardenedDerivation    = errors.New("ed25519 supports only hardened derivation")
+	ErrInvalidDiscoveryGapLimit = errors.New("discovery gap limit should be greater than 0")
+)
+
+// Validation errors
+var (
+	ErrNilTransaction    = errors.New("transaction should not be nil")
+	ErrRequiredField     = errors.New("field is required")
+	ErrEmptyField        = errors.New("field should not be empty")
+	ErrTooManyElements   = errors.New("number of elements exceeds network limit")
+	ErrSizeExceeded      = errors.New("size exceeds network limit")
+	ErrDuplicateElement  = errors.New("field contains duplicate elements")
+	ErrValueOutOfRange   = errors.New("value is out of allowed range")
+	ErrDeadlineExpired   = errors.New("deadline is expired")
+	ErrDeadlineTooFar    = errors.New("deadline exceeds max transaction lifetime")
+	ErrUnsupportedEntity = errors.New("entity type or version is not supported by network")
+)
+
+// Fee estimation errors
+var (
+	ErrInvalidFeeTarget = errors.New("target number of blocks should be greater than 0")
+	ErrNoFeeSamples     = errors.New("there are no blocks to estimate fee")
+)
+
+// Node pool errors
+var (
+	ErrNoHealthyNodes = errors.New("there are no healthy nodes in pool")
+)
+
 // reputations error
 var (
 	ErrInvalidReputationConfig = errors.New("default reputation should be greater than 0 and less than 1")
 )
+
+// Offline signing errors
+var (
+	ErrUnsupportedFileVersion    = errors.New("version of offline transaction file is not supported")
+	ErrUnexpectedFileKind        = errors.New("offline transaction file has unexpected kind")
+	ErrFileNetworkMismatch       = errors.New("offline transaction file doesn't match network of transaction")
+	ErrCosignatureMismatch       = errors.New("cosignatures belong to another transaction or network")
+	ErrNotAggregate              = errors.New("transaction is not aggregate")
+	ErrSignedTransactionMismatch = errors.New("hash or signature of signed transaction doesn't match its payload")
+	ErrInvalidCosignature        = errors.New("cosignature is not made by its signer over the transaction hash")
+)
+
+// Amount errors
+var (
+	ErrInvalidAmount       = errors.New("amount is not a valid decimal number")
+	ErrAmountPrecision     = errors.New("amount has more fractional digits than divisibility of mosaic")
+	ErrAmountOverflow      = errors.New("amount exceeds max value of mosaic amount")
+	ErrInvalidMosaicString = errors.New("mosaic should be formatted as amount followed by mosaic name or id")
+	ErrNilMosaic           = errors.New("mosaic must not be nil")
+)
@@ -0,0 +1,82 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewApiError(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		body       string
+		code       string
+		message    string
+		class      ApiErrorClass
+		sentinel   error
+	}{
+		{404, `{"code": "ResourceNotFound", "message": "no resource exists with id 'SAONSOGF'"}`, "ResourceNotFound", "no resource exists with id 'SAONSOGF'", NotFoundApiError, ErrResourceNotFound},
+		{409, `{"code": "InvalidArgument", "message": "accountId has an invalid format"}`, "InvalidArgument", "accountId has an invalid format", InvalidArgumentApiError, ErrArgumentNotValid},
+		{400, `{"code": "InvalidContent", "message": "Invalid JSON"}`, "InvalidContent", "Invalid JSON", InvalidArgumentApiError, ErrInvalidRequest},
+		{409, `{"code": "Conflict", "message": "transaction already exists"}`, "Conflict", "transaction already exists", ConflictApiError, ErrConflict},
+		{429, `Too Many Requests`, "", "Too Many Requests", RateLimitedApiError, ErrRateLimited},
+		{500, `{"code": "Internal", "message": "Internal Server Error"}`, "Internal", "Internal Server Error", ServerApiError, ErrServerError},
+		{503, ``, "", "", ServerApiError, ErrServerError},
+		{418, `{}`, "", "{}", UnknownApiError, nil},
+	}
+
+	for _, test := range tests {
+		err := newApiError(test.statusCode, []byte(test.body))
+
+		assert.Equal(t, test.statusCode, err.StatusCode)
+		assert.Equal(t, test.code, err.Code)
+		assert.Equal(t, test.message, err.Message)
+		assert.Equal(t, test.class, err.Class)
+
+		if test.sentinel != nil {
+			assert.True(t, errors.Is(err, test.sentinel), "%s should match %s", err, test.sentinel)
+		}
+	}
+}
+
+func TestApiError_Is(t *testing.T) {
+	notFound := newApiError(404, []byte(`{"code": "ResourceNotFound", "message": "not found"}`))
+
+	assert.True(t, errors.Is(notFound, ErrResourceNotFound))
+	assert.True(t, errors.Is(fmt.Errorf("within GET request: %w", notFound), ErrResourceNotFound))
+	assert.False(t, errors.Is(notFound, ErrArgumentNotValid))
+	assert.False(t, errors.Is(notFound, ErrServerError))
+
+	invalidArgument := newApiError(409, []byte(`{"code": "InvalidArgument", "message": "invalid"}`))
+	assert.True(t, errors.Is(invalidArgument, ErrArgumentNotValid))
+	assert.False(t, errors.Is(invalidArgument, ErrInvalidRequest))
+	assert.False(t, errors.Is(invalidArgument, ErrConflict))
+
+	assert.Equal(t, "sdk do request: 404 ResourceNotFound: not found", notFound.Error())
+	assert.Equal(t, "sdk do request: 503 Service Unavailable", newApiError(503, []byte("Service Unavailable")).Error())
+}
+
+func TestAccountService_GetAccountInfo_NotFound(t *testing.T) {
+	mockServer.AddRouter(&mock.Router{
+		Path:         fmt.Sprintf(accountRoute, nemTestAddress2),
+		RespHttpCode: http.StatusNotFound,
+		RespBody:     `{"code": "ResourceNotFound", "message": "no resource exists with id '` + nemTestAddress2 + `'"}`,
+	})
+
+	_, err := accountClient.GetAccountInfo(ctx, &Address{MijinTest, nemTestAddress2})
+	assert.True(t, errors.Is(err, ErrResourceNotFound))
+
+	apiErr, ok := err.(*ApiError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "ResourceNotFound", apiErr.Code)
+	assert.Equal(t, NotFoundApiError, apiErr.Class)
+}
@@ -25,7 +25,7 @@ func (e *ExchangeService) GetAccountExchangeInfo(ctx context.Context, account *P
 
 	dto := &exchangeDTO{}
 
-	resp, err := e.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := e.client.doNewRequest(ctx, RequestOperation{e.name, "GetAccountExchangeInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +57,7 @@ func (e *ExchangeService) GetExchangeOfferByAssetId(ctx context.Context, assetId
 
 	dto := &offerInfoDTOs{}
 
-	resp, err := e.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := e.client.doNewRequest(ctx, RequestOperation{e.name, "GetExchangeOfferByAssetId"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
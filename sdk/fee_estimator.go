@@ -0,0 +1,189 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFeeSampleBlocks = 100
+	DefaultFeeCacheTTL     = time.Second * 15
+	DefaultFeeConfidence   = 0.95
+	// node doesn't return more blocks in one request
+	maxFeeSampleBlocks = 100
+)
+
+type FeeEstimatorConfig struct {
+	// number of latest blocks which fee multipliers are sampled
+	SampleBlocks int
+	// how long sampled fee multipliers are reused before blocks are requested again
+	CacheTTL time.Duration
+	// probability of transaction confirmation within target number of blocks
+	Confidence float64
+}
+
+func DefaultFeeEstimatorConfig() *FeeEstimatorConfig {
+	return &FeeEstimatorConfig{
+		SampleBlocks: DefaultFeeSampleBlocks,
+		CacheTTL:     DefaultFeeCacheTTL,
+		Confidence:   DefaultFeeConfidence,
+	}
+}
+
+// FeeEstimator estimates MaxFee of transactions from fee multipliers of latest blocks.
+// Block accepts transaction if MaxFee covers size * fee multiplier * fee interest / fee interest denominator,
+// so fee which is enough for share q of blocks is not confirmed within n blocks with probability (1 - q)^n
+type FeeEstimator struct {
+	sync.Mutex
+	blockchain *BlockchainService
+	config     *FeeEstimatorConfig
+	// effective fee per byte of sampled blocks, sorted ascending
+	rates     []float64
+	sampledAt time.Time
+}
+
+// returns FeeEstimator which samples blocks through passed BlockchainService, default config is used if config is nil
+func NewFeeEstimator(blockchain *BlockchainService, config *FeeEstimatorConfig) *FeeEstimator {
+	if config == nil {
+		config = DefaultFeeEstimatorConfig()
+	}
+
+	if config.SampleBlocks <= 0 || config.SampleBlocks > maxFeeSampleBlocks {
+		config.SampleBlocks = DefaultFeeSampleBlocks
+	}
+
+	if config.Confidence <= 0 || config.Confidence >= 1 {
+		config.Confidence = DefaultFeeConfidence
+	}
+
+	return &FeeEstimator{
+		blockchain: blockchain,
+		config:     config,
+	}
+}
+
+// returns fee per byte which is enough to confirm transaction within targetBlocks blocks
+func (e *FeeEstimator) EstimateFeeRate(ctx context.Context, targetBlocks int) (float64, error) {
+	if targetBlocks <= 0 {
+		return 0, ErrInvalidFeeTarget
+	}
+
+	rates, err := e.sample(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// share of blocks which should accept transaction
+	share := 1 - math.Pow(1-e.config.Confidence, 1/float64(targetBlocks))
+
+	i := int(math.Ceil(share*float64(len(rates)))) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return rates[i], nil
+}
+
+// returns MaxFee which is enough to confirm transaction within targetBlocks blocks.
+// fee of aggregate transaction includes its current cosignatures
+func (e *FeeEstimator) EstimateFee(ctx context.Context, tx Transaction, targetBlocks int) (Amount, error) {
+	if tx == nil {
+		return 0, ErrNilTransaction
+	}
+
+	size := tx.Size()
+	if aggTx, ok := tx.(*AggregateTransaction); ok {
+		size += len(aggTx.Cosignatures) * AggregateCosignatureSize
+	}
+
+	return e.estimateFeeOfSize(ctx, size, targetBlocks)
+}
+
+// returns MaxFee of aggregate transaction which will be signed by passed number of cosigners besides signer
+func (e *FeeEstimator) EstimateAggregateFee(ctx context.Context, tx *AggregateTransaction, cosigners int, targetBlocks int) (Amount, error) {
+	if tx == nil {
+		return 0, ErrNilTransaction
+	}
+
+	return e.estimateFeeOfSize(ctx, tx.Size()+cosigners*AggregateCosignatureSize, targetBlocks)
+}
+
+// sets MaxFee of passed transaction to fee which is enough to confirm it within targetBlocks blocks
+func (e *FeeEstimator) ApplyFee(ctx context.Context, tx Transaction, targetBlocks int) error {
+	fee, err := e.EstimateFee(ctx, tx, targetBlocks)
+	if err != nil {
+		return err
+	}
+
+	tx.GetAbstractTransaction().MaxFee = fee
+
+	return nil
+}
+
+func (e *FeeEstimator) estimateFeeOfSize(ctx context.Context, size int, targetBlocks int) (Amount, error) {
+	rate, err := e.EstimateFeeRate(ctx, targetBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	return Amount(math.Ceil(rate * float64(size))), nil
+}
+
+// returns sorted fee rates of latest blocks, blocks are requested only when cache is expired
+func (e *FeeEstimator) sample(ctx context.Context) ([]float64, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.rates != nil && time.Since(e.sampledAt) < e.config.CacheTTL {
+		return e.rates, nil
+	}
+
+	height, err := e.blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from := Height(1)
+	if height > Height(e.config.SampleBlocks) {
+		from = height - Height(e.config.SampleBlocks) + 1
+	}
+
+	blocks, err := e.blockchain.GetBlocksByHeightWithLimit(ctx, from, Amount(e.config.SampleBlocks))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blocks) == 0 {
+		return nil, ErrNoFeeSamples
+	}
+
+	rates := make([]float64, 0, len(blocks))
+	for _, b := range blocks {
+		rates = append(rates, feeRate(b))
+	}
+
+	sort.Float64s(rates)
+
+	e.rates = rates
+	e.sampledAt = time.Now()
+
+	return rates, nil
+}
+
+// returns fee per byte which was required by block
+func feeRate(b *BlockInfo) float64 {
+	rate := float64(b.FeeMultiplier)
+
+	if b.FeeInterestDenominator != 0 {
+		rate = rate * float64(b.FeeInterest) / float64(b.FeeInterestDenominator)
+	}
+
+	return rate
+}
@@ -0,0 +1,147 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const feeBlockJSONTpl = `{
+	"meta": {
+		"hash": "83FB2550BDB72B6F507BDBDE90C265D4A324DF9F1EFEFD9F7BD0FDF6391C30D8",
+		"generationHash": "8EC49BBADB3B2FD90810DB9BDACF1FDE999295C594B5FD4B584A0A72F5AAFA59",
+		"totalFee": [0, 0],
+		"subCacheMerkleRoots": [],
+		"numTransactions": 0
+	},
+	"block": {
+		"signature": "0BEAE2B3DCDEC268B43797C7A855EC03FDEE0B4687EC14F250D0EA3588ADDD0B42EBB77E14157EAB168B41457CA28395C1EBAB354B0A20CCB5FC73CFA65A3107",
+		"signer": "321DE652C4D3362FC2DDF7800F6582F4A10CFEA134B81F8AB6E4BE78BBA4D18E",
+		"version": -1879048189,
+		"type": 32835,
+		"height": [%d, 0],
+		"timestamp": [0, 0],
+		"difficulty": [276447232, 23283],
+		"feeMultiplier": %d,
+		"previousBlockHash": "0000000000000000000000000000000000000000000000000000000000000000",
+		"blockTransactionsHash": "8A77819676852F20EB7ACDE5A18F7CE060C3D1A61A7EF80A99B3346EB9091B19",
+		"blockReceiptsHash": "C1CCDD2786E301BD384A3E3717FF2383BBFB013FC86E885F0889CD18A3508001",
+		"stateHash": "E563E955B14B1C8A58FBD4B2D8B28F42EF3C2200D6BC8260A693ABCBD43C5BB7",
+		"beneficiary": "0000000000000000000000000000000000000000000000000000000000000000",
+		"feeInterest": %d,
+		"feeInterestDenominator": %d
+	}
+}`
+
+// serves chain with one block for every passed fee multiplier
+func newFeeMock(interest uint32, multipliers ...uint32) *sdkMock {
+	m := newSdkMockWithRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: fmt.Sprintf(`{"height": [%d, 0]}`, len(multipliers)),
+	})
+
+	blocks := make([]string, 0, len(multipliers))
+	for i, multiplier := range multipliers {
+		blocks = append(blocks, fmt.Sprintf(feeBlockJSONTpl, i+1, multiplier, interest, 2))
+	}
+
+	m.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(blockInfoRoute, Height(1), Amount(len(multipliers))),
+		RespBody: "[" + strings.Join(blocks, ",") + "]",
+	})
+
+	return m
+}
+
+func TestFeeEstimator_EstimateFee(t *testing.T) {
+	mockServ := newFeeMock(1, 40, 10, 30, 20)
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+	estimator := NewFeeEstimator(client.Blockchain, &FeeEstimatorConfig{SampleBlocks: 4, CacheTTL: time.Minute})
+
+	tx, err := NewTransferTransaction(NewDeadline(time.Hour), testRecipientAddress, []*Mosaic{}, NewPlainMessage(""), PublicTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	// next block should accept transaction with 95% probability, fee interest is 1/2
+	fee, err := estimator.EstimateFee(ctx, tx, 1)
+	assert.Nilf(t, err, "FeeEstimator.EstimateFee returned error: %s", err)
+	assert.Equal(t, Amount(tx.Size()*20), fee)
+
+	// within 30 blocks it is enough to pay as the cheapest block
+	fee, err = estimator.EstimateFee(ctx, tx, 30)
+	assert.Nilf(t, err, "FeeEstimator.EstimateFee returned error: %s", err)
+	assert.Equal(t, Amount(tx.Size()*5), fee)
+
+	err = estimator.ApplyFee(ctx, tx, 30)
+	assert.Nilf(t, err, "FeeEstimator.ApplyFee returned error: %s", err)
+	assert.Equal(t, fee, tx.MaxFee)
+
+	_, err = estimator.EstimateFee(ctx, tx, 0)
+	assert.Equal(t, ErrInvalidFeeTarget, err)
+}
+
+func TestFeeEstimator_EstimateAggregateFee(t *testing.T) {
+	mockServ := newFeeMock(2, 10, 10)
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+	estimator := NewFeeEstimator(client.Blockchain, &FeeEstimatorConfig{SampleBlocks: 2, CacheTTL: time.Minute})
+
+	signer, err := NewAccountFromPublicKey("321DE652C4D3362FC2DDF7800F6582F4A10CFEA134B81F8AB6E4BE78BBA4D18E", PublicTest)
+	assert.Nilf(t, err, "NewAccountFromPublicKey returned error: %s", err)
+
+	inner, err := NewTransferTransaction(NewDeadline(time.Hour), testRecipientAddress, []*Mosaic{}, NewPlainMessage(""), PublicTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+	inner.ToAggregate(signer)
+
+	tx, err := NewBondedAggregateTransaction(NewDeadline(time.Hour), []Transaction{inner}, PublicTest)
+	assert.Nilf(t, err, "NewBondedAggregateTransaction returned error: %s", err)
+
+	fee, err := estimator.EstimateAggregateFee(ctx, tx, 2, 1)
+	assert.Nilf(t, err, "FeeEstimator.EstimateAggregateFee returned error: %s", err)
+	assert.Equal(t, Amount((tx.Size()+2*AggregateCosignatureSize)*10), fee)
+}
+
+func TestFeeEstimator_Cache(t *testing.T) {
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height": [1, 0]}`,
+	})
+	defer mockServ.Close()
+
+	// fee multiplier of block can be changed between requests
+	var multiplier uint32 = 10
+	mockServ.AddHandler(fmt.Sprintf(blockInfoRoute, Height(1), Amount(1)), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[%s]", fmt.Sprintf(feeBlockJSONTpl, 1, atomic.LoadUint32(&multiplier), 2, 2))
+	})
+
+	client := mockServ.getPublicTestClientUnsafe()
+	estimator := NewFeeEstimator(client.Blockchain, &FeeEstimatorConfig{SampleBlocks: 1, CacheTTL: time.Minute})
+
+	rate, err := estimator.EstimateFeeRate(ctx, 1)
+	assert.Nilf(t, err, "FeeEstimator.EstimateFeeRate returned error: %s", err)
+	assert.Equal(t, float64(10), rate)
+
+	atomic.StoreUint32(&multiplier, 100)
+
+	rate, err = estimator.EstimateFeeRate(ctx, 1)
+	assert.Nilf(t, err, "FeeEstimator.EstimateFeeRate returned error: %s", err)
+	assert.Equal(t, float64(10), rate)
+
+	estimator.config.CacheTTL = 0
+
+	rate, err = estimator.EstimateFeeRate(ctx, 1)
+	assert.Nilf(t, err, "FeeEstimator.EstimateFeeRate returned error: %s", err)
+	assert.Equal(t, float64(100), rate)
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// Backend is an ordered key-value storage of KVStore
+type Backend interface {
+	// returns value of passed key, nil is returned if key doesn't exist
+	Get(key []byte) ([]byte, error)
+	// calls fn for every key in range [from, to) in ascending order, or in descending order if reverse is true.
+	// Range isn't bounded from above if to is nil. Iteration stops when fn returns false
+	Scan(from, to []byte, reverse bool, fn func(key, value []byte) bool) error
+	// applies all operations of passed batch atomically
+	Write(batch *Batch) error
+	Close() error
+}
+
+type batchOp struct {
+	delete bool
+	key    []byte
+	value  []byte
+}
+
+// Batch is a list of operations which are applied by Backend atomically
+type Batch struct {
+	ops []*batchOp
+}
+
+func (b *Batch) Put(key, value []byte) {
+	b.ops = append(b.ops, &batchOp{key: key, value: value})
+}
+
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, &batchOp{delete: true, key: key})
+}
+
+// MemoryBackend is a Backend which keeps sorted keys in memory
+type MemoryBackend struct {
+	sync.RWMutex
+	keys   []string
+	values map[string][]byte
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{values: make(map[string][]byte)}
+}
+
+func (m *MemoryBackend) Get(key []byte) ([]byte, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.values[string(key)], nil
+}
+
+func (m *MemoryBackend) Scan(from, to []byte, reverse bool, fn func(key, value []byte) bool) error {
+	// keys of range are copied, so fn may read and write backend
+	m.RLock()
+	begin := sort.SearchStrings(m.keys, string(from))
+	end := len(m.keys)
+	if to != nil {
+		end = sort.SearchStrings(m.keys, string(to))
+	}
+
+	var keys []string
+	if begin < end {
+		keys = append(keys, m.keys[begin:end]...)
+	}
+	m.RUnlock()
+
+	for i := range keys {
+		key := keys[i]
+		if reverse {
+			key = keys[len(keys)-1-i]
+		}
+
+		m.RLock()
+		value, ok := m.values[key]
+		m.RUnlock()
+
+		if !ok {
+			continue
+		}
+
+		if !fn([]byte(key), value) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryBackend) Write(batch *Batch) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, op := range batch.ops {
+		m.apply(op)
+	}
+
+	return nil
+}
+
+func (m *MemoryBackend) apply(op *batchOp) {
+	key := string(op.key)
+	_, exists := m.values[key]
+
+	if op.delete {
+		if !exists {
+			return
+		}
+
+		delete(m.values, key)
+
+		i := sort.SearchStrings(m.keys, key)
+		m.keys = append(m.keys[:i], m.keys[i+1:]...)
+
+		return
+	}
+
+	m.values[key] = op.value
+
+	if !exists {
+		i := sort.SearchStrings(m.keys, key)
+		m.keys = append(m.keys, "")
+		copy(m.keys[i+1:], m.keys[i:])
+		m.keys[i] = key
+	}
+}
+
+func (m *MemoryBackend) Close() error {
+	return nil
+}
+
+// returns the smallest key which is greater than all keys with passed prefix, nil is returned if there is no such key
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.TrimRight(prefix, "\xff")
+	if len(end) == 0 {
+		return nil
+	}
+
+	end = append([]byte{}, end...)
+	end[len(end)-1]++
+
+	return end
+}
@@ -0,0 +1,279 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// size of length and checksum of record in log
+	recordHeaderSize = 8
+
+	opPut    byte = 1
+	opDelete byte = 2
+)
+
+var (
+	ErrBackendFailed   = errors.New("log of file backend is not restored after failed write")
+	errCorruptedRecord = errors.New("record of log is corrupted")
+)
+
+// file of log, it is replaced in tests to inject failures
+type logFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Truncate(size int64) error
+	Sync() error
+}
+
+// FileBackend is an embedded Backend which appends every batch to log file and keeps all keys in memory.
+// Log is replayed when backend is opened, incomplete record at the end of log, e.g. after crash, is discarded.
+// Every batch is synced to disk before Write returns, log is truncated back after failed write
+type FileBackend struct {
+	*MemoryBackend
+
+	mutex sync.Mutex
+	path  string
+	file  logFile
+	// is set when log can't be restored after failed write, every next write fails
+	failed bool
+}
+
+// returns FileBackend which keeps log in file at passed path, file is created if it doesn't exist
+func OpenFileBackend(path string) (*FileBackend, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &FileBackend{
+		MemoryBackend: NewMemoryBackend(),
+		path:          path,
+		file:          file,
+	}
+
+	if err := b.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// applies records of log to memory and truncates log after the last valid record
+func (b *FileBackend) replay() error {
+	data, err := ioutil.ReadAll(b.file)
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	for offset < len(data) {
+		batch, size, err := decodeRecord(data[offset:])
+		if err != nil {
+			break
+		}
+
+		for _, op := range batch.ops {
+			b.MemoryBackend.apply(op)
+		}
+
+		offset += size
+	}
+
+	if offset < len(data) {
+		if err := b.file.Truncate(int64(offset)); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.file.Seek(int64(offset), io.SeekStart)
+
+	return err
+}
+
+func (b *FileBackend) Write(batch *Batch) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.failed {
+		return ErrBackendFailed
+	}
+
+	offset, err := b.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if err := b.append(encodeRecord(batch)); err != nil {
+		// replay stops at partial record, so records appended after it would be lost
+		if b.file.Truncate(offset) != nil {
+			b.failed = true
+		} else if _, seekErr := b.file.Seek(offset, io.SeekStart); seekErr != nil {
+			b.failed = true
+		}
+
+		return err
+	}
+
+	return b.MemoryBackend.Write(batch)
+}
+
+// writes record to the end of log and syncs it to disk
+func (b *FileBackend) append(record []byte) error {
+	if _, err := b.file.Write(record); err != nil {
+		return err
+	}
+
+	return b.file.Sync()
+}
+
+// rewrites log with only the current values, log is replaced atomically
+func (b *FileBackend) Compact() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	batch := &Batch{}
+	err := b.MemoryBackend.Scan(nil, nil, false, func(key, value []byte) bool {
+		batch.Put(key, value)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(b.path), filepath.Base(b.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(encodeRecord(batch)); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), b.path); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	b.file.Close()
+	b.file = f
+	// log is rewritten from memory, so it is consistent again
+	b.failed = false
+
+	return nil
+}
+
+func (b *FileBackend) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.file.Close()
+}
+
+// returns record of log with length and checksum of batch followed by its operations
+func encodeRecord(batch *Batch) []byte {
+	var body bytes.Buffer
+	for _, op := range batch.ops {
+		if op.delete {
+			body.WriteByte(opDelete)
+			writeBytes(&body, op.key)
+		} else {
+			body.WriteByte(opPut)
+			writeBytes(&body, op.key)
+			writeBytes(&body, op.value)
+		}
+	}
+
+	record := make([]byte, recordHeaderSize, recordHeaderSize+body.Len())
+	binary.LittleEndian.PutUint32(record[:4], uint32(body.Len()))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(body.Bytes()))
+
+	return append(record, body.Bytes()...)
+}
+
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(b)))
+	buf.Write(size)
+	buf.Write(b)
+}
+
+// returns batch of record at the start of data and size of record
+func decodeRecord(data []byte) (*Batch, int, error) {
+	if len(data) < recordHeaderSize {
+		return nil, 0, errCorruptedRecord
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[:4]))
+	if len(data)-recordHeaderSize < size {
+		return nil, 0, errCorruptedRecord
+	}
+
+	body := data[recordHeaderSize : recordHeaderSize+size]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(data[4:8]) {
+		return nil, 0, errCorruptedRecord
+	}
+
+	batch := &Batch{}
+	for len(body) > 0 {
+		op := body[0]
+		body = body[1:]
+
+		key, rest, err := readBytes(body)
+		if err != nil {
+			return nil, 0, err
+		}
+		body = rest
+
+		switch op {
+		case opDelete:
+			batch.Delete(key)
+		case opPut:
+			value, rest, err := readBytes(body)
+			if err != nil {
+				return nil, 0, err
+			}
+			body = rest
+
+			batch.Put(key, value)
+		default:
+			return nil, 0, errCorruptedRecord
+		}
+	}
+
+	return batch, recordHeaderSize + size, nil
+}
+
+func readBytes(data []byte) ([]byte, []byte, error) {
+	if len(data) < 4 {
+		return nil, nil, errCorruptedRecord
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[:4]))
+	if len(data)-4 < size {
+		return nil, nil, errCorruptedRecord
+	}
+
+	return data[4 : 4+size], data[4+size:], nil
+}
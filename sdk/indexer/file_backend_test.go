@@ -0,0 +1,108 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errInjected = errors.New("injected failure")
+
+// logFile which writes only half of data or fails sync or truncate
+type failingFile struct {
+	logFile
+	failWrite, failSync, failTruncate bool
+}
+
+func (f *failingFile) Write(p []byte) (int, error) {
+	if !f.failWrite {
+		return f.logFile.Write(p)
+	}
+
+	n, _ := f.logFile.Write(p[:len(p)/2])
+	return n, errInjected
+}
+
+func (f *failingFile) Sync() error {
+	if f.failSync {
+		return errInjected
+	}
+
+	return f.logFile.Sync()
+}
+
+func (f *failingFile) Truncate(size int64) error {
+	if f.failTruncate {
+		return errInjected
+	}
+
+	return f.logFile.Truncate(size)
+}
+
+func putBatch(key, value string) *Batch {
+	batch := &Batch{}
+	batch.Put([]byte(key), []byte(value))
+	return batch
+}
+
+func get(t *testing.T, b Backend, key string) string {
+	value, err := b.Get([]byte(key))
+	assert.Nilf(t, err, "Get returned error: %s", err)
+
+	return string(value)
+}
+
+func TestFileBackend_FailedWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	assert.Nilf(t, err, "TempDir returned error: %s", err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "index.log")
+
+	b, err := OpenFileBackend(path)
+	assert.Nilf(t, err, "OpenFileBackend returned error: %s", err)
+
+	assert.Nil(t, b.Write(putBatch("first", "1")))
+
+	file := &failingFile{logFile: b.file, failWrite: true}
+	b.file = file
+	assert.Equal(t, errInjected, b.Write(putBatch("partial", "2")))
+
+	file.failWrite, file.failSync = false, true
+	assert.Equal(t, errInjected, b.Write(putBatch("unsynced", "3")))
+	assert.Equal(t, "", get(t, b, "unsynced"))
+
+	// batches after failed ones are kept in log
+	file.failSync = false
+	assert.Nil(t, b.Write(putBatch("second", "4")))
+	assert.Nil(t, b.Close())
+
+	b, err = OpenFileBackend(path)
+	assert.Nilf(t, err, "OpenFileBackend returned error: %s", err)
+	defer b.Close()
+
+	assert.Equal(t, "1", get(t, b, "first"))
+	assert.Equal(t, "", get(t, b, "partial"))
+	assert.Equal(t, "", get(t, b, "unsynced"))
+	assert.Equal(t, "4", get(t, b, "second"))
+
+	// log which can't be truncated back refuses next writes until it is compacted
+	file = &failingFile{logFile: b.file, failWrite: true, failTruncate: true}
+	b.file = file
+	assert.Equal(t, errInjected, b.Write(putBatch("partial", "5")))
+
+	file.failWrite, file.failTruncate = false, false
+	assert.Equal(t, ErrBackendFailed, b.Write(putBatch("third", "6")))
+
+	assert.Nil(t, b.Compact())
+	assert.Nil(t, b.Write(putBatch("third", "6")))
+	assert.Equal(t, "6", get(t, b, "third"))
+}
@@ -0,0 +1,338 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+// Package indexer follows the chain and keeps its transactions in local Store indexed by address, mosaic, namespace,
+// entity type and height.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+const (
+	// number of blocks requested at once while indexer catches up the chain
+	DefaultBatchSize sdk.Amount = 100
+
+	// interval of polling chain height when websocket is not available
+	DefaultPollInterval = time.Second * 15
+
+	// while websocket delivers blocks chain height is polled this times rarer, just as a safety net
+	socketPollFactor = 6
+
+	// number of blocks from websocket waiting to be indexed
+	blocksBufferSize = 16
+)
+
+var (
+	ErrNilStore           = errors.New("store should not be nil")
+	ErrNilBlock           = errors.New("block should not be nil")
+	ErrDiscontinuousBlock = errors.New("block doesn't follow the last indexed block")
+	ErrIncompleteBlock    = errors.New("node returned not all transactions of block")
+)
+
+// Config of Indexer, zero fields are replaced with defaults
+type Config struct {
+	// height of the first indexed block when store is empty, 1 if zero
+	StartHeight sdk.Height
+	// limit of GetBlocksByHeightWithLimit
+	BatchSize    sdk.Amount
+	PollInterval time.Duration
+	// is called after block is indexed
+	OnBlock func(block *Block)
+	// is called after blocks above passed height are removed on fork
+	OnRollback func(height sdk.Height)
+	// is called when Run fails to index blocks, Run tries again on the next block or poll
+	OnError func(err error)
+}
+
+// Indexer follows the chain and writes its blocks with transactions into Store
+type Indexer struct {
+	client *sdk.Client
+	ws     websocket.CatapultClient
+	store  Store
+	config Config
+}
+
+// returns Indexer which reads blocks through passed client and writes them into passed store.
+// ws can be nil, then new blocks are found by polling chain height through REST
+func New(client *sdk.Client, ws websocket.CatapultClient, store Store, config *Config) (*Indexer, error) {
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	ix := &Indexer{
+		client: client,
+		ws:     ws,
+		store:  store,
+	}
+
+	if config != nil {
+		ix.config = *config
+	}
+
+	if ix.config.StartHeight == 0 {
+		ix.config.StartHeight = 1
+	}
+
+	if ix.config.BatchSize == 0 {
+		ix.config.BatchSize = DefaultBatchSize
+	}
+
+	if ix.config.PollInterval <= 0 {
+		ix.config.PollInterval = DefaultPollInterval
+	}
+
+	return ix, nil
+}
+
+// returns Store of indexer
+func (ix *Indexer) Store() Store {
+	return ix.store
+}
+
+// indexes all blocks up to the current chain height and returns height of the last indexed block.
+// Blocks of abandoned fork are rolled back before blocks of the new chain are indexed
+func (ix *Indexer) Sync(ctx context.Context) (sdk.Height, error) {
+	for {
+		chainHeight, err := ix.client.Blockchain.GetBlockchainHeight(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		last, err := ix.store.LastBlock()
+		if err != nil {
+			return 0, err
+		}
+
+		// node which is behind the indexed chain can't confirm or deny the last indexed block
+		if last != nil && last.Height <= chainHeight {
+			if last, err = ix.reconcile(ctx, last); err != nil {
+				return 0, err
+			}
+		}
+
+		next := ix.config.StartHeight
+		if last != nil {
+			next = last.Height + 1
+		}
+
+		if next > chainHeight {
+			return next - 1, nil
+		}
+
+		blocks, err := ix.client.Blockchain.GetBlocksByHeightWithLimit(ctx, next, ix.config.BatchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].Height < blocks[j].Height
+		})
+
+		reconciled := last
+
+		for _, b := range blocks {
+			if b.Height < next {
+				continue
+			}
+
+			// chain changed since last reconcile, so it is reconciled again
+			if last != nil && (b.Height != last.Height+1 || !sameHash(b.PreviousBlockHash, last.Hash)) {
+				break
+			}
+
+			if last, err = ix.index(ctx, b); err != nil {
+				return 0, err
+			}
+		}
+
+		// node returned blocks which don't continue its own chain, it should be consistent on the next call
+		if last == reconciled {
+			return 0, ErrDiscontinuousBlock
+		}
+	}
+}
+
+// rolls back blocks which are not in the chain of node and returns the last block which remains
+func (ix *Indexer) reconcile(ctx context.Context, last *BlockHeader) (*BlockHeader, error) {
+	header := last
+	for header != nil {
+		b, err := ix.client.Blockchain.GetBlockByHeight(ctx, header.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		if sameHash(b.BlockHash, header.Hash) {
+			break
+		}
+
+		if header.Height <= ix.config.StartHeight {
+			header = nil
+			break
+		}
+
+		if header, err = ix.store.Block(header.Height - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if header == last {
+		return last, nil
+	}
+
+	height := ix.config.StartHeight - 1
+	if header != nil {
+		height = header.Height
+	}
+
+	if err := ix.store.Rollback(height); err != nil {
+		return nil, err
+	}
+
+	if ix.config.OnRollback != nil {
+		ix.config.OnRollback(height)
+	}
+
+	return header, nil
+}
+
+// writes passed block with its transactions into store
+func (ix *Indexer) index(ctx context.Context, b *sdk.BlockInfo) (*BlockHeader, error) {
+	block := &Block{
+		BlockHeader: BlockHeader{
+			Height:       b.Height,
+			Hash:         b.BlockHash,
+			PreviousHash: b.PreviousBlockHash,
+		},
+	}
+
+	if b.NumTransactions > 0 {
+		txs := make([]sdk.Transaction, 0, b.NumTransactions)
+
+		it := ix.client.Blockchain.BlockTransactionsIterator(b.Height, nil)
+		for it.Next(ctx) {
+			txs = append(txs, it.Transaction())
+		}
+
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+
+		// partially indexed block would never be completed, because indexing continues from the next block
+		if uint64(len(txs)) != b.NumTransactions {
+			return nil, ErrIncompleteBlock
+		}
+
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].GetAbstractTransaction().Index < txs[j].GetAbstractTransaction().Index
+		})
+
+		block.Transactions = txs
+	}
+
+	if err := ix.store.PutBlock(block); err != nil {
+		return nil, err
+	}
+
+	if ix.config.OnBlock != nil {
+		ix.config.OnBlock(block)
+	}
+
+	return &block.BlockHeader, nil
+}
+
+// indexes the chain until passed context is done.
+// After indexer catches up the chain, blocks are taken from websocket block channel if ws is available.
+// ws should be listening, Run doesn't call its Listen
+func (ix *Indexer) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscription goes first, so blocks produced during catching up are not missed
+	blocks := make(chan *sdk.BlockInfo, blocksBufferSize)
+	interval := ix.config.PollInterval
+	if ix.subscribe(ctx, blocks) {
+		interval *= socketPollFactor
+	}
+
+	ix.report(ix.sync(ctx))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case b := <-blocks:
+			ix.report(ix.follow(ctx, b))
+		case <-ticker.C:
+			ix.report(ix.sync(ctx))
+		}
+	}
+}
+
+// subscribes to websocket block channel, returns false if websocket is not available
+func (ix *Indexer) subscribe(ctx context.Context, blocks chan<- *sdk.BlockInfo) bool {
+	if ix.ws == nil {
+		return false
+	}
+
+	sub, err := ix.ws.AddBlockHandlers(func(b *sdk.BlockInfo) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		select {
+		case blocks <- b:
+		default:
+			// indexer is behind, skipped block is fetched by Sync
+		}
+
+		return false
+	})
+	if err != nil {
+		return false
+	}
+
+	// handler is removed when Run is finished
+	go func() {
+		<-ctx.Done()
+		sub.Unsubscribe()
+	}()
+
+	return true
+}
+
+// indexes block received from websocket, Sync is used if it doesn't follow the last indexed block
+func (ix *Indexer) follow(ctx context.Context, b *sdk.BlockInfo) error {
+	last, err := ix.store.LastBlock()
+	if err != nil {
+		return err
+	}
+
+	if last != nil && b.Height == last.Height+1 && sameHash(b.PreviousBlockHash, last.Hash) {
+		_, err = ix.index(ctx, b)
+		return err
+	}
+
+	return ix.sync(ctx)
+}
+
+func (ix *Indexer) sync(ctx context.Context) error {
+	_, err := ix.Sync(ctx)
+	return err
+}
+
+func (ix *Indexer) report(err error) {
+	if err != nil && ix.config.OnError != nil && !errors.Is(err, context.Canceled) {
+		ix.config.OnError(err)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/sdktest"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+func newTestNode(t *testing.T) (*sdktest.Node, *sdk.Client) {
+	node, err := sdktest.NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+
+	cfg, err := node.Config(context.Background())
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	return node, sdk.NewClient(nil, cfg)
+}
+
+// announces transfer of XPX from nemesis to recipient
+func transfer(t *testing.T, node *sdktest.Node, client *sdk.Client, recipient *sdk.Address, message string) {
+	tx, err := client.NewTransferTransaction(sdk.NewDeadline(time.Hour), recipient, []*sdk.Mosaic{sdk.Xpx(1)}, sdk.NewPlainMessage(message))
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx, err := node.Nemesis().Sign(tx)
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	_, err = client.Transaction.Announce(context.Background(), stx)
+	assert.Nilf(t, err, "Announce returned error: %s", err)
+}
+
+func TestIndexer_Sync(t *testing.T) {
+	node, client := newTestNode(t)
+	defer node.Close()
+
+	ctx := context.Background()
+	recipient, err := sdk.NewAccount(sdk.MijinTest, client.GenerationHash())
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	for _, message := range []string{"first", "second", "third"} {
+		transfer(t, node, client, recipient.Address, message)
+		node.GenerateBlock()
+	}
+
+	var rollbacks []sdk.Height
+	ix, err := New(client, nil, NewMemoryStore(), &Config{
+		BatchSize:  2,
+		OnRollback: func(height sdk.Height) { rollbacks = append(rollbacks, height) },
+	})
+	assert.Nilf(t, err, "New returned error: %s", err)
+
+	height, err := ix.Sync(ctx)
+	assert.Nilf(t, err, "Sync returned error: %s", err)
+	assert.Equal(t, sdk.Height(4), height)
+
+	filter := &Filter{Address: recipient.Address}
+	assert.Equal(t, []string{"first", "second", "third"}, transactions(t, ix.Store(), filter))
+
+	// blocks 3 and 4 are replaced with blocks of another fork, where transactions are confirmed in other blocks
+	node.Fork(2)
+	node.GenerateBlock()
+	transfer(t, node, client, recipient.Address, "fourth")
+	node.GenerateBlock()
+	node.GenerateBlock()
+
+	height, err = ix.Sync(ctx)
+	assert.Nilf(t, err, "Sync returned error: %s", err)
+	assert.Equal(t, sdk.Height(5), height)
+	assert.Equal(t, []sdk.Height{2}, rollbacks)
+
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, transactions(t, ix.Store(), filter))
+	assert.Equal(t, []string{"second", "third"}, transactions(t, ix.Store(), &Filter{Address: recipient.Address, FromHeight: 3, ToHeight: 3}))
+
+	for h := sdk.Height(1); h <= height; h++ {
+		block, err := client.Blockchain.GetBlockByHeight(ctx, h)
+		assert.Nilf(t, err, "GetBlockByHeight returned error: %s", err)
+
+		header, err := ix.Store().Block(h)
+		assert.Nilf(t, err, "Block returned error: %s", err)
+		assert.Equal(t, block.BlockHash, header.Hash)
+	}
+}
+
+func TestIndexer_Sync_LargeBlock(t *testing.T) {
+	node, client := newTestNode(t)
+	defer node.Close()
+
+	recipient, err := sdk.NewAccount(sdk.MijinTest, client.GenerationHash())
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	// node returns transactions of block by pages of at most 100 transactions
+	expected := make([]string, 105)
+	for i := range expected {
+		expected[i] = fmt.Sprintf("transfer %03d", i)
+		transfer(t, node, client, recipient.Address, expected[i])
+	}
+	node.GenerateBlock()
+
+	ix, err := New(client, nil, NewMemoryStore(), nil)
+	assert.Nilf(t, err, "New returned error: %s", err)
+
+	height, err := ix.Sync(context.Background())
+	assert.Nilf(t, err, "Sync returned error: %s", err)
+	assert.Equal(t, sdk.Height(2), height)
+
+	assert.Equal(t, expected, transactions(t, ix.Store(), &Filter{Address: recipient.Address}))
+}
+
+func TestIndexer_Run(t *testing.T) {
+	node, client := newTestNode(t)
+	defer node.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	wsc, err := websocket.NewClient(ctx, cfg)
+	assert.Nilf(t, err, "websocket.NewClient returned error: %s", err)
+	defer wsc.Close()
+
+	go wsc.Listen()
+
+	indexed := make(chan sdk.Height, 100)
+	ix, err := New(client, wsc, NewMemoryStore(), &Config{
+		// only websocket can deliver new blocks during test
+		PollInterval: time.Hour,
+		OnBlock:      func(block *Block) { indexed <- block.Height },
+	})
+	assert.Nilf(t, err, "New returned error: %s", err)
+
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- ix.Run(runCtx)
+	}()
+
+	assert.Equal(t, sdk.Height(1), <-indexed)
+
+	recipient, err := sdk.NewAccount(sdk.MijinTest, client.GenerationHash())
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	transfer(t, node, client, recipient.Address, "websocket")
+
+	// websocket may subscribe after the first blocks, missed blocks are synced when the next block arrives
+	var last sdk.Height
+	for i := 0; i < 50 && last < 2; i++ {
+		node.GenerateBlock()
+
+		select {
+		case last = <-indexed:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	assert.True(t, last >= 2, "blocks are not indexed from websocket")
+	assert.Equal(t, []string{"websocket"}, transactions(t, ix.Store(), &Filter{Address: recipient.Address}))
+
+	stop()
+	assert.Equal(t, context.Canceled, <-done)
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"encoding/binary"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// keyCollector collects prefixes of index keys of transaction
+type keyCollector struct {
+	keys [][]byte
+}
+
+// returns prefixes of index keys of passed transaction and its inner transactions, keys may repeat
+func transactionKeys(tx sdk.Transaction) [][]byte {
+	c := &keyCollector{}
+	c.transaction(tx)
+
+	return c.keys
+}
+
+func (c *keyCollector) transaction(tx sdk.Transaction) {
+	atx := tx.GetAbstractTransaction()
+
+	c.entityType(atx.Type)
+	c.publicAccount(atx.Signer)
+
+	switch tx := tx.(type) {
+	case *sdk.AggregateTransaction:
+		for _, itx := range tx.InnerTransactions {
+			c.transaction(itx)
+		}
+
+		for _, cosignature := range tx.Cosignatures {
+			c.publicAccount(cosignature.Signer)
+		}
+	case *sdk.TransferTransaction:
+		c.address(tx.Recipient)
+		for _, mosaic := range tx.Mosaics {
+			c.mosaic(mosaic)
+		}
+	case *sdk.AddressAliasTransaction:
+		c.address(tx.Address)
+		c.namespaceId(tx.NamespaceId)
+	case *sdk.MosaicAliasTransaction:
+		c.mosaicId(tx.MosaicId)
+		c.namespaceId(tx.NamespaceId)
+	case *sdk.RegisterNamespaceTransaction:
+		c.namespaceId(tx.NamespaceId)
+		c.namespaceId(tx.ParentId)
+	case *sdk.MosaicDefinitionTransaction:
+		c.mosaicId(tx.MosaicId)
+	case *sdk.MosaicSupplyChangeTransaction:
+		c.assetId(tx.AssetId)
+	case *sdk.LockFundsTransaction:
+		c.mosaic(tx.Mosaic)
+	case *sdk.SecretLockTransaction:
+		c.mosaic(tx.Mosaic)
+		c.address(tx.Recipient)
+	case *sdk.SecretProofTransaction:
+		c.address(tx.Recipient)
+	case *sdk.ModifyMultisigAccountTransaction:
+		for _, m := range tx.Modifications {
+			c.publicAccount(m.PublicAccount)
+		}
+	case *sdk.AccountLinkTransaction:
+		c.publicAccount(tx.RemoteAccount)
+	}
+}
+
+func (c *keyCollector) entityType(t sdk.EntityType) {
+	c.keys = append(c.keys, prefixed(typePrefix, uint16Key(uint16(t))))
+}
+
+func (c *keyCollector) publicAccount(pa *sdk.PublicAccount) {
+	if pa != nil {
+		c.address(pa.Address)
+	}
+}
+
+// addresses of namespace alias are indexed by namespace
+func (c *keyCollector) address(a *sdk.Address) {
+	if a == nil {
+		return
+	}
+
+	raw, err := a.Decode()
+	if err != nil || len(raw) == 0 {
+		return
+	}
+
+	if raw[0] == byte(sdk.AliasAddress) && len(raw) > 8 {
+		c.keys = append(c.keys, prefixed(namespacePrefix, uint64Key(binary.LittleEndian.Uint64(raw[1:9]))))
+		return
+	}
+
+	c.keys = append(c.keys, prefixed(addressPrefix, raw))
+}
+
+func (c *keyCollector) mosaic(m *sdk.Mosaic) {
+	if m != nil {
+		c.assetId(m.AssetId)
+	}
+}
+
+func (c *keyCollector) assetId(assetId sdk.AssetId) {
+	switch id := assetId.(type) {
+	case *sdk.MosaicId:
+		c.mosaicId(id)
+	case *sdk.NamespaceId:
+		c.namespaceId(id)
+	}
+}
+
+func (c *keyCollector) mosaicId(id *sdk.MosaicId) {
+	if id != nil {
+		c.keys = append(c.keys, prefixed(mosaicPrefix, uint64Key(id.Id())))
+	}
+}
+
+// root namespaces have zero parent
+func (c *keyCollector) namespaceId(id *sdk.NamespaceId) {
+	if id != nil && id.Id() != 0 {
+		c.keys = append(c.keys, prefixed(namespacePrefix, uint64Key(id.Id())))
+	}
+}
@@ -0,0 +1,533 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// prefixes of keys in Backend
+const (
+	blockPrefix       = 'b'
+	transactionPrefix = 't'
+	addressPrefix     = 'a'
+	mosaicPrefix      = 'm'
+	namespacePrefix   = 'n'
+	typePrefix        = 'e'
+
+	heightSize = 8
+	indexSize  = 4
+)
+
+// BlockHeader identifies indexed block in chain
+type BlockHeader struct {
+	Height       sdk.Height
+	Hash         *sdk.Hash
+	PreviousHash *sdk.Hash
+}
+
+// Block is an indexed block with its transactions ordered by index in block
+type Block struct {
+	BlockHeader
+	Transactions []sdk.Transaction
+}
+
+// Filter selects indexed transactions, zero fields don't restrict selection.
+// Transaction matches filter if any of its inner transactions matches it, transfers to namespace alias are matched by namespace.
+// Mosaics referenced by namespace alias, e.g. prx.xpx, are matched only by NamespaceId
+type Filter struct {
+	Address     *sdk.Address
+	MosaicId    *sdk.MosaicId
+	NamespaceId *sdk.NamespaceId
+	Type        sdk.EntityType
+	FromHeight  sdk.Height
+	// inclusive
+	ToHeight sdk.Height
+	// max number of returned transactions
+	Limit int
+	// transactions are returned from the highest block if true
+	Descending bool
+}
+
+// Store keeps indexed blocks, blocks are added one by one and removed from the top on rollback
+type Store interface {
+	// returns the highest indexed block, nil is returned if store is empty
+	LastBlock() (*BlockHeader, error)
+	// returns indexed block at passed height, nil is returned if block isn't indexed
+	Block(height sdk.Height) (*BlockHeader, error)
+	// adds block which follows the last indexed block, the first block may have any height
+	PutBlock(block *Block) error
+	// removes all blocks above passed height
+	Rollback(height sdk.Height) error
+	// returns transactions which match passed filter ordered by height and index in block
+	Transactions(filter *Filter) ([]sdk.Transaction, error)
+	Close() error
+}
+
+type blockRecord struct {
+	Hash         string   `json:"hash"`
+	PreviousHash string   `json:"previousHash"`
+	Keys         [][]byte `json:"keys"`
+}
+
+type transactionInfoRecord struct {
+	Height              sdk.Height `json:"height"`
+	Index               uint32     `json:"index"`
+	Id                  string     `json:"id"`
+	Hash                string     `json:"hash,omitempty"`
+	MerkleComponentHash string     `json:"merkleComponentHash,omitempty"`
+	AggregateHash       string     `json:"aggregateHash,omitempty"`
+	AggregateId         string     `json:"aggregateId,omitempty"`
+}
+
+type transactionRecord struct {
+	Payload string                   `json:"payload"`
+	Info    *transactionInfoRecord   `json:"info"`
+	Inner   []*transactionInfoRecord `json:"inner,omitempty"`
+	Keys    [][]byte                 `json:"keys"`
+}
+
+// KVStore is a Store over ordered key-value Backend.
+// Every transaction is saved as its catapult payload and is referenced by keys of secondary indexes,
+// keys are ordered by height and index in block
+type KVStore struct {
+	mutex   sync.Mutex
+	backend Backend
+}
+
+func NewKVStore(backend Backend) *KVStore {
+	return &KVStore{backend: backend}
+}
+
+// returns KVStore which keeps blocks in memory
+func NewMemoryStore() *KVStore {
+	return NewKVStore(NewMemoryBackend())
+}
+
+// returns KVStore which keeps blocks in log file at passed path
+func OpenFileStore(path string) (*KVStore, error) {
+	backend, err := OpenFileBackend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKVStore(backend), nil
+}
+
+func (s *KVStore) LastBlock() (*BlockHeader, error) {
+	var (
+		header *BlockHeader
+		err    error
+	)
+
+	prefix := []byte{blockPrefix}
+	scanErr := s.backend.Scan(prefix, prefixEnd(prefix), true, func(key, value []byte) bool {
+		header, err = decodeBlockHeader(key, value)
+		return false
+	})
+	if scanErr != nil {
+		return nil, scanErr
+	}
+
+	return header, err
+}
+
+func (s *KVStore) Block(height sdk.Height) (*BlockHeader, error) {
+	key := blockKey(height)
+
+	value, err := s.backend.Get(key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	return decodeBlockHeader(key, value)
+}
+
+func (s *KVStore) PutBlock(block *Block) error {
+	if block == nil || block.Hash == nil {
+		return ErrNilBlock
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	last, err := s.LastBlock()
+	if err != nil {
+		return err
+	}
+
+	if last != nil && (block.Height != last.Height+1 || !sameHash(block.PreviousHash, last.Hash)) {
+		return ErrDiscontinuousBlock
+	}
+
+	batch := &Batch{}
+	record := &blockRecord{
+		Hash:         hashToString(block.Hash),
+		PreviousHash: hashToString(block.PreviousHash),
+	}
+
+	for i, tx := range block.Transactions {
+		index := uint32(i)
+		if info := tx.GetAbstractTransaction().TransactionInfo; info.Height == block.Height {
+			index = info.Index
+		}
+
+		suffix := heightIndex(block.Height, index)
+
+		txRecord, err := newTransactionRecord(tx, suffix)
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(txRecord)
+		if err != nil {
+			return err
+		}
+
+		key := prefixed(transactionPrefix, suffix)
+		batch.Put(key, value)
+		record.Keys = append(record.Keys, key)
+
+		for _, key := range txRecord.Keys {
+			batch.Put(key, []byte{})
+			record.Keys = append(record.Keys, key)
+		}
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	batch.Put(blockKey(block.Height), value)
+
+	return s.backend.Write(batch)
+}
+
+func (s *KVStore) Rollback(height sdk.Height) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch := &Batch{}
+
+	var decodeErr error
+	err := s.backend.Scan(blockKey(height+1), prefixEnd([]byte{blockPrefix}), false, func(key, value []byte) bool {
+		record := &blockRecord{}
+		if decodeErr = json.Unmarshal(value, record); decodeErr != nil {
+			return false
+		}
+
+		for _, key := range record.Keys {
+			batch.Delete(key)
+		}
+
+		batch.Delete(key)
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	return s.backend.Write(batch)
+}
+
+func (s *KVStore) Transactions(filter *Filter) ([]sdk.Transaction, error) {
+	if filter == nil {
+		filter = &Filter{}
+	}
+
+	keys, err := filterKeys(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first index key is scanned and the rest are checked against keys of transaction
+	prefix := []byte{transactionPrefix}
+	if len(keys) > 0 {
+		prefix = keys[0]
+	}
+
+	from := append(append([]byte{}, prefix...), heightIndex(filter.FromHeight, 0)...)
+	to := prefixEnd(prefix)
+	if filter.ToHeight != 0 {
+		to = append(append([]byte{}, prefix...), heightIndex(filter.ToHeight+1, 0)...)
+	}
+
+	var (
+		txs     []sdk.Transaction
+		readErr error
+	)
+
+	err = s.backend.Scan(from, to, filter.Descending, func(key, value []byte) bool {
+		if len(keys) > 0 {
+			value, readErr = s.backend.Get(prefixed(transactionPrefix, key[len(key)-heightSize-indexSize:]))
+			if readErr != nil {
+				return false
+			}
+
+			if value == nil {
+				return true
+			}
+		}
+
+		record := &transactionRecord{}
+		if readErr = json.Unmarshal(value, record); readErr != nil {
+			return false
+		}
+
+		if !record.hasKeys(keys) {
+			return true
+		}
+
+		var tx sdk.Transaction
+		if tx, readErr = record.transaction(); readErr != nil {
+			return false
+		}
+
+		txs = append(txs, tx)
+
+		return filter.Limit <= 0 || len(txs) < filter.Limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return txs, readErr
+}
+
+func (s *KVStore) Close() error {
+	return s.backend.Close()
+}
+
+// returns prefixes of index keys which transaction must have to match filter
+func filterKeys(filter *Filter) ([][]byte, error) {
+	var keys [][]byte
+
+	if filter.Address != nil {
+		raw, err := filter.Address.Decode()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, prefixed(addressPrefix, raw))
+	}
+
+	if filter.MosaicId != nil {
+		keys = append(keys, prefixed(mosaicPrefix, uint64Key(filter.MosaicId.Id())))
+	}
+
+	if filter.NamespaceId != nil {
+		keys = append(keys, prefixed(namespacePrefix, uint64Key(filter.NamespaceId.Id())))
+	}
+
+	if filter.Type != 0 {
+		keys = append(keys, prefixed(typePrefix, uint16Key(uint16(filter.Type))))
+	}
+
+	return keys, nil
+}
+
+func newTransactionRecord(tx sdk.Transaction, suffix []byte) (*transactionRecord, error) {
+	payload, err := sdk.TransactionPayload(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &transactionRecord{
+		Payload: hex.EncodeToString(payload),
+		Info:    newTransactionInfoRecord(&tx.GetAbstractTransaction().TransactionInfo),
+	}
+
+	if agtx, ok := tx.(*sdk.AggregateTransaction); ok {
+		for _, itx := range agtx.InnerTransactions {
+			record.Inner = append(record.Inner, newTransactionInfoRecord(&itx.GetAbstractTransaction().TransactionInfo))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range transactionKeys(tx) {
+		if seen[string(key)] {
+			continue
+		}
+
+		seen[string(key)] = true
+		record.Keys = append(record.Keys, append(key, suffix...))
+	}
+
+	return record, nil
+}
+
+// returns true if transaction has index keys with all passed prefixes
+func (r *transactionRecord) hasKeys(prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		found := false
+		for _, key := range r.Keys {
+			if bytes.HasPrefix(key, prefix) && len(key) == len(prefix)+heightSize+indexSize {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *transactionRecord) transaction() (sdk.Transaction, error) {
+	payload, err := hex.DecodeString(r.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := sdk.ParseTransactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Info.restore(&tx.GetAbstractTransaction().TransactionInfo); err != nil {
+		return nil, err
+	}
+
+	if agtx, ok := tx.(*sdk.AggregateTransaction); ok {
+		for i, itx := range agtx.InnerTransactions {
+			if i >= len(r.Inner) {
+				break
+			}
+
+			if err := r.Inner[i].restore(&itx.GetAbstractTransaction().TransactionInfo); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return tx, nil
+}
+
+func newTransactionInfoRecord(info *sdk.TransactionInfo) *transactionInfoRecord {
+	return &transactionInfoRecord{
+		Height:              info.Height,
+		Index:               info.Index,
+		Id:                  info.Id,
+		Hash:                hashToString(info.TransactionHash),
+		MerkleComponentHash: hashToString(info.MerkleComponentHash),
+		AggregateHash:       hashToString(info.AggregateHash),
+		AggregateId:         info.AggregateId,
+	}
+}
+
+func (r *transactionInfoRecord) restore(info *sdk.TransactionInfo) (err error) {
+	if r == nil {
+		return nil
+	}
+
+	info.Height = r.Height
+	info.Index = r.Index
+	info.Id = r.Id
+	info.AggregateId = r.AggregateId
+
+	if info.TransactionHash, err = stringToHash(r.Hash); err != nil {
+		return err
+	}
+
+	if info.MerkleComponentHash, err = stringToHash(r.MerkleComponentHash); err != nil {
+		return err
+	}
+
+	info.AggregateHash, err = stringToHash(r.AggregateHash)
+
+	return err
+}
+
+func decodeBlockHeader(key, value []byte) (*BlockHeader, error) {
+	record := &blockRecord{}
+	if err := json.Unmarshal(value, record); err != nil {
+		return nil, err
+	}
+
+	hash, err := stringToHash(record.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	previousHash, err := stringToHash(record.PreviousHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockHeader{
+		Height:       sdk.Height(binary.BigEndian.Uint64(key[1:])),
+		Hash:         hash,
+		PreviousHash: previousHash,
+	}, nil
+}
+
+func blockKey(height sdk.Height) []byte {
+	return prefixed(blockPrefix, uint64Key(uint64(height)))
+}
+
+// returns suffix of keys which orders them by height and index in block
+func heightIndex(height sdk.Height, index uint32) []byte {
+	b := make([]byte, heightSize+indexSize)
+	binary.BigEndian.PutUint64(b[:heightSize], uint64(height))
+	binary.BigEndian.PutUint32(b[heightSize:], index)
+
+	return b
+}
+
+func prefixed(prefix byte, b []byte) []byte {
+	return append([]byte{prefix}, b...)
+}
+
+func uint64Key(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+
+	return b
+}
+
+func uint16Key(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+
+	return b
+}
+
+func hashToString(h *sdk.Hash) string {
+	if h == nil {
+		return ""
+	}
+
+	return h.String()
+}
+
+func stringToHash(s string) (*sdk.Hash, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	return sdk.StringToHash(s)
+}
+
+func sameHash(a, b *sdk.Hash) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,245 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+var testGenerationHash = testHash("generation")
+
+type testChain struct {
+	sender, recipient *sdk.Account
+	mosaicId          *sdk.MosaicId
+	aliasId           *sdk.NamespaceId
+	blocks            []*Block
+}
+
+func testHash(s string) *sdk.Hash {
+	h := sdk.Hash(sha256.Sum256([]byte(s)))
+	return &h
+}
+
+func newTestAccount(t *testing.T) *sdk.Account {
+	acc, err := sdk.NewAccount(sdk.MijinTest, testGenerationHash)
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	return acc
+}
+
+// returns transaction as it is returned by REST, with signer and TransactionInfo
+func confirmedTransaction(t *testing.T, signer *sdk.Account, tx sdk.Transaction, height sdk.Height, index uint32) sdk.Transaction {
+	stx, err := signer.Sign(tx)
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	parsed, err := sdk.ParseSignedTransaction(stx)
+	assert.Nilf(t, err, "ParseSignedTransaction returned error: %s", err)
+
+	info := &parsed.GetAbstractTransaction().TransactionInfo
+	info.Height = height
+	info.Index = index
+	info.Id = fmt.Sprintf("%024X", uint64(height)<<8|uint64(index))
+	info.MerkleComponentHash = info.TransactionHash
+
+	return parsed
+}
+
+func newTestChain(t *testing.T) *testChain {
+	c := &testChain{
+		sender:    newTestAccount(t),
+		recipient: newTestAccount(t),
+	}
+
+	var err error
+	c.mosaicId, err = sdk.NewMosaicIdFromNonceAndOwner(1, c.sender.PublicAccount.PublicKey)
+	assert.Nilf(t, err, "NewMosaicIdFromNonceAndOwner returned error: %s", err)
+
+	c.aliasId, err = sdk.NewNamespaceIdFromName("indexer")
+	assert.Nilf(t, err, "NewNamespaceIdFromName returned error: %s", err)
+
+	mosaic, err := sdk.NewMosaic(c.mosaicId, 10)
+	assert.Nilf(t, err, "NewMosaic returned error: %s", err)
+
+	transfer, err := sdk.NewTransferTransaction(sdk.NewDeadline(time.Hour), c.recipient.Address, []*sdk.Mosaic{mosaic}, sdk.NewPlainMessage("first"), sdk.MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	register, err := sdk.NewRegisterRootNamespaceTransaction(sdk.NewDeadline(time.Hour), "indexer", sdk.Duration(100), sdk.MijinTest)
+	assert.Nilf(t, err, "NewRegisterRootNamespaceTransaction returned error: %s", err)
+
+	alias, err := sdk.NewAddressFromNamespace(c.aliasId)
+	assert.Nilf(t, err, "NewAddressFromNamespace returned error: %s", err)
+
+	aliasTransfer, err := sdk.NewTransferTransaction(sdk.NewDeadline(time.Hour), alias, []*sdk.Mosaic{sdk.Xpx(1)}, sdk.NewPlainMessage("second"), sdk.MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	c.addBlock([]sdk.Transaction{
+		confirmedTransaction(t, c.sender, transfer, 1, 0),
+		confirmedTransaction(t, c.sender, register, 1, 1),
+	})
+	c.addBlock([]sdk.Transaction{
+		confirmedTransaction(t, c.recipient, aliasTransfer, 2, 0),
+	})
+	c.addBlock(nil)
+
+	return c
+}
+
+func (c *testChain) addBlock(txs []sdk.Transaction) {
+	height := sdk.Height(len(c.blocks) + 1)
+	previousHash := testHash("genesis")
+	if len(c.blocks) > 0 {
+		previousHash = c.blocks[len(c.blocks)-1].Hash
+	}
+
+	c.blocks = append(c.blocks, &Block{
+		BlockHeader: BlockHeader{
+			Height:       height,
+			Hash:         testHash(fmt.Sprintf("block %d", height)),
+			PreviousHash: previousHash,
+		},
+		Transactions: txs,
+	})
+}
+
+func messages(txs []sdk.Transaction) []string {
+	res := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		switch tx := tx.(type) {
+		case *sdk.TransferTransaction:
+			res = append(res, string(tx.Message.Payload()))
+		case *sdk.RegisterNamespaceTransaction:
+			res = append(res, tx.NamspaceName)
+		}
+	}
+
+	return res
+}
+
+func transactions(t *testing.T, store Store, filter *Filter) []string {
+	txs, err := store.Transactions(filter)
+	assert.Nilf(t, err, "Transactions returned error: %s", err)
+
+	return messages(txs)
+}
+
+func testStore(t *testing.T, store Store) {
+	c := newTestChain(t)
+
+	last, err := store.LastBlock()
+	assert.Nilf(t, err, "LastBlock returned error: %s", err)
+	assert.Nil(t, last)
+
+	for _, b := range c.blocks {
+		err := store.PutBlock(b)
+		assert.Nilf(t, err, "PutBlock returned error: %s", err)
+	}
+
+	err = store.PutBlock(c.blocks[1])
+	assert.Equal(t, ErrDiscontinuousBlock, err)
+
+	last, err = store.LastBlock()
+	assert.Nilf(t, err, "LastBlock returned error: %s", err)
+	assert.Equal(t, c.blocks[2].BlockHeader, *last)
+
+	header, err := store.Block(2)
+	assert.Nilf(t, err, "Block returned error: %s", err)
+	assert.Equal(t, c.blocks[1].BlockHeader, *header)
+
+	assert.Equal(t, []string{"first", "indexer", "second"}, transactions(t, store, nil))
+	assert.Equal(t, []string{"first", "indexer"}, transactions(t, store, &Filter{Address: c.sender.Address}))
+	assert.Equal(t, []string{"first", "second"}, transactions(t, store, &Filter{Address: c.recipient.Address}))
+	assert.Equal(t, []string{"first"}, transactions(t, store, &Filter{MosaicId: c.mosaicId}))
+	assert.Equal(t, []string{"second"}, transactions(t, store, &Filter{NamespaceId: sdk.XpxNamespaceId}))
+	assert.Equal(t, []string{"indexer", "second"}, transactions(t, store, &Filter{NamespaceId: c.aliasId}))
+	assert.Equal(t, []string{"indexer"}, transactions(t, store, &Filter{Type: sdk.RegisterNamespace}))
+	assert.Equal(t, []string{"first"}, transactions(t, store, &Filter{Address: c.recipient.Address, ToHeight: 1}))
+	assert.Equal(t, []string{"second"}, transactions(t, store, &Filter{FromHeight: 2}))
+	assert.Equal(t, []string{"second", "indexer"}, transactions(t, store, &Filter{Descending: true, Limit: 2}))
+	assert.Equal(t, []string{"first"}, transactions(t, store, &Filter{Address: c.sender.Address, Type: sdk.Transfer}))
+
+	txs, err := store.Transactions(&Filter{Address: c.recipient.Address, FromHeight: 2})
+	assert.Nilf(t, err, "Transactions returned error: %s", err)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, c.blocks[1].Transactions[0], txs[0])
+
+	err = store.Rollback(1)
+	assert.Nilf(t, err, "Rollback returned error: %s", err)
+
+	last, err = store.LastBlock()
+	assert.Nilf(t, err, "LastBlock returned error: %s", err)
+	assert.Equal(t, sdk.Height(1), last.Height)
+
+	header, err = store.Block(2)
+	assert.Nilf(t, err, "Block returned error: %s", err)
+	assert.Nil(t, header)
+
+	assert.Equal(t, []string{"first"}, transactions(t, store, &Filter{Address: c.recipient.Address}))
+	assert.Equal(t, []string{"indexer"}, transactions(t, store, &Filter{NamespaceId: c.aliasId}))
+
+	err = store.PutBlock(c.blocks[1])
+	assert.Nilf(t, err, "PutBlock returned error: %s", err)
+	assert.Equal(t, []string{"first", "second"}, transactions(t, store, &Filter{Address: c.recipient.Address}))
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	testStore(t, store)
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	assert.Nilf(t, err, "TempDir returned error: %s", err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "index.log")
+
+	store, err := OpenFileStore(path)
+	assert.Nilf(t, err, "OpenFileStore returned error: %s", err)
+
+	testStore(t, store)
+	assert.Nil(t, store.Close())
+
+	expected := []string{"first", "indexer", "second"}
+
+	// incomplete record at the end of log is discarded
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte{100, 0, 0, 0, 1, 2, 3})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	store, err = OpenFileStore(path)
+	assert.Nilf(t, err, "OpenFileStore returned error: %s", err)
+	assert.Equal(t, expected, transactions(t, store, nil))
+
+	last, err := store.LastBlock()
+	assert.Nilf(t, err, "LastBlock returned error: %s", err)
+	assert.Equal(t, sdk.Height(2), last.Height)
+
+	err = store.backend.(*FileBackend).Compact()
+	assert.Nilf(t, err, "Compact returned error: %s", err)
+
+	err = store.Rollback(1)
+	assert.Nilf(t, err, "Rollback returned error: %s", err)
+	assert.Nil(t, store.Close())
+
+	store, err = OpenFileStore(path)
+	assert.Nilf(t, err, "OpenFileStore returned error: %s", err)
+	defer store.Close()
+
+	assert.Equal(t, []string{"first", "indexer"}, transactions(t, store, nil))
+}
@@ -0,0 +1,238 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServiceName identifies service of Client, names are equal to fields of Client
+type ServiceName string
+
+const (
+	AccountServiceName       ServiceName = "Account"
+	BlockchainServiceName    ServiceName = "Blockchain"
+	ContractServiceName      ServiceName = "Contract"
+	ExchangeServiceName      ServiceName = "Exchange"
+	LockServiceName          ServiceName = "Lock"
+	MetadataServiceName      ServiceName = "Metadata"
+	MosaicServiceName        ServiceName = "Mosaic"
+	NamespaceServiceName     ServiceName = "Namespace"
+	NetworkServiceName       ServiceName = "Network"
+	ResolverServiceName      ServiceName = "Resolve"
+	StorageServiceName       ServiceName = "Storage"
+	SuperContractServiceName ServiceName = "SuperContract"
+	TransactionServiceName   ServiceName = "Transaction"
+	// requests of NodePool probing nodes
+	NodePoolServiceName ServiceName = "Nodes"
+)
+
+// RequestOperation is a logical operation of Client which sends REST request, e.g. Account.GetAccountInfo
+type RequestOperation struct {
+	Service ServiceName
+	Method  string
+}
+
+func (o RequestOperation) String() string {
+	return fmt.Sprintf("%s.%s", o.Service, o.Method)
+}
+
+// Call is a single REST request made by Client
+type Call struct {
+	Operation RequestOperation
+	Request   *http.Request
+	// response decoded by Invoker, it is filled only when Invoker returned without error
+	Response interface{}
+	// time which request waited for rate limiter of node, it is filled by Invoker
+	Wait time.Duration
+}
+
+// Invoker sends request of call and decodes response into call.Response
+type Invoker func(ctx context.Context, call *Call) (*http.Response, error)
+
+// Interceptor wraps every REST request of Client. It can change request before calling next
+// and inspect response or error returned by next. Interceptors are set in Config.Interceptors,
+// the first interceptor is the outermost one
+type Interceptor func(ctx context.Context, call *Call, next Invoker) (*http.Response, error)
+
+// returns Invoker which passes call through interceptors to invoker
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+
+		invoker = func(ctx context.Context, call *Call) (*http.Response, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+
+	return invoker
+}
+
+// returns Interceptor which sets passed header of every request, e.g. authorization header of gateway
+func HeaderInterceptor(key, value string) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+		call.Request.Header.Set(key, value)
+
+		return next(ctx, call)
+	}
+}
+
+// returns Interceptor which writes every request to logger as key=value pairs:
+//
+//	operation=Account.GetAccountInfo method=GET url=http://node:3000/account/... status=200 duration=12ms
+//
+// headers are not written, so they can contain credentials
+func LoggingInterceptor(logger *log.Logger) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+		start := time.Now()
+
+		resp, err := next(ctx, call)
+
+		fields := []string{
+			fmt.Sprintf("operation=%s", call.Operation),
+			fmt.Sprintf("method=%s", call.Request.Method),
+			fmt.Sprintf("url=%s", call.Request.URL),
+			fmt.Sprintf("status=%d", responseStatus(resp, err)),
+			fmt.Sprintf("duration=%s", time.Since(start)),
+		}
+
+		if call.Wait > 0 {
+			fields = append(fields, fmt.Sprintf("wait=%s", call.Wait))
+		}
+
+		if err != nil {
+			fields = append(fields, fmt.Sprintf("error=%q", err.Error()))
+		}
+
+		logger.Println(strings.Join(fields, " "))
+
+		return resp, err
+	}
+}
+
+// OperationStats contains timings of requests of one operation
+type OperationStats struct {
+	Operation     RequestOperation
+	Count         uint64
+	Errors        uint64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	// time which requests waited for rate limiters of nodes, it is included in durations
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// returns mean duration of request
+func (s *OperationStats) MeanDuration() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.TotalDuration / time.Duration(s.Count)
+}
+
+func (s *OperationStats) String() string {
+	return fmt.Sprintf(
+		`[Operation: %s, Count: %d, Errors: %d, MeanDuration: %s, MaxDuration: %s, TotalWait: %s, MaxWait: %s]`,
+		s.Operation,
+		s.Count,
+		s.Errors,
+		s.MeanDuration(),
+		s.MaxDuration,
+		s.TotalWait,
+		s.MaxWait,
+	)
+}
+
+// OperationMetrics collects timings of requests by operation
+type OperationMetrics struct {
+	sync.Mutex
+	stats map[RequestOperation]*OperationStats
+}
+
+func NewOperationMetrics() *OperationMetrics {
+	return &OperationMetrics{
+		stats: make(map[RequestOperation]*OperationStats),
+	}
+}
+
+// returns Interceptor which records timing of every request to metrics
+func (m *OperationMetrics) Interceptor() Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+		start := time.Now()
+
+		resp, err := next(ctx, call)
+
+		m.record(call.Operation, time.Since(start), call.Wait, err)
+
+		return resp, err
+	}
+}
+
+// returns stats of every operation sorted by operation name
+func (m *OperationMetrics) Stats() []*OperationStats {
+	m.Lock()
+	defer m.Unlock()
+
+	stats := make([]*OperationStats, 0, len(m.stats))
+	for _, s := range m.stats {
+		copied := *s
+		stats = append(stats, &copied)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Operation.String() < stats[j].Operation.String()
+	})
+
+	return stats
+}
+
+func (m *OperationMetrics) record(op RequestOperation, duration time.Duration, wait time.Duration, err error) {
+	m.Lock()
+	defer m.Unlock()
+
+	s, ok := m.stats[op]
+	if !ok {
+		s = &OperationStats{Operation: op}
+		m.stats[op] = s
+	}
+
+	s.Count++
+	s.TotalDuration += duration
+
+	if duration > s.MaxDuration {
+		s.MaxDuration = duration
+	}
+
+	s.TotalWait += wait
+
+	if wait > s.MaxWait {
+		s.MaxWait = wait
+	}
+
+	if err != nil {
+		s.Errors++
+	}
+}
+
+// returns status code of response, 0 is returned when node didn't respond
+func responseStatus(resp *http.Response, err error) int {
+	if resp != nil {
+		return resp.StatusCode
+	}
+
+	if apiErr, ok := err.(*ApiError); ok {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
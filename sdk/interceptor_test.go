@@ -0,0 +1,150 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newInterceptorClient(t *testing.T, handler http.HandlerFunc, interceptors ...Interceptor) (*Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	client := newRetryClient(t, server, NoRetryPolicy())
+	client.config.Interceptors = interceptors
+
+	return client, server
+}
+
+func TestInterceptor_Chain(t *testing.T) {
+	var order []string
+	var calls []*Call
+
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call *Call, next Invoker) (*http.Response, error) {
+			order = append(order, name+" before")
+			resp, err := next(ctx, call)
+			order = append(order, name+" after")
+
+			calls = append(calls, call)
+
+			return resp, err
+		}
+	}
+
+	var authorization string
+	client, server := newInterceptorClient(t, func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"height": [42, 0]}`))
+	}, record("first"), HeaderInterceptor("Authorization", "Bearer token"), record("second"))
+	defer server.Close()
+
+	height, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	assert.Equal(t, Height(42), height)
+
+	assert.Equal(t, "Bearer token", authorization)
+	assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, order)
+
+	for _, call := range calls {
+		assert.Equal(t, "Blockchain.GetBlockchainHeight", call.Operation.String())
+		assert.Equal(t, blockHeightRoute, call.Request.URL.Path)
+		assert.NotNil(t, call.Response)
+	}
+}
+
+func TestInterceptor_Error(t *testing.T) {
+	var call *Call
+	var callErr error
+
+	client, server := newInterceptorClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, func(ctx context.Context, c *Call, next Invoker) (*http.Response, error) {
+		resp, err := next(ctx, c)
+		call, callErr = c, err
+
+		return resp, err
+	})
+	defer server.Close()
+
+	_, err := client.Account.GetAccountInfo(ctx, &Address{Address: "SAONSOGFZZHNEIBRYXHDTDTBR2YSAXKTITRFHG2Y"})
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "Account.GetAccountInfo", call.Operation.String())
+	assert.Nil(t, call.Response)
+	assert.Equal(t, err, callErr)
+	assert.Equal(t, http.StatusNotFound, responseStatus(nil, callErr))
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	client, server := newInterceptorClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == blockHeightRoute {
+			w.Write([]byte(`{"height": [42, 0]}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}, LoggingInterceptor(log.New(buf, "", 0)))
+	defer server.Close()
+
+	_, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+
+	_, err = client.Blockchain.GetBlockByHeight(ctx, Height(1))
+	assert.NotNil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	assert.Contains(t, lines[0], "operation=Blockchain.GetBlockchainHeight method=GET url="+server.URL+blockHeightRoute+" status=200 duration=")
+	assert.NotContains(t, lines[0], "error=")
+
+	assert.Contains(t, lines[1], "operation=Blockchain.GetBlockByHeight method=GET")
+	assert.Contains(t, lines[1], "status=404")
+	assert.Contains(t, lines[1], "error=")
+}
+
+func TestOperationMetrics(t *testing.T) {
+	metrics := NewOperationMetrics()
+
+	client, server := newInterceptorClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == blockHeightRoute {
+			w.Write([]byte(`{"height": [42, 0]}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}, metrics.Interceptor())
+	defer server.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Blockchain.GetBlockchainHeight(ctx)
+		assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	}
+
+	_, err := client.Blockchain.GetBlockByHeight(ctx, Height(1))
+	assert.NotNil(t, err)
+
+	stats := metrics.Stats()
+	assert.Len(t, stats, 2)
+
+	assert.Equal(t, RequestOperation{BlockchainServiceName, "GetBlockByHeight"}, stats[0].Operation)
+	assert.Equal(t, uint64(1), stats[0].Count)
+	assert.Equal(t, uint64(1), stats[0].Errors)
+
+	assert.Equal(t, RequestOperation{BlockchainServiceName, "GetBlockchainHeight"}, stats[1].Operation)
+	assert.Equal(t, uint64(3), stats[1].Count)
+	assert.Equal(t, uint64(0), stats[1].Errors)
+	assert.True(t, stats[1].MaxDuration >= stats[1].MeanDuration())
+}
@@ -0,0 +1,369 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+)
+
+const (
+	// page size used by iterators when page size is not set
+	DefaultIteratorPageSize = 100
+	// maximum number of blocks which node returns for one request
+	DefaultIteratorBlocksLimit = Amount(100)
+)
+
+// TransactionFilter returns true if transaction should be returned by iterator
+type TransactionFilter func(tx Transaction) bool
+
+// returns TransactionFilter which passes only transactions of passed types
+func FilterByEntityType(types ...EntityType) TransactionFilter {
+	return func(tx Transaction) bool {
+		t := tx.GetAbstractTransaction().Type
+
+		for _, allowed := range types {
+			if allowed == t {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// fetchPage returns next page of items and true if there are no pages after it
+type fetchPage func(ctx context.Context) ([]interface{}, bool, error)
+
+// pageIterator walks items of pages returned by fetch
+type pageIterator struct {
+	fetch   fetchPage
+	filter  func(interface{}) bool
+	page    []interface{}
+	current interface{}
+	last    bool
+	err     error
+}
+
+func (it *pageIterator) next(ctx context.Context) bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		if len(it.page) == 0 {
+			if it.last {
+				return false
+			}
+
+			it.page, it.last, it.err = it.fetch(ctx)
+			continue
+		}
+
+		it.current, it.page = it.page[0], it.page[1:]
+
+		if it.filter == nil || it.filter(it.current) {
+			return true
+		}
+	}
+}
+
+// TransactionIterator walks every page of account transactions.
+// Use Next to move to the next transaction and Err to check why iteration was stopped:
+//
+//	it := client.Account.TransactionsIterator(account, nil)
+//	for it.Next(ctx) {
+//		fmt.Println(it.Transaction())
+//	}
+//	if it.Err() != nil { ... }
+type TransactionIterator struct {
+	pageIterator
+}
+
+func newTransactionIterator(opt *AccountTransactionsOption, find func(ctx context.Context, opt *AccountTransactionsOption) ([]Transaction, error)) *TransactionIterator {
+	pageOpt := AccountTransactionsOption{PageSize: DefaultIteratorPageSize}
+	if opt != nil {
+		pageOpt = *opt
+	}
+
+	if pageOpt.PageSize <= 0 {
+		pageOpt.PageSize = DefaultIteratorPageSize
+	}
+
+	it := &TransactionIterator{}
+	it.fetch = func(ctx context.Context) ([]interface{}, bool, error) {
+		txs, err := find(ctx, &pageOpt)
+		if err != nil {
+			return nil, false, err
+		}
+
+		items := make([]interface{}, len(txs))
+		for i, tx := range txs {
+			items[i] = tx
+		}
+
+		if len(txs) == 0 {
+			return items, true, nil
+		}
+
+		// next page starts after the last transaction of current one in the same ordering.
+		// Node caps page size, so short page doesn't mean the last one
+		id := txs[len(txs)-1].GetAbstractTransaction().Id
+		last := id == "" || id == pageOpt.Id
+		pageOpt.Id = id
+
+		return items, last, nil
+	}
+
+	return it
+}
+
+// sets filter of transactions, transactions which don't pass filter are skipped
+func (it *TransactionIterator) Filter(filter TransactionFilter) *TransactionIterator {
+	if filter == nil {
+		it.filter = nil
+		return it
+	}
+
+	it.filter = func(item interface{}) bool {
+		return filter(item.(Transaction))
+	}
+
+	return it
+}
+
+// moves to the next transaction, returns false when there are no more transactions or error occurred
+func (it *TransactionIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// returns current transaction
+func (it *TransactionIterator) Transaction() Transaction {
+	tx, _ := it.current.(Transaction)
+	return tx
+}
+
+// returns error which stopped iteration
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+// returns channel with all remaining transactions, channel is closed when iteration is stopped.
+// Err should be checked after channel is closed
+func (it *TransactionIterator) Channel(ctx context.Context) <-chan Transaction {
+	ch := make(chan Transaction)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next(ctx) {
+			select {
+			case ch <- it.Transaction():
+			case <-ctx.Done():
+				it.err = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// BlockIterator walks blocks in ascending order of height
+type BlockIterator struct {
+	pageIterator
+}
+
+// moves to the next block, returns false when there are no more blocks or error occurred
+func (it *BlockIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// returns current block
+func (it *BlockIterator) Block() *BlockInfo {
+	block, _ := it.current.(*BlockInfo)
+	return block
+}
+
+// returns error which stopped iteration
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// returns channel with all remaining blocks, channel is closed when iteration is stopped.
+// Err should be checked after channel is closed
+func (it *BlockIterator) Channel(ctx context.Context) <-chan *BlockInfo {
+	ch := make(chan *BlockInfo)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next(ctx) {
+			select {
+			case ch <- it.Block():
+			case <-ctx.Done():
+				it.err = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// NamespaceIterator walks every page of namespaces
+type NamespaceIterator struct {
+	pageIterator
+}
+
+// moves to the next namespace, returns false when there are no more namespaces or error occurred
+func (it *NamespaceIterator) Next(ctx context.Context) bool {
+	return it.next(ctx)
+}
+
+// returns current namespace
+func (it *NamespaceIterator) NamespaceInfo() *NamespaceInfo {
+	info, _ := it.current.(*NamespaceInfo)
+	return info
+}
+
+// returns error which stopped iteration
+func (it *NamespaceIterator) Err() error {
+	return it.err
+}
+
+// returns channel with all remaining namespaces, channel is closed when iteration is stopped.
+// Err should be checked after channel is closed
+func (it *NamespaceIterator) Channel(ctx context.Context) <-chan *NamespaceInfo {
+	ch := make(chan *NamespaceInfo)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next(ctx) {
+			select {
+			case ch <- it.NamespaceInfo():
+			case <-ctx.Done():
+				it.err = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// returns TransactionIterator over confirmed transactions for which passed account is sender or receiver.
+// Id of opt is a start position, pages are requested in Ordering of opt
+func (a *AccountService) TransactionsIterator(account *PublicAccount, opt *AccountTransactionsOption) *TransactionIterator {
+	return a.transactionsIterator("Transactions", account, opt, accountTransactionsRoute)
+}
+
+// returns TransactionIterator over transactions for which passed account is receiver
+func (a *AccountService) IncomingTransactionsIterator(account *PublicAccount, opt *AccountTransactionsOption) *TransactionIterator {
+	return a.transactionsIterator("IncomingTransactions", account, opt, incomingTransactionsRoute)
+}
+
+// returns TransactionIterator over transactions for which passed account is sender
+func (a *AccountService) OutgoingTransactionsIterator(account *PublicAccount, opt *AccountTransactionsOption) *TransactionIterator {
+	return a.transactionsIterator("OutgoingTransactions", account, opt, outgoingTransactionsRoute)
+}
+
+// returns TransactionIterator over unconfirmed transactions for which passed account is sender or receiver
+func (a *AccountService) UnconfirmedTransactionsIterator(account *PublicAccount, opt *AccountTransactionsOption) *TransactionIterator {
+	return a.transactionsIterator("UnconfirmedTransactions", account, opt, unconfirmedTransactionsRoute)
+}
+
+func (a *AccountService) transactionsIterator(operation string, account *PublicAccount, opt *AccountTransactionsOption, path string) *TransactionIterator {
+	return newTransactionIterator(opt, func(ctx context.Context, opt *AccountTransactionsOption) ([]Transaction, error) {
+		return a.findTransactions(ctx, operation, account, opt, path)
+	})
+}
+
+// returns TransactionIterator over all transactions of block at passed height.
+// Id of opt is a start position, pages are requested in Ordering of opt
+func (b *BlockchainService) BlockTransactionsIterator(height Height, opt *AccountTransactionsOption) *TransactionIterator {
+	return newTransactionIterator(opt, func(ctx context.Context, opt *AccountTransactionsOption) ([]Transaction, error) {
+		return b.getBlockTransactions(ctx, height, opt)
+	})
+}
+
+// returns BlockIterator over blocks starting from passed height up to the current height of blockchain.
+// blocks are requested by pages of passed limit, DefaultIteratorBlocksLimit is used if limit is 0 or greater than it
+func (b *BlockchainService) BlocksIterator(height Height, limit Amount) *BlockIterator {
+	if limit == 0 || limit > DefaultIteratorBlocksLimit {
+		limit = DefaultIteratorBlocksLimit
+	}
+
+	it := &BlockIterator{}
+	it.fetch = func(ctx context.Context) ([]interface{}, bool, error) {
+		blocks, err := b.GetBlocksByHeightWithLimit(ctx, height, limit)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].Height < blocks[j].Height
+		})
+
+		items := make([]interface{}, 0, len(blocks))
+		for _, block := range blocks {
+			// node can return blocks before passed height when limit exceeds the chain
+			if block.Height < height {
+				continue
+			}
+
+			items = append(items, block)
+		}
+
+		if len(items) == 0 {
+			return items, true, nil
+		}
+
+		height = items[len(items)-1].(*BlockInfo).Height + 1
+
+		return items, Amount(len(items)) < limit, nil
+	}
+
+	return it
+}
+
+// returns NamespaceIterator over namespaces owned by passed address, nsId is a start position
+func (ref *NamespaceService) NamespaceInfosFromAccountIterator(address *Address, nsId *NamespaceId, pageSize int) *NamespaceIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultIteratorPageSize
+	}
+
+	it := &NamespaceIterator{}
+	it.fetch = func(ctx context.Context) ([]interface{}, bool, error) {
+		infos, err := ref.GetNamespaceInfosFromAccount(ctx, address, nsId, pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+
+		items := make([]interface{}, len(infos))
+		for i, info := range infos {
+			items[i] = info
+		}
+
+		if len(infos) == 0 {
+			return items, true, nil
+		}
+
+		// node caps page size, so short page doesn't mean the last one
+		next := infos[len(infos)-1].NamespaceId
+		last := next == nil || (nsId != nil && next.Id() == nsId.Id())
+		nsId = next
+
+		return items, last, nil
+	}
+
+	return it
+}
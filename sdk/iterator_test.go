@@ -0,0 +1,150 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func iteratorTransactionsJson(ids ...string) string {
+	txs := make([]string, len(ids))
+	for i, id := range ids {
+		txs[i] = strings.Replace(transactionJson, "5B686E97F0C0EA00017B9437", id, 1)
+	}
+
+	return "[" + strings.Join(txs, ",") + "]"
+}
+
+// serves two pages of transactions at passed path, page is chosen by id of the last transaction of previous page
+func newIteratorTransactionsMock(t *testing.T, path string, ordering TransactionOrder) *sdkMock {
+	m := newSdkMock(0)
+
+	m.AddHandler(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, string(ordering), query.Get("ordering"))
+		assert.Equal(t, "2", query.Get("pageSize"))
+
+		switch query.Get("id") {
+		case "":
+			fmt.Fprint(w, iteratorTransactionsJson("5B686E97F0C0EA00017B9431", "5B686E97F0C0EA00017B9432"))
+		case "5B686E97F0C0EA00017B9432":
+			// node can return less transactions than page size before the last page
+			fmt.Fprint(w, iteratorTransactionsJson("5B686E97F0C0EA00017B9433"))
+		default:
+			fmt.Fprint(w, "[]")
+		}
+	})
+
+	return m
+}
+
+func iteratorAccount() *PublicAccount {
+	return &PublicAccount{&Address{MijinTest, nemTestAddress2}, publicKey1}
+}
+
+func TestAccountService_TransactionsIterator(t *testing.T) {
+	mockServ := newIteratorTransactionsMock(t, fmt.Sprintf("/account/%s/transactions", publicKey1), TRANSACTION_ORDER_DESC)
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+
+	it := client.Account.TransactionsIterator(iteratorAccount(), &AccountTransactionsOption{PageSize: 2, Ordering: TRANSACTION_ORDER_DESC})
+
+	ids := make([]string, 0)
+	for it.Next(ctx) {
+		ids = append(ids, it.Transaction().GetAbstractTransaction().Id)
+	}
+
+	assert.Nilf(t, it.Err(), "TransactionIterator returned error: %s", it.Err())
+	assert.Equal(t, []string{"5B686E97F0C0EA00017B9431", "5B686E97F0C0EA00017B9432", "5B686E97F0C0EA00017B9433"}, ids)
+}
+
+func TestAccountService_TransactionsIterator_Filter(t *testing.T) {
+	mockServ := newIteratorTransactionsMock(t, fmt.Sprintf("/account/%s/transactions", publicKey1), TRANSACTION_ORDER_DESC)
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+	opt := &AccountTransactionsOption{PageSize: 2, Ordering: TRANSACTION_ORDER_DESC}
+
+	it := client.Account.TransactionsIterator(iteratorAccount(), opt).Filter(FilterByEntityType(AggregateCompleted))
+	assert.False(t, it.Next(ctx))
+	assert.Nilf(t, it.Err(), "TransactionIterator returned error: %s", it.Err())
+
+	it = client.Account.TransactionsIterator(iteratorAccount(), opt).Filter(FilterByEntityType(Transfer))
+
+	count := 0
+	for range it.Channel(ctx) {
+		count++
+	}
+
+	assert.Nilf(t, it.Err(), "TransactionIterator returned error: %s", it.Err())
+	assert.Equal(t, 3, count)
+}
+
+func TestAccountService_TransactionsIterator_Cancel(t *testing.T) {
+	mockServ := newIteratorTransactionsMock(t, fmt.Sprintf("/account/%s/transactions", publicKey1), TRANSACTION_ORDER_DESC)
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+
+	it := client.Account.TransactionsIterator(iteratorAccount(), &AccountTransactionsOption{PageSize: 2, Ordering: TRANSACTION_ORDER_DESC})
+	assert.True(t, it.Next(cancelCtx))
+
+	cancel()
+
+	assert.False(t, it.Next(cancelCtx))
+	assert.Equal(t, context.Canceled, it.Err())
+}
+
+func TestBlockchainService_BlockTransactionsIterator(t *testing.T) {
+	mockServ := newIteratorTransactionsMock(t, fmt.Sprintf(blockGetTransactionRoute, testHeight), "")
+	defer mockServ.Close()
+
+	client := mockServ.getPublicTestClientUnsafe()
+
+	it := client.Blockchain.BlockTransactionsIterator(testHeight, &AccountTransactionsOption{PageSize: 2})
+
+	ids := make([]string, 0)
+	for it.Next(ctx) {
+		ids = append(ids, it.Transaction().GetAbstractTransaction().Id)
+	}
+
+	assert.Nilf(t, it.Err(), "TransactionIterator returned error: %s", it.Err())
+	assert.Equal(t, []string{"5B686E97F0C0EA00017B9431", "5B686E97F0C0EA00017B9432", "5B686E97F0C0EA00017B9433"}, ids)
+}
+
+func TestBlockchainService_BlocksIterator(t *testing.T) {
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     fmt.Sprintf(blockInfoRoute, Height(1), Amount(2)),
+		RespBody: "[" + fmt.Sprintf(feeBlockJSONTpl, 2, 0, 1, 1) + "," + fmt.Sprintf(feeBlockJSONTpl, 1, 0, 1, 1) + "]",
+	})
+	defer mockServ.Close()
+
+	mockServ.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(blockInfoRoute, Height(3), Amount(2)),
+		RespBody: "[" + fmt.Sprintf(feeBlockJSONTpl, 3, 0, 1, 1) + "]",
+	})
+
+	client := mockServ.getPublicTestClientUnsafe()
+
+	it := client.Blockchain.BlocksIterator(Height(1), Amount(2))
+
+	heights := make([]Height, 0)
+	for it.Next(ctx) {
+		heights = append(heights, it.Block().Height)
+	}
+
+	assert.Nilf(t, it.Err(), "BlockIterator returned error: %s", it.Err())
+	assert.Equal(t, []Height{1, 2, 3}, heights)
+}
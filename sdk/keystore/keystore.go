@@ -0,0 +1,463 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+// Package keystore stores password encrypted accounts in a single versioned JSON file.
+// Keys are encrypted with AES-256-GCM under a key derived from password by scrypt.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const (
+	// version of keystore file format
+	Version = 1
+
+	// scrypt parameters recommended for interactive usage
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// scrypt parameters which use less memory and CPU, e.g. for tests or constrained devices
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR      = 8
+	scryptKeyLen = 32
+	saltSize     = 32
+
+	kdfScrypt    = "scrypt"
+	cipherAesGcm = "aes-256-gcm"
+)
+
+var (
+	ErrAccountExists      = errors.New("account with the same name already exists")
+	ErrAccountNotFound    = errors.New("account is not found")
+	ErrAccountLocked      = errors.New("account is locked")
+	ErrEmptyName          = errors.New("name of account should not be empty")
+	ErrNilAccount         = errors.New("account should not be nil")
+	ErrWrongPassword      = errors.New("password is wrong")
+	ErrUnsupportedVersion = errors.New("version of keystore file is not supported")
+	ErrUnsupportedCrypto  = errors.New("kdf or cipher of account is not supported")
+	ErrKeyMismatch        = errors.New("decrypted key doesn't match public key of account")
+)
+
+// Entry describes an account stored in Keystore without its private key
+type Entry struct {
+	Name           string
+	PublicAccount  *sdk.PublicAccount
+	NetworkType    sdk.NetworkType
+	GenerationHash *sdk.Hash
+}
+
+type scryptParamsJSON struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"keyLen"`
+	Salt   string `json:"salt"`
+}
+
+type cryptoJSON struct {
+	Cipher     string           `json:"cipher"`
+	CipherText string           `json:"cipherText"`
+	Nonce      string           `json:"nonce"`
+	KDF        string           `json:"kdf"`
+	KDFParams  scryptParamsJSON `json:"kdfParams"`
+}
+
+type entryJSON struct {
+	Name           string          `json:"name"`
+	PublicKey      string          `json:"publicKey"`
+	NetworkType    sdk.NetworkType `json:"networkType"`
+	GenerationHash string          `json:"generationHash,omitempty"`
+	Crypto         cryptoJSON      `json:"crypto"`
+}
+
+type keystoreJSON struct {
+	Version  int          `json:"version"`
+	Accounts []*entryJSON `json:"accounts"`
+}
+
+// Keystore keeps encrypted accounts in file and unlocked accounts in memory.
+// Every modification is written to file immediately
+type Keystore struct {
+	mutex    sync.RWMutex
+	path     string
+	scryptN  int
+	scryptP  int
+	entries  []*entryJSON
+	unlocked map[string]*sdk.Account
+}
+
+// returns Keystore backed by file at passed path, file is created on first modification if it doesn't exist.
+// scryptN and scryptP are used to encrypt new keys, already stored keys keep their own parameters
+func Open(path string, scryptN, scryptP int) (*Keystore, error) {
+	ks := &Keystore{
+		path:     path,
+		scryptN:  scryptN,
+		scryptP:  scryptP,
+		entries:  make([]*entryJSON, 0),
+		unlocked: make(map[string]*sdk.Account),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &keystoreJSON{}
+	if err := json.Unmarshal(b, dto); err != nil {
+		return nil, err
+	}
+
+	if dto.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	if dto.Accounts != nil {
+		ks.entries = dto.Accounts
+	}
+
+	return ks, nil
+}
+
+// returns description of every stored account in order of import
+func (ks *Keystore) List() ([]*Entry, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+
+	entries := make([]*Entry, len(ks.entries))
+	for i, e := range ks.entries {
+		entry, err := e.toStruct()
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i] = entry
+	}
+
+	return entries, nil
+}
+
+// encrypts private key of passed Account with password and stores it with passed name
+func (ks *Keystore) Import(name string, account *sdk.Account, password string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+
+	if account == nil || account.KeyPair == nil || account.PublicAccount == nil {
+		return ErrNilAccount
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	if ks.find(name) != nil {
+		return ErrAccountExists
+	}
+
+	e, err := ks.encrypt(name, account, password)
+	if err != nil {
+		return err
+	}
+
+	ks.entries = append(ks.entries, e)
+
+	if err := ks.save(); err != nil {
+		ks.entries = ks.entries[:len(ks.entries)-1]
+		return err
+	}
+
+	return nil
+}
+
+// decrypts account with passed name and keeps it in memory until Lock is called
+// returned Account can be used with Client.AdaptAccount
+func (ks *Keystore) Unlock(name string, password string) (*sdk.Account, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	e := ks.find(name)
+	if e == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	acc, err := e.decrypt(password)
+	if err != nil {
+		return nil, err
+	}
+
+	ks.unlocked[name] = acc
+
+	return acc, nil
+}
+
+// removes unlocked account with passed name from memory
+func (ks *Keystore) Lock(name string) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	if ks.find(name) == nil {
+		return ErrAccountNotFound
+	}
+
+	delete(ks.unlocked, name)
+
+	return nil
+}
+
+// returns unlocked account with passed name
+func (ks *Keystore) Account(name string) (*sdk.Account, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+
+	if ks.find(name) == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	acc, ok := ks.unlocked[name]
+	if !ok {
+		return nil, ErrAccountLocked
+	}
+
+	return acc, nil
+}
+
+// returns hex encoded private key of account with passed name
+func (ks *Keystore) Export(name string, password string) (string, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+
+	e := ks.find(name)
+	if e == nil {
+		return "", ErrAccountNotFound
+	}
+
+	acc, err := e.decrypt(password)
+	if err != nil {
+		return "", err
+	}
+
+	return acc.PrivateKey.String(), nil
+}
+
+// re-encrypts account with passed name with new password
+func (ks *Keystore) ChangePassword(name string, oldPassword string, newPassword string) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	e := ks.find(name)
+	if e == nil {
+		return ErrAccountNotFound
+	}
+
+	acc, err := e.decrypt(oldPassword)
+	if err != nil {
+		return err
+	}
+
+	ne, err := ks.encrypt(name, acc, newPassword)
+	if err != nil {
+		return err
+	}
+
+	*e, *ne = *ne, *e
+
+	if err := ks.save(); err != nil {
+		*e = *ne
+		return err
+	}
+
+	return nil
+}
+
+func (ks *Keystore) find(name string) *entryJSON {
+	for _, e := range ks.entries {
+		if e.Name == name {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (ks *Keystore) encrypt(name string, account *sdk.Account, password string) (*entryJSON, error) {
+	key, err := hex.DecodeString(account.PrivateKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	params := scryptParamsJSON{
+		N:      ks.scryptN,
+		R:      scryptR,
+		P:      ks.scryptP,
+		KeyLen: scryptKeyLen,
+		Salt:   hex.EncodeToString(salt),
+	}
+
+	aead, err := params.aead(password)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	publicKey := account.PublicAccount.PublicKey
+
+	e := &entryJSON{
+		Name:        name,
+		PublicKey:   publicKey,
+		NetworkType: account.PublicAccount.Address.Type,
+		Crypto: cryptoJSON{
+			Cipher:     cipherAesGcm,
+			CipherText: hex.EncodeToString(aead.Seal(nil, nonce, key, []byte(publicKey))),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        kdfScrypt,
+			KDFParams:  params,
+		},
+	}
+
+	if account.GenerationHash() != nil {
+		e.GenerationHash = account.GenerationHash().String()
+	}
+
+	return e, nil
+}
+
+// writes keystore to temporary file and replaces original one, so file is never left half written
+func (ks *Keystore) save() error {
+	b, err := json.MarshalIndent(&keystoreJSON{Version, ks.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(ks.path), filepath.Base(ks.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), ks.path)
+}
+
+func (p *scryptParamsJSON) aead(password string) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(p.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, p.N, p.R, p.P, p.KeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (e *entryJSON) decrypt(password string) (*sdk.Account, error) {
+	if e.Crypto.KDF != kdfScrypt || e.Crypto.Cipher != cipherAesGcm {
+		return nil, ErrUnsupportedCrypto
+	}
+
+	aead, err := e.Crypto.KDFParams.aead(password)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(e.Crypto.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(e.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrUnsupportedCrypto
+	}
+
+	key, err := aead.Open(nil, nonce, cipherText, []byte(e.PublicKey))
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	var generationHash *sdk.Hash
+	if e.GenerationHash != "" {
+		generationHash, err = sdk.StringToHash(e.GenerationHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	acc, err := sdk.NewAccountFromPrivateKey(hex.EncodeToString(key), e.NetworkType, generationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(acc.PublicAccount.PublicKey, e.PublicKey) {
+		return nil, ErrKeyMismatch
+	}
+
+	return acc, nil
+}
+
+func (e *entryJSON) toStruct() (*Entry, error) {
+	pa, err := sdk.NewAccountFromPublicKey(e.PublicKey, e.NetworkType)
+	if err != nil {
+		return nil, err
+	}
+
+	var generationHash *sdk.Hash
+	if e.GenerationHash != "" {
+		generationHash, err = sdk.StringToHash(e.GenerationHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Entry{e.Name, pa, e.NetworkType, generationHash}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const (
+	testPrivateKey     = "2a2b1f5d366a5dd5dc56c3c757cf4fe6c66e2787087692cf329d7a49a594658b"
+	testPassword       = "password"
+	testNewPassword    = "new-password"
+	testGenerationHash = "86258172F90639811F2ABD055747D1E11B55A64B68AED2CEA9A34FBD6C0BE790"
+)
+
+func newTestKeystore(t *testing.T) (*Keystore, string, func()) {
+	dir, err := ioutil.TempDir("", "keystore")
+	assert.Nilf(t, err, "ioutil.TempDir returned error: %s", err)
+
+	path := filepath.Join(dir, "keystore.json")
+
+	ks, err := Open(path, LightScryptN, LightScryptP)
+	assert.Nilf(t, err, "Open returned error: %s", err)
+
+	return ks, path, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func newTestAccount(t *testing.T) *sdk.Account {
+	generationHash, err := sdk.StringToHash(testGenerationHash)
+	assert.Nilf(t, err, "StringToHash returned error: %s", err)
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, generationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	return acc
+}
+
+func TestKeystore_ImportAndUnlock(t *testing.T) {
+	ks, path, closeFn := newTestKeystore(t)
+	defer closeFn()
+
+	acc := newTestAccount(t)
+
+	err := ks.Import("main", acc, testPassword)
+	assert.Nilf(t, err, "Keystore.Import returned error: %s", err)
+
+	err = ks.Import("main", acc, testPassword)
+	assert.Equal(t, ErrAccountExists, err)
+
+	b, err := ioutil.ReadFile(path)
+	assert.Nilf(t, err, "ioutil.ReadFile returned error: %s", err)
+	assert.False(t, strings.Contains(strings.ToLower(string(b)), testPrivateKey))
+
+	_, err = ks.Account("main")
+	assert.Equal(t, ErrAccountLocked, err)
+
+	_, err = ks.Unlock("main", "wrong")
+	assert.Equal(t, ErrWrongPassword, err)
+
+	unlocked, err := ks.Unlock("main", testPassword)
+	assert.Nilf(t, err, "Keystore.Unlock returned error: %s", err)
+	assert.Equal(t, acc.PublicAccount, unlocked.PublicAccount)
+	assert.Equal(t, acc.PrivateKey.String(), unlocked.PrivateKey.String())
+	assert.Equal(t, acc.GenerationHash(), unlocked.GenerationHash())
+
+	cached, err := ks.Account("main")
+	assert.Nilf(t, err, "Keystore.Account returned error: %s", err)
+	assert.Equal(t, unlocked, cached)
+
+	err = ks.Lock("main")
+	assert.Nilf(t, err, "Keystore.Lock returned error: %s", err)
+
+	_, err = ks.Account("main")
+	assert.Equal(t, ErrAccountLocked, err)
+
+	_, err = ks.Unlock("other", testPassword)
+	assert.Equal(t, ErrAccountNotFound, err)
+}
+
+func TestKeystore_ReopenAndList(t *testing.T) {
+	ks, path, closeFn := newTestKeystore(t)
+	defer closeFn()
+
+	acc := newTestAccount(t)
+	other, err := sdk.NewAccount(sdk.PublicTest, nil)
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	assert.Nil(t, ks.Import("main", acc, testPassword))
+	assert.Nil(t, ks.Import("other", other, testNewPassword))
+
+	reopened, err := Open(path, LightScryptN, LightScryptP)
+	assert.Nilf(t, err, "Open returned error: %s", err)
+
+	entries, err := reopened.List()
+	assert.Nilf(t, err, "Keystore.List returned error: %s", err)
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "main", entries[0].Name)
+	assert.Equal(t, acc.PublicAccount, entries[0].PublicAccount)
+	assert.Equal(t, sdk.MijinTest, entries[0].NetworkType)
+	assert.Equal(t, acc.GenerationHash(), entries[0].GenerationHash)
+	assert.Equal(t, "other", entries[1].Name)
+	assert.Equal(t, sdk.PublicTest, entries[1].NetworkType)
+	assert.Nil(t, entries[1].GenerationHash)
+
+	unlocked, err := reopened.Unlock("other", testNewPassword)
+	assert.Nilf(t, err, "Keystore.Unlock returned error: %s", err)
+	assert.Equal(t, other.PublicAccount, unlocked.PublicAccount)
+}
+
+func TestKeystore_ExportAndChangePassword(t *testing.T) {
+	ks, path, closeFn := newTestKeystore(t)
+	defer closeFn()
+
+	assert.Nil(t, ks.Import("main", newTestAccount(t), testPassword))
+
+	_, err := ks.Export("main", "wrong")
+	assert.Equal(t, ErrWrongPassword, err)
+
+	key, err := ks.Export("main", testPassword)
+	assert.Nilf(t, err, "Keystore.Export returned error: %s", err)
+	assert.Equal(t, testPrivateKey, strings.ToLower(key))
+
+	err = ks.ChangePassword("main", "wrong", testNewPassword)
+	assert.Equal(t, ErrWrongPassword, err)
+
+	err = ks.ChangePassword("main", testPassword, testNewPassword)
+	assert.Nilf(t, err, "Keystore.ChangePassword returned error: %s", err)
+
+	reopened, err := Open(path, LightScryptN, LightScryptP)
+	assert.Nilf(t, err, "Open returned error: %s", err)
+
+	_, err = reopened.Unlock("main", testPassword)
+	assert.Equal(t, ErrWrongPassword, err)
+
+	key, err = reopened.Export("main", testNewPassword)
+	assert.Nilf(t, err, "Keystore.Export returned error: %s", err)
+	assert.Equal(t, testPrivateKey, strings.ToLower(key))
+}
+
+func TestOpen_UnsupportedVersion(t *testing.T) {
+	_, path, closeFn := newTestKeystore(t)
+	defer closeFn()
+
+	err := ioutil.WriteFile(path, []byte(`{"version": 2, "accounts": []}`), 0600)
+	assert.Nil(t, err)
+
+	_, err = Open(path, LightScryptN, LightScryptP)
+	assert.Equal(t, ErrUnsupportedVersion, err)
+}
@@ -22,7 +22,7 @@ func (s *LockService) GetHashLockInfosByAccount(ctx context.Context, account *Pu
 
 	dto := &hashLockInfoDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetHashLockInfosByAccount"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +43,7 @@ func (s *LockService) GetHashLockInfo(ctx context.Context, hash *Hash) (*HashLoc
 
 	dto := &hashLockInfoDTO{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetHashLockInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +64,7 @@ func (s *LockService) GetSecretLockInfosByAccount(ctx context.Context, account *
 
 	dto := &secretLockInfoDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetSecretLockInfosByAccount"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +85,7 @@ func (s *LockService) GetSecretLockInfo(ctx context.Context, compositeHash *Hash
 
 	dto := &secretLockInfoDTO{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetSecretLockInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +106,7 @@ func (s *LockService) GetSecretLockInfosBySecret(ctx context.Context, secret *Ha
 
 	dto := &secretLockInfoDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetSecretLockInfosBySecret"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +42,12 @@ type ConfirmedAddedMapper interface {
 	MapConfirmedAdded(m []byte) (Transaction, error)
 }
 
+type ConfirmedAddedMapperFn func(m []byte) (Transaction, error)
+
+func (p ConfirmedAddedMapperFn) MapConfirmedAdded(m []byte) (Transaction, error) {
+	return p(m)
+}
+
 type confirmedAddedMapperImpl struct {
 	mapTransactionFunc mapTransactionFunc
 	generationHash     *Hash
@@ -2,10 +2,10 @@ package sdk
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
-	"github.com/pkg/errors"
 	"github.com/proximax-storage/go-xpx-utils/net"
 )
 
@@ -24,9 +24,9 @@ func (ref *MetadataService) GetAddressMetadatasInfo(ctx context.Context, address
 
 	dtos := addressMetadataInfoDTOs(make([]*addressMetadataInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, metadatasInfoRoute, addressesDto, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetAddressMetadatasInfo"}, http.MethodPost, metadatasInfoRoute, addressesDto, &dtos)
 	if err != nil {
-		return nil, errors.Wrapf(err, "within POST request %s", metadatasInfoRoute)
+		return nil, fmt.Errorf("within POST request %s: %w", metadatasInfoRoute, err)
 	}
 
 	if err = handleResponseStatusCode(resp, map[int]error{409: ErrArgumentNotValid}); err != nil {
@@ -58,9 +58,9 @@ func (ref *MetadataService) GetMosaicMetadatasInfo(ctx context.Context, mosaicId
 
 	dtos := mosaicMetadataInfoDTOs(make([]*mosaicMetadataInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, metadatasInfoRoute, mosaicsDto, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetMosaicMetadatasInfo"}, http.MethodPost, metadatasInfoRoute, mosaicsDto, &dtos)
 	if err != nil {
-		return nil, errors.Wrapf(err, "within POST request %s", metadatasInfoRoute)
+		return nil, fmt.Errorf("within POST request %s: %w", metadatasInfoRoute, err)
 	}
 
 	if err = handleResponseStatusCode(resp, map[int]error{409: ErrArgumentNotValid}); err != nil {
@@ -92,9 +92,9 @@ func (ref *MetadataService) GetNamespaceMetadatasInfo(ctx context.Context, names
 
 	dtos := namespaceMetadataInfoDTOs(make([]*namespaceMetadataInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, metadatasInfoRoute, namespacesDto, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNamespaceMetadatasInfo"}, http.MethodPost, metadatasInfoRoute, namespacesDto, &dtos)
 	if err != nil {
-		return nil, errors.Wrapf(err, "within POST request %s", metadatasInfoRoute)
+		return nil, fmt.Errorf("within POST request %s: %w", metadatasInfoRoute, err)
 	}
 
 	if err = handleResponseStatusCode(resp, map[int]error{409: ErrArgumentNotValid}); err != nil {
@@ -118,7 +118,7 @@ func (ref *MetadataService) GetMetadataByAddress(ctx context.Context, address st
 
 	dto := addressMetadataInfoDTO{}
 
-	err := ref.getMetadata(ctx, url, &dto)
+	err := ref.getMetadata(ctx, "GetMetadataByAddress", url, &dto)
 
 	if err != nil {
 		return nil, err
@@ -151,7 +151,7 @@ func (ref *MetadataService) GetMetadataByMosaicId(ctx context.Context, mosaicId
 
 	dto := mosaicMetadataInfoDTO{}
 
-	err := ref.getMetadata(ctx, url, &dto)
+	err := ref.getMetadata(ctx, "GetMetadataByMosaicId", url, &dto)
 
 	if err != nil {
 		return nil, err
@@ -179,7 +179,7 @@ func (ref *MetadataService) GetMetadataByNamespaceId(ctx context.Context, namesp
 
 	dto := namespaceMetadataInfoDTO{}
 
-	err := ref.getMetadata(ctx, url, &dto)
+	err := ref.getMetadata(ctx, "GetMetadataByNamespaceId", url, &dto)
 
 	if err != nil {
 		return nil, err
@@ -198,16 +198,11 @@ func (ref *MetadataService) GetMetadataByNamespaceId(ctx context.Context, namesp
 	return info, nil
 }
 
-func (ref *MetadataService) getMetadata(ctx context.Context, url *net.Url, dto interface{}) error {
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+func (ref *MetadataService) getMetadata(ctx context.Context, operation string, url *net.Url, dto interface{}) error {
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, operation}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
-		switch e := err.(type) {
-		case *HttpError:
-			if e.StatusCode != 404 {
-				return errors.Wrapf(e, "within GET request %s", url.Encode())
-			}
-		default:
-			return errors.Wrapf(err, "within GET request %s", url.Encode())
+		if !errors.Is(err, ErrResourceNotFound) {
+			return fmt.Errorf("within GET request %s: %w", url.Encode(), err)
 		}
 	} else if err = handleResponseStatusCode(resp, map[int]error{409: ErrArgumentNotValid}); err != nil {
 		return err
@@ -19,11 +19,15 @@ func (ref *MosaicService) GetMosaicInfo(ctx context.Context, mosaicId *MosaicId)
 		return nil, ErrNilMosaicId
 	}
 
+	if v, ok := ref.client.Cache.get(mosaicInfoCacheOperation, mosaicId.toHexString()); ok {
+		return v.(*MosaicInfo), nil
+	}
+
 	url := net.NewUrl(fmt.Sprintf(mosaicRoute, mosaicId.toHexString()))
 
 	dto := &mosaicInfoDTO{}
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetMosaicInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +41,8 @@ func (ref *MosaicService) GetMosaicInfo(ctx context.Context, mosaicId *MosaicId)
 		return nil, err
 	}
 
+	ref.client.Cache.set(mosaicInfoCacheOperation, mosaicId.toHexString(), mscInfo)
+
 	return mscInfo, nil
 }
 
@@ -45,23 +51,60 @@ func (ref *MosaicService) GetMosaicInfos(ctx context.Context, mscIds []*MosaicId
 		return nil, ErrEmptyMosaicIds
 	}
 
-	dtos := mosaicInfoDTOs(make([]*mosaicInfoDTO, 0))
+	cached := make([]*MosaicInfo, 0)
+	missed := make([]*MosaicId, 0, len(mscIds))
+
+	for _, mscId := range mscIds {
+		if mscId == nil {
+			return nil, ErrNilMosaicId
+		}
+
+		if v, ok := ref.client.Cache.get(mosaicInfoCacheOperation, mscId.toHexString()); ok {
+			cached = append(cached, v.(*MosaicInfo))
+		} else {
+			missed = append(missed, mscId)
+		}
+	}
+
+	if len(missed) == 0 {
+		return cached, nil
+	}
+
+	// node accepts limited number of ids in one request
+	err := ref.client.forEachBatch(len(missed), func(from, to int) error {
+		mscInfos, err := ref.getMosaicInfos(ctx, missed[from:to])
+		if err != nil {
+			return err
+		}
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, mosaicsRoute, &mosaicIds{mscIds}, &dtos)
+		for _, mscInfo := range mscInfos {
+			ref.client.Cache.set(mosaicInfoCacheOperation, mscInfo.MosaicId.toHexString(), mscInfo)
+		}
+
+		cached = append(cached, mscInfos...)
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err = handleResponseStatusCode(resp, map[int]error{400: ErrInvalidRequest, 409: ErrArgumentNotValid}); err != nil {
+	return cached, nil
+}
+
+func (ref *MosaicService) getMosaicInfos(ctx context.Context, mscIds []*MosaicId) ([]*MosaicInfo, error) {
+	dtos := mosaicInfoDTOs(make([]*mosaicInfoDTO, 0))
+
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetMosaicInfos"}, http.MethodPost, mosaicsRoute, &mosaicIds{mscIds}, &dtos)
+	if err != nil {
 		return nil, err
 	}
 
-	mscInfos, err := dtos.toStruct(ref.client.config.NetworkType)
-	if err != nil {
+	if err = handleResponseStatusCode(resp, map[int]error{400: ErrInvalidRequest, 409: ErrArgumentNotValid}); err != nil {
 		return nil, err
 	}
 
-	return mscInfos, nil
+	return dtos.toStruct(ref.client.config.NetworkType)
 }
 
 // GetMosaicsNames Get readable names for a set of mosaics
@@ -73,7 +116,7 @@ func (ref *MosaicService) GetMosaicsNames(ctx context.Context, mscIds ...*Mosaic
 
 	dtos := mosaicNameDTOs{}
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, mosaicNamesRoute, &mosaicIds{mscIds}, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetMosaicsNames"}, http.MethodPost, mosaicNamesRoute, &mosaicIds{mscIds}, &dtos)
 	if err != nil {
 		return nil, err
 	}
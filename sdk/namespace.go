@@ -21,11 +21,22 @@ func (ref *NamespaceService) GetNamespaceInfo(ctx context.Context, nsId *Namespa
 		return nil, ErrNilNamespaceId
 	}
 
+	if v, ok := ref.client.Cache.get(namespaceInfoCacheOperation, nsId.toHexString()); ok {
+		// cached info is shared, so hierarchy is built on the copy
+		nsInfo := *v.(*NamespaceInfo)
+
+		if err := ref.buildNamespaceHierarchy(ctx, &nsInfo); err != nil {
+			return nil, err
+		}
+
+		return &nsInfo, nil
+	}
+
 	nsInfoDTO := &namespaceInfoDTO{}
 
 	url := net.NewUrl(fmt.Sprintf(namespaceRoute, nsId.toHexString()))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, nsInfoDTO)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNamespaceInfo"}, http.MethodGet, url.Encode(), nil, nsInfoDTO)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +50,10 @@ func (ref *NamespaceService) GetNamespaceInfo(ctx context.Context, nsId *Namespa
 		return nil, err
 	}
 
+	// every level of hierarchy is cached separately, so parents can be invalidated
+	cached := *nsInfo
+	ref.client.Cache.set(namespaceInfoCacheOperation, nsId.toHexString(), &cached)
+
 	if err = ref.buildNamespaceHierarchy(ctx, nsInfo); err != nil {
 		return nil, err
 	}
@@ -66,7 +81,7 @@ func (ref *NamespaceService) GetNamespaceInfosFromAccount(ctx context.Context, a
 
 	dtos := namespaceInfoDTOs(make([]*namespaceInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNamespaceInfosFromAccount"}, http.MethodGet, url.Encode(), nil, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +122,7 @@ func (ref *NamespaceService) GetNamespaceInfosFromAccounts(ctx context.Context,
 
 	dtos := namespaceInfoDTOs(make([]*namespaceInfoDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, url.Encode(), &addresses{addrs}, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNamespaceInfosFromAccounts"}, http.MethodPost, url.Encode(), &addresses{addrs}, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -133,9 +148,52 @@ func (ref *NamespaceService) GetNamespaceNames(ctx context.Context, nsIds []*Nam
 		return nil, ErrEmptyNamespaceIds
 	}
 
+	cached := make([]*NamespaceName, 0)
+	missed := make([]*NamespaceId, 0, len(nsIds))
+
+	for _, nsId := range nsIds {
+		if nsId == nil {
+			return nil, ErrNilNamespaceId
+		}
+
+		if v, ok := ref.client.Cache.get(namespaceNameCacheOperation, nsId.toHexString()); ok {
+			cached = append(cached, v.(*NamespaceName))
+		} else {
+			missed = append(missed, nsId)
+		}
+	}
+
+	if len(missed) == 0 {
+		return cached, nil
+	}
+
+	// node accepts limited number of ids in one request
+	err := ref.client.forEachBatch(len(missed), func(from, to int) error {
+		names, err := ref.getNamespaceNames(ctx, missed[from:to])
+		if err != nil {
+			return err
+		}
+
+		// name of namespace is a part of its id, so names never change
+		for _, name := range names {
+			ref.client.Cache.set(namespaceNameCacheOperation, name.NamespaceId.toHexString(), name)
+		}
+
+		cached = append(cached, names...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+func (ref *NamespaceService) getNamespaceNames(ctx context.Context, nsIds []*NamespaceId) ([]*NamespaceName, error) {
 	dtos := namespaceNameDTOs(make([]*namespaceNameDTO, 0))
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodPost, namespaceNamesRoute, &namespaceIds{nsIds}, &dtos)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNamespaceNames"}, http.MethodPost, namespaceNamesRoute, &namespaceIds{nsIds}, &dtos)
 	if err != nil {
 		return nil, err
 	}
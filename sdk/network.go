@@ -19,7 +19,7 @@ type NetworkService struct {
 func (ref *NetworkService) GetNetworkType(ctx context.Context) (NetworkType, error) {
 	netDTO := &networkDTO{}
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, networkRoute, nil, netDTO)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNetworkType"}, http.MethodGet, networkRoute, nil, netDTO)
 
 	if err != nil {
 		return NotSupportedNet, err
@@ -39,11 +39,15 @@ func (ref *NetworkService) GetNetworkType(ctx context.Context) (NetworkType, err
 }
 
 func (ref *NetworkService) GetNetworkConfigAtHeight(ctx context.Context, height Height) (*BlockchainConfig, error) {
+	if v, ok := ref.client.Cache.get(networkConfigCacheOperation, height.String()); ok {
+		return v.(*BlockchainConfig), nil
+	}
+
 	blockchainDTO := &blockchainConfigDTO{}
 
 	url := fmt.Sprintf(configRoute, height)
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url, nil, blockchainDTO)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNetworkConfigAtHeight"}, http.MethodGet, url, nil, blockchainDTO)
 
 	if err != nil {
 		return nil, err
@@ -53,7 +57,17 @@ func (ref *NetworkService) GetNetworkConfigAtHeight(ctx context.Context, height
 		return nil, err
 	}
 
-	return blockchainDTO.toStruct()
+	config, err := blockchainDTO.toStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	// config at height is fixed when block at height is confirmed, later changes are applied to next heights
+	if ref.client.Cache.isConfirmed(ctx, height) {
+		ref.client.Cache.set(networkConfigCacheOperation, height.String(), config)
+	}
+
+	return config, nil
 }
 
 func (ref *NetworkService) GetNetworkConfig(ctx context.Context) (*BlockchainConfig, error) {
@@ -70,7 +84,7 @@ func (ref *NetworkService) GetNetworkVersionAtHeight(ctx context.Context, height
 
 	url := fmt.Sprintf(upgradeRoute, height)
 
-	resp, err := ref.client.doNewRequest(ctx, http.MethodGet, url, nil, netDTO)
+	resp, err := ref.client.doNewRequest(ctx, RequestOperation{ref.name, "GetNetworkVersionAtHeight"}, http.MethodGet, url, nil, netDTO)
 
 	if err != nil {
 		return nil, err
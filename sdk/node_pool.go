@@ -0,0 +1,428 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultNodeProbeInterval = time.Second * 30
+	DefaultNodeMaxHeightLag  = Height(5)
+	DefaultNodeMaxErrorRate  = 0.5
+	// weight of the latest measurement in moving averages of latency and error rate
+	nodeHealthSmoothing = 0.2
+)
+
+// NodeHealth is a snapshot of node state collected by NodePool
+type NodeHealth struct {
+	URL *url.URL
+	// height and score of blockchain reported by node at last probe
+	Height Height
+	Score  *ChainScore
+	// moving average of response time
+	Latency time.Duration
+	// moving average of failed requests share, from 0 to 1
+	ErrorRate float64
+	Requests  uint64
+	Errors    uint64
+	LastError error
+	LastProbe time.Time
+	// false if node lags behind the highest node more than allowed
+	InSync bool
+	// true if node is in sync and error rate is acceptable
+	Healthy bool
+}
+
+func (h *NodeHealth) String() string {
+	return fmt.Sprintf(
+		`[URL: %s, Height: %s, Latency: %s, ErrorRate: %.2f, InSync: %t, Healthy: %t]`,
+		h.URL,
+		h.Height,
+		h.Latency,
+		h.ErrorRate,
+		h.InSync,
+		h.Healthy,
+	)
+}
+
+// NodeSelector chooses node which receives the next request from healthy nodes
+type NodeSelector interface {
+	Select(nodes []*NodeHealth) *NodeHealth
+}
+
+// NodeSelectorFunc is an adapter to use ordinary functions as NodeSelector
+type NodeSelectorFunc func(nodes []*NodeHealth) *NodeHealth
+
+func (f NodeSelectorFunc) Select(nodes []*NodeHealth) *NodeHealth {
+	return f(nodes)
+}
+
+// LowestLatencySelector chooses the fastest node, nodes without measurements are tried first
+var LowestLatencySelector NodeSelector = NodeSelectorFunc(func(nodes []*NodeHealth) *NodeHealth {
+	var best *NodeHealth
+	for _, n := range nodes {
+		if best == nil || n.Latency < best.Latency {
+			best = n
+		}
+	}
+
+	return best
+})
+
+// HighestHeightSelector chooses the node with the highest blockchain, latency breaks ties
+var HighestHeightSelector NodeSelector = NodeSelectorFunc(func(nodes []*NodeHealth) *NodeHealth {
+	var best *NodeHealth
+	for _, n := range nodes {
+		if best == nil || n.Height > best.Height || (n.Height == best.Height && n.Latency < best.Latency) {
+			best = n
+		}
+	}
+
+	return best
+})
+
+// returns NodeSelector which spreads requests between nodes in turn
+func NewRoundRobinSelector() NodeSelector {
+	var m sync.Mutex
+	next := 0
+
+	return NodeSelectorFunc(func(nodes []*NodeHealth) *NodeHealth {
+		if len(nodes) == 0 {
+			return nil
+		}
+
+		m.Lock()
+		defer m.Unlock()
+
+		n := nodes[next%len(nodes)]
+		next++
+
+		return n
+	})
+}
+
+type NodePoolConfig struct {
+	// interval of background probing started by NodePool.Start
+	ProbeInterval time.Duration
+	// node is out of sync if its height is lower than the highest one more than by MaxHeightLag
+	MaxHeightLag Height
+	// node with greater error rate is unhealthy
+	MaxErrorRate float64
+	Selector     NodeSelector
+}
+
+func DefaultNodePoolConfig() *NodePoolConfig {
+	return &NodePoolConfig{
+		ProbeInterval: DefaultNodeProbeInterval,
+		MaxHeightLag:  DefaultNodeMaxHeightLag,
+		MaxErrorRate:  DefaultNodeMaxErrorRate,
+		Selector:      LowestLatencySelector,
+	}
+}
+
+// NodePool tracks health of nodes from Config.BaseURLs and routes requests of Client to the healthiest node in sync.
+// Health is collected from results of requests and from probing of height and score of every node
+type NodePool struct {
+	sync.RWMutex
+	client  *Client
+	config  *NodePoolConfig
+	nodes   []*NodeHealth
+	started bool
+}
+
+func newNodePool(client *Client, urls []*url.URL, config *NodePoolConfig) *NodePool {
+	p := &NodePool{
+		client: client,
+		nodes:  make([]*NodeHealth, len(urls)),
+	}
+
+	for i, u := range urls {
+		p.nodes[i] = &NodeHealth{URL: u, InSync: true, Healthy: true}
+	}
+
+	p.SetConfig(config)
+
+	return p
+}
+
+// replaces config of pool, default config is used if config is nil
+func (p *NodePool) SetConfig(config *NodePoolConfig) {
+	defaults := DefaultNodePoolConfig()
+
+	if config == nil {
+		config = defaults
+	}
+
+	if config.ProbeInterval <= 0 {
+		config.ProbeInterval = defaults.ProbeInterval
+	}
+
+	if config.MaxErrorRate <= 0 {
+		config.MaxErrorRate = defaults.MaxErrorRate
+	}
+
+	if config.Selector == nil {
+		config.Selector = defaults.Selector
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.config = config
+	p.updateHealth()
+}
+
+// starts background probing of nodes until context is done
+func (p *NodePool) Start(ctx context.Context) {
+	p.Lock()
+	if p.started {
+		p.Unlock()
+		return
+	}
+	p.started = true
+	interval := p.config.ProbeInterval
+	p.Unlock()
+
+	go func() {
+		defer func() {
+			p.Lock()
+			p.started = false
+			p.Unlock()
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			p.Probe(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// requests height and score of every node and updates their health
+func (p *NodePool) Probe(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, u := range p.urls() {
+		wg.Add(1)
+
+		go func(u *url.URL) {
+			defer wg.Done()
+			p.probe(ctx, u)
+		}(u)
+	}
+
+	wg.Wait()
+}
+
+func (p *NodePool) probe(ctx context.Context, u *url.URL) {
+	start := time.Now()
+
+	bh := &struct {
+		Height uint64DTO `json:"height"`
+	}{}
+
+	_, err := p.client.doRequestToNode(ctx, RequestOperation{NodePoolServiceName, "Probe"}, u, http.MethodGet, blockHeightRoute, nil, bh)
+	if err != nil {
+		p.report(u, 0, err, true)
+		return
+	}
+
+	cs := &chainScoreDTO{}
+	if _, err = p.client.doRequestToNode(ctx, RequestOperation{NodePoolServiceName, "Probe"}, u, http.MethodGet, blockScoreRoute, nil, cs); err != nil {
+		p.report(u, 0, err, true)
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	n := p.node(u)
+	if n == nil {
+		return
+	}
+
+	n.Height = bh.Height.toStruct()
+	n.Score = cs.toStruct()
+	n.LastProbe = time.Now()
+	n.LastError = nil
+	p.record(n, time.Since(start)/2, false)
+	p.updateHealth()
+}
+
+// returns snapshot of health of every node
+func (p *NodePool) Nodes() []*NodeHealth {
+	p.RLock()
+	defer p.RUnlock()
+
+	nodes := make([]*NodeHealth, len(p.nodes))
+	for i, n := range p.nodes {
+		copied := *n
+		nodes[i] = &copied
+	}
+
+	return nodes
+}
+
+// returns node chosen by selector from healthy nodes or ErrNoHealthyNodes
+func (p *NodePool) Select() (*url.URL, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	selected := p.config.Selector.Select(p.healthy())
+	if selected == nil {
+		return nil, ErrNoHealthyNodes
+	}
+
+	return selected.URL, nil
+}
+
+// returns nodes in order they should be tried by request: selected node, other healthy nodes and unhealthy ones
+func (p *NodePool) candidates() []*url.URL {
+	p.RLock()
+	defer p.RUnlock()
+
+	urls := make([]*url.URL, 0, len(p.nodes))
+
+	healthy := p.healthy()
+	if selected := p.config.Selector.Select(healthy); selected != nil {
+		urls = append(urls, selected.URL)
+	}
+
+	for _, n := range healthy {
+		if !containsUrl(urls, n.URL) {
+			urls = append(urls, n.URL)
+		}
+	}
+
+	for _, n := range p.nodes {
+		if !containsUrl(urls, n.URL) {
+			urls = append(urls, n.URL)
+		}
+	}
+
+	return urls
+}
+
+func (p *NodePool) urls() []*url.URL {
+	p.RLock()
+	defer p.RUnlock()
+
+	urls := make([]*url.URL, len(p.nodes))
+	for i, n := range p.nodes {
+		urls[i] = n.URL
+	}
+
+	return urls
+}
+
+// records result of request to node, only network errors and server errors count as node failures
+func (p *NodePool) report(u *url.URL, latency time.Duration, err error, probe bool) {
+	failed := false
+	if err != nil {
+		_, isNetErr := err.(*url.Error)
+		failed = isNetErr || errors.Is(err, ErrServerError)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	n := p.node(u)
+	if n == nil {
+		return
+	}
+
+	if failed {
+		n.LastError = err
+		if probe {
+			n.LastProbe = time.Now()
+		}
+	}
+
+	p.record(n, latency, failed)
+	p.updateHealth()
+}
+
+func (p *NodePool) record(n *NodeHealth, latency time.Duration, failed bool) {
+	n.Requests++
+
+	failure := 0.0
+	if failed {
+		n.Errors++
+		failure = 1
+	}
+
+	n.ErrorRate += (failure - n.ErrorRate) * nodeHealthSmoothing
+
+	if failed || latency <= 0 {
+		return
+	}
+
+	if n.Latency == 0 {
+		n.Latency = latency
+	} else {
+		n.Latency += time.Duration(float64(latency-n.Latency) * nodeHealthSmoothing)
+	}
+}
+
+// recalculates InSync and Healthy of every node, should be called under lock
+func (p *NodePool) updateHealth() {
+	var maxHeight Height
+	for _, n := range p.nodes {
+		if n.Height > maxHeight {
+			maxHeight = n.Height
+		}
+	}
+
+	for _, n := range p.nodes {
+		// height of node is unknown until first successful probe
+		n.InSync = n.Height == 0 || n.Height+p.config.MaxHeightLag >= maxHeight
+		n.Healthy = n.InSync && n.ErrorRate <= p.config.MaxErrorRate
+	}
+}
+
+func (p *NodePool) healthy() []*NodeHealth {
+	nodes := make([]*NodeHealth, 0, len(p.nodes))
+	for _, n := range p.nodes {
+		if n.Healthy {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+func (p *NodePool) node(u *url.URL) *NodeHealth {
+	for _, n := range p.nodes {
+		if n.URL == u {
+			return n
+		}
+	}
+
+	return nil
+}
+
+func containsUrl(urls []*url.URL, u *url.URL) bool {
+	for _, existing := range urls {
+		if existing == u {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNodeMock(height uint64) *sdkMock {
+	m := newSdkMockWithRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: fmt.Sprintf(`{"height": [%d, 0]}`, height),
+	})
+
+	m.AddRouter(&mock.Router{
+		Path:     blockScoreRoute,
+		RespBody: `{"scoreHigh": [0, 0], "scoreLow": [10, 0]}`,
+	})
+
+	return m
+}
+
+func newNodePoolClient(t *testing.T, nodes ...*sdkMock) *Client {
+	urls := make([]string, len(nodes))
+	for i, node := range nodes {
+		urls[i] = node.GetServerURL()
+	}
+
+	conf, err := NewConfigWithReputation(urls, PublicTest, &defaultRepConfig, DefaultWebsocketReconnectionTimeout, nil, DefaultFeeCalculationStrategy)
+	assert.Nilf(t, err, "NewConfigWithReputation returned error: %s", err)
+
+	return NewClient(nil, conf)
+}
+
+func TestNodePool_Probe(t *testing.T) {
+	lagging := newNodeMock(10)
+	defer lagging.Close()
+
+	synced := newNodeMock(100)
+	defer synced.Close()
+
+	client := newNodePoolClient(t, lagging, synced)
+	client.Nodes.Probe(ctx)
+
+	nodes := client.Nodes.Nodes()
+	assert.Equal(t, Height(10), nodes[0].Height)
+	assert.False(t, nodes[0].InSync)
+	assert.False(t, nodes[0].Healthy)
+	assert.Equal(t, Height(100), nodes[1].Height)
+	assert.True(t, nodes[1].Healthy)
+	assert.NotNil(t, nodes[1].Score)
+
+	u, err := client.Nodes.Select()
+	assert.Nilf(t, err, "NodePool.Select returned error: %s", err)
+	assert.Equal(t, synced.GetServerURL(), u.String())
+
+	height, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	assert.Equal(t, Height(100), height)
+	assert.Equal(t, synced.GetServerURL(), client.config.GetUsedBaseUrl().String())
+}
+
+func TestNodePool_Failover(t *testing.T) {
+	unavailable := newNodeMock(100)
+	unavailable.Close()
+
+	available := newNodeMock(100)
+	defer available.Close()
+
+	client := newNodePoolClient(t, unavailable, available)
+	client.Nodes.SetConfig(&NodePoolConfig{Selector: NodeSelectorFunc(func(nodes []*NodeHealth) *NodeHealth {
+		return nodes[0]
+	})})
+
+	for i := 0; i < 5; i++ {
+		height, err := client.Blockchain.GetBlockchainHeight(ctx)
+		assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+		assert.Equal(t, Height(100), height)
+	}
+
+	nodes := client.Nodes.Nodes()
+	assert.False(t, nodes[0].Healthy)
+	assert.NotNil(t, nodes[0].LastError)
+	assert.True(t, nodes[0].Errors > 0)
+	assert.Equal(t, uint64(0), nodes[1].Errors)
+	assert.Equal(t, uint64(5), nodes[1].Requests)
+}
+
+func TestNodePool_RoundRobinSelector(t *testing.T) {
+	first := newNodeMock(100)
+	defer first.Close()
+
+	second := newNodeMock(100)
+	defer second.Close()
+
+	client := newNodePoolClient(t, first, second)
+	client.Nodes.SetConfig(&NodePoolConfig{Selector: NewRoundRobinSelector()})
+
+	for i := 0; i < 4; i++ {
+		_, err := client.Blockchain.GetBlockchainHeight(ctx)
+		assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	}
+
+	for _, node := range client.Nodes.Nodes() {
+		assert.Equal(t, uint64(2), node.Requests)
+	}
+}
+
+func TestNodePool_Start(t *testing.T) {
+	node := newNodeMock(100)
+	defer node.Close()
+
+	client := newNodePoolClient(t, node)
+	client.Nodes.SetConfig(&NodePoolConfig{ProbeInterval: time.Millisecond * 10})
+
+	probeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client.Nodes.Start(probeCtx)
+
+	// probe runs in background, so height is polled until deadline
+	deadline := time.Now().Add(time.Second)
+	for client.Nodes.Nodes()[0].Height != Height(100) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	assert.Equal(t, Height(100), client.Nodes.Nodes()[0].Height)
+}
@@ -0,0 +1,491 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/proximax-storage/go-xpx-crypto"
+)
+
+// version of offline transaction files which are written by sdk
+const OfflineFileVersion = 1
+
+// kinds of offline transaction files
+const (
+	unsignedTransactionKind = "unsignedTransaction"
+	signedTransactionKind   = "signedTransaction"
+	cosignaturesKind        = "cosignatures"
+)
+
+type offlineFileDto struct {
+	Version         int                      `json:"version"`
+	Kind            string                   `json:"kind"`
+	NetworkType     NetworkType              `json:"networkType"`
+	GenerationHash  string                   `json:"generationHash"`
+	TransactionType EntityType               `json:"transactionType,omitempty"`
+	Payload         string                   `json:"payload,omitempty"`
+	Hash            string                   `json:"hash,omitempty"`
+	Cosignatures    []*offlineCosignatureDto `json:"cosignatures,omitempty"`
+}
+
+type offlineCosignatureDto struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+}
+
+// checks version and kind of file and returns its generation hash
+func (dto *offlineFileDto) header(kind string) (*Hash, error) {
+	if dto.Version < 1 || dto.Version > OfflineFileVersion {
+		return nil, ErrUnsupportedFileVersion
+	}
+
+	if dto.Kind != kind {
+		return nil, ErrUnexpectedFileKind
+	}
+
+	return StringToHash(dto.GenerationHash)
+}
+
+// returns Transaction parsed from payload of file and checks that it matches network type and type of file
+func (dto *offlineFileDto) transaction() (Transaction, error) {
+	payload, err := hex.DecodeString(dto.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := ParseTransactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	atx := tx.GetAbstractTransaction()
+	if atx.NetworkType != dto.NetworkType {
+		return nil, ErrFileNetworkMismatch
+	}
+
+	if atx.Type != dto.TransactionType {
+		return nil, fmt.Errorf("entity type of payload %s doesn't match %s", atx.Type, dto.TransactionType)
+	}
+
+	return tx, nil
+}
+
+// checks that hash of SignedTransaction is a hash of its payload and that payload is signed by its signer.
+// Cosignatures appended to aggregate payload are not covered by hash and signature
+func verifySignedTransaction(stx *SignedTransaction, generationHash *Hash) error {
+	if stx == nil || stx.Hash == nil {
+		return ErrSignedTransactionMismatch
+	}
+
+	b, err := hex.DecodeString(stx.Payload)
+	if err != nil {
+		return err
+	}
+
+	if len(b) < TransactionHeaderSize {
+		return ErrPayloadTooShort
+	}
+
+	if stx.EntityType == AggregateBonded || stx.EntityType == AggregateCompleted {
+		if len(b) < TransactionHeaderSize+SizeSize {
+			return ErrPayloadTooShort
+		}
+
+		size := uint64(TransactionHeaderSize+SizeSize) + uint64(binary.LittleEndian.Uint32(b[TransactionHeaderSize:]))
+		if size > uint64(len(b)) {
+			return ErrPayloadSizeMismatch
+		}
+
+		b = b[:size]
+	}
+
+	hash, err := createTransactionHash(b, generationHash)
+	if err != nil {
+		return err
+	}
+
+	if !hash.Equal(stx.Hash) {
+		return ErrSignedTransactionMismatch
+	}
+
+	data := b[SizeSize+SignatureSize+SignerSize:]
+	if generationHash != nil {
+		data = append(generationHash[:], data...)
+	}
+
+	signer := hex.EncodeToString(b[SizeSize+SignatureSize : SizeSize+SignatureSize+SignerSize])
+	signature, err := bytesToSignature(b[SizeSize : SizeSize+SignatureSize])
+	if err != nil {
+		return err
+	}
+
+	if !verifySignature(signer, data, signature) {
+		return ErrSignedTransactionMismatch
+	}
+
+	return nil
+}
+
+// returns true if signature of data is made by key pair of passed hex public key
+func verifySignature(publicKey string, data []byte, signature *Signature) bool {
+	pk, err := crypto.NewPublicKeyfromHex(publicKey)
+	if err != nil {
+		return false
+	}
+
+	kp, err := crypto.NewKeyPair(nil, pk, nil)
+	if err != nil {
+		return false
+	}
+
+	s, err := crypto.NewSignatureFromBytes(signature[:])
+	if err != nil {
+		return false
+	}
+
+	return crypto.NewSignerFromKeyPair(kp, nil).Verify(data, s)
+}
+
+// UnsignedTransactionFile is a portable representation of transaction which is built on online machine
+// and is signed on offline one. It is encoded to versioned JSON by json.Marshal
+type UnsignedTransactionFile struct {
+	GenerationHash *Hash
+	Transaction    Transaction
+}
+
+// returns UnsignedTransactionFile of passed transaction for network with passed generation hash
+func NewUnsignedTransactionFile(tx Transaction, generationHash *Hash) (*UnsignedTransactionFile, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+
+	if generationHash == nil {
+		return nil, errors.New("generationHash must not be nil")
+	}
+
+	if tx.GetAbstractTransaction().Deadline == nil {
+		return nil, errors.New("deadline of transaction must not be nil")
+	}
+
+	return &UnsignedTransactionFile{generationHash, tx}, nil
+}
+
+func (f *UnsignedTransactionFile) MarshalJSON() ([]byte, error) {
+	b, err := f.Transaction.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	atx := f.Transaction.GetAbstractTransaction()
+
+	return json.Marshal(&offlineFileDto{
+		Version:         OfflineFileVersion,
+		Kind:            unsignedTransactionKind,
+		NetworkType:     atx.NetworkType,
+		GenerationHash:  f.GenerationHash.String(),
+		TransactionType: atx.Type,
+		Payload:         strings.ToUpper(hex.EncodeToString(b)),
+	})
+}
+
+func (f *UnsignedTransactionFile) UnmarshalJSON(data []byte) error {
+	dto := offlineFileDto{}
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	generationHash, err := dto.header(unsignedTransactionKind)
+	if err != nil {
+		return err
+	}
+
+	tx, err := dto.transaction()
+	if err != nil {
+		return err
+	}
+
+	f.GenerationHash, f.Transaction = generationHash, tx
+
+	return nil
+}
+
+// signs transaction of file with passed Signer for network of file
+func (f *UnsignedTransactionFile) Sign(signer Signer) (*SignedTransactionFile, error) {
+	stx, err := SignTransaction(signer, f.Transaction, f.GenerationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransactionFile{f.Transaction.GetAbstractTransaction().NetworkType, f.GenerationHash, stx}, nil
+}
+
+// signs aggregate transaction of file with passed Signer and every passed cosignatory Signer for network of file
+func (f *UnsignedTransactionFile) SignWithCosignatures(signer Signer, cosignatories []Signer) (*SignedTransactionFile, error) {
+	agtx, ok := f.Transaction.(*AggregateTransaction)
+	if !ok {
+		return nil, ErrNotAggregate
+	}
+
+	stx, err := SignTransactionWithCosignatures(signer, agtx, cosignatories, f.GenerationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransactionFile{agtx.NetworkType, f.GenerationHash, stx}, nil
+}
+
+// SignedTransactionFile is a portable representation of signed transaction which is brought back from offline machine.
+// Signed aggregate transactions are passed to cosignatories, which return CosignatureFile's
+type SignedTransactionFile struct {
+	NetworkType       NetworkType
+	GenerationHash    *Hash
+	SignedTransaction *SignedTransaction
+}
+
+// returns SignedTransactionFile of passed SignedTransaction for network with passed generation hash
+func NewSignedTransactionFile(stx *SignedTransaction, generationHash *Hash) (*SignedTransactionFile, error) {
+	if generationHash == nil {
+		return nil, errors.New("generationHash must not be nil")
+	}
+
+	tx, err := ParseSignedTransaction(stx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignedTransaction(stx, generationHash); err != nil {
+		return nil, err
+	}
+
+	return &SignedTransactionFile{tx.GetAbstractTransaction().NetworkType, generationHash, stx}, nil
+}
+
+func (f *SignedTransactionFile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&offlineFileDto{
+		Version:         OfflineFileVersion,
+		Kind:            signedTransactionKind,
+		NetworkType:     f.NetworkType,
+		GenerationHash:  f.GenerationHash.String(),
+		TransactionType: f.SignedTransaction.EntityType,
+		Payload:         strings.ToUpper(f.SignedTransaction.Payload),
+		Hash:            f.SignedTransaction.Hash.String(),
+	})
+}
+
+func (f *SignedTransactionFile) UnmarshalJSON(data []byte) error {
+	dto := offlineFileDto{}
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	generationHash, err := dto.header(signedTransactionKind)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dto.transaction(); err != nil {
+		return err
+	}
+
+	hash, err := StringToHash(dto.Hash)
+	if err != nil {
+		return err
+	}
+
+	stx := &SignedTransaction{dto.TransactionType, dto.Payload, hash}
+	if err := verifySignedTransaction(stx, generationHash); err != nil {
+		return err
+	}
+
+	f.NetworkType, f.GenerationHash, f.SignedTransaction = dto.NetworkType, generationHash, stx
+
+	return nil
+}
+
+// returns Transaction parsed from signed payload, it allows to review transaction before cosigning or announcing it
+func (f *SignedTransactionFile) Transaction() (Transaction, error) {
+	return ParseSignedTransaction(f.SignedTransaction)
+}
+
+// returns CosignatureFile with cosignature of signed aggregate transaction by passed Signer
+func (f *SignedTransactionFile) Cosign(signer Signer) (*CosignatureFile, error) {
+	if f.SignedTransaction.EntityType != AggregateBonded && f.SignedTransaction.EntityType != AggregateCompleted {
+		return nil, ErrNotAggregate
+	}
+
+	// cosigner reviews payload, so hash which is cosigned should be the hash of that payload
+	if err := verifySignedTransaction(f.SignedTransaction, f.GenerationHash); err != nil {
+		return nil, err
+	}
+
+	cosignature, err := SignCosignatureTransaction(signer, NewCosignatureTransactionFromHash(f.SignedTransaction.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CosignatureFile{
+		NetworkType:    f.NetworkType,
+		GenerationHash: f.GenerationHash,
+		ParentHash:     f.SignedTransaction.Hash,
+		Cosignatures:   []*CosignatureSignedTransaction{cosignature},
+	}, nil
+}
+
+// returns SignedTransactionFile with cosignatures of passed files appended to signed aggregate transaction.
+// Cosignatures of signers which already cosigned transaction are skipped
+func (f *SignedTransactionFile) AddCosignatures(files ...*CosignatureFile) (*SignedTransactionFile, error) {
+	if err := verifySignedTransaction(f.SignedTransaction, f.GenerationHash); err != nil {
+		return nil, err
+	}
+
+	tx, err := f.Transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	agtx, ok := tx.(*AggregateTransaction)
+	if !ok {
+		return nil, ErrNotAggregate
+	}
+
+	merged, err := MergeCosignatureFiles(files...)
+	if err != nil {
+		return nil, err
+	}
+
+	if merged.NetworkType != f.NetworkType ||
+		!merged.GenerationHash.Equal(f.GenerationHash) ||
+		!merged.ParentHash.Equal(f.SignedTransaction.Hash) {
+		return nil, ErrCosignatureMismatch
+	}
+
+	signed := make(map[string]bool)
+	if agtx.Signer != nil {
+		signed[strings.ToUpper(agtx.Signer.PublicKey)] = true
+	}
+	for _, c := range agtx.Cosignatures {
+		signed[strings.ToUpper(c.Signer.PublicKey)] = true
+	}
+
+	cosignatures := make([]*CosignatureSignedTransaction, 0, len(merged.Cosignatures))
+	for _, c := range merged.Cosignatures {
+		if c.Signature == nil || !verifySignature(c.Signer, f.SignedTransaction.Hash[:], c.Signature) {
+			return nil, ErrInvalidCosignature
+		}
+
+		if !signed[strings.ToUpper(c.Signer)] {
+			cosignatures = append(cosignatures, c)
+		}
+	}
+
+	stx, err := appendCosignatures(f.SignedTransaction, cosignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransactionFile{f.NetworkType, f.GenerationHash, stx}, nil
+}
+
+// CosignatureFile is a portable set of cosignatures of one aggregate transaction, which are produced by cosignatories.
+// Files of different cosignatories are merged by MergeCosignatureFiles
+type CosignatureFile struct {
+	NetworkType    NetworkType
+	GenerationHash *Hash
+	// hash of cosigned aggregate transaction
+	ParentHash   *Hash
+	Cosignatures []*CosignatureSignedTransaction
+}
+
+func (f *CosignatureFile) MarshalJSON() ([]byte, error) {
+	cosignatures := make([]*offlineCosignatureDto, len(f.Cosignatures))
+	for i, c := range f.Cosignatures {
+		cosignatures[i] = &offlineCosignatureDto{strings.ToUpper(c.Signer), strings.ToUpper(c.Signature.String())}
+	}
+
+	return json.Marshal(&offlineFileDto{
+		Version:        OfflineFileVersion,
+		Kind:           cosignaturesKind,
+		NetworkType:    f.NetworkType,
+		GenerationHash: f.GenerationHash.String(),
+		Hash:           f.ParentHash.String(),
+		Cosignatures:   cosignatures,
+	})
+}
+
+func (f *CosignatureFile) UnmarshalJSON(data []byte) error {
+	dto := offlineFileDto{}
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	generationHash, err := dto.header(cosignaturesKind)
+	if err != nil {
+		return err
+	}
+
+	parentHash, err := StringToHash(dto.Hash)
+	if err != nil {
+		return err
+	}
+
+	cosignatures := make([]*CosignatureSignedTransaction, len(dto.Cosignatures))
+	for i, c := range dto.Cosignatures {
+		if _, err := NewAccountFromPublicKey(c.Signer, dto.NetworkType); err != nil {
+			return err
+		}
+
+		signature, err := StringToSignature(c.Signature)
+		if err != nil {
+			return err
+		}
+
+		cosignatures[i] = &CosignatureSignedTransaction{parentHash, signature, c.Signer}
+	}
+
+	f.NetworkType, f.GenerationHash, f.ParentHash, f.Cosignatures = dto.NetworkType, generationHash, parentHash, cosignatures
+
+	return nil
+}
+
+// returns CosignatureFile with cosignatures of all passed files, every signer is included once.
+// All files should cosign the same transaction of the same network
+func MergeCosignatureFiles(files ...*CosignatureFile) (*CosignatureFile, error) {
+	if len(files) == 0 {
+		return nil, errors.New("files must not be empty")
+	}
+
+	first := files[0]
+	merged := &CosignatureFile{
+		NetworkType:    first.NetworkType,
+		GenerationHash: first.GenerationHash,
+		ParentHash:     first.ParentHash,
+	}
+
+	signers := make(map[string]bool)
+	for _, f := range files {
+		if f.NetworkType != first.NetworkType ||
+			!f.GenerationHash.Equal(first.GenerationHash) ||
+			!f.ParentHash.Equal(first.ParentHash) {
+			return nil, ErrCosignatureMismatch
+		}
+
+		for _, c := range f.Cosignatures {
+			signer := strings.ToUpper(c.Signer)
+			if signers[signer] {
+				continue
+			}
+
+			signers[signer] = true
+			merged.Cosignatures = append(merged.Cosignatures, c)
+		}
+	}
+
+	return merged, nil
+}
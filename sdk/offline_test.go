@@ -0,0 +1,247 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var offlineGenerationHash = stringToHashPanic("86258172F90639811F2ABD055747D1E11B55A64B68AED2CEA9A34FBD6C0BE790")
+
+func newOfflineAccount(t *testing.T) *Account {
+	acc, err := NewAccount(MijinTest, offlineGenerationHash)
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	return acc
+}
+
+func newOfflineTransfer(t *testing.T, recipient *Address) *TransferTransaction {
+	tx, err := NewTransferTransaction(NewDeadline(time.Hour), recipient, []*Mosaic{Xpx(10)}, NewPlainMessage("offline"), MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	return tx
+}
+
+func newOfflineAggregate(t *testing.T, signers ...*Account) *AggregateTransaction {
+	inner := make([]Transaction, len(signers))
+	for i, signer := range signers {
+		tx := newOfflineTransfer(t, signers[(i+1)%len(signers)].Address)
+		tx.ToAggregate(signer.PublicAccount)
+		inner[i] = tx
+	}
+
+	agtx, err := NewCompleteAggregateTransaction(NewDeadline(time.Hour), inner, MijinTest)
+	assert.Nilf(t, err, "NewCompleteAggregateTransaction returned error: %s", err)
+
+	return agtx
+}
+
+func TestUnsignedTransactionFile_RoundTrip(t *testing.T) {
+	signer := newOfflineAccount(t)
+	tx := newOfflineTransfer(t, newOfflineAccount(t).Address)
+
+	file, err := NewUnsignedTransactionFile(tx, offlineGenerationHash)
+	assert.Nilf(t, err, "NewUnsignedTransactionFile returned error: %s", err)
+
+	data, err := json.Marshal(file)
+	assert.Nilf(t, err, "json.Marshal returned error: %s", err)
+
+	decoded := &UnsignedTransactionFile{}
+	err = json.Unmarshal(data, decoded)
+	assert.Nilf(t, err, "json.Unmarshal returned error: %s", err)
+
+	assert.Equal(t, offlineGenerationHash, decoded.GenerationHash)
+	assert.IsType(t, &TransferTransaction{}, decoded.Transaction)
+
+	expected, err := tx.Bytes()
+	assert.Nil(t, err)
+	actual, err := decoded.Transaction.Bytes()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+
+	signed, err := decoded.Sign(signer)
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	stx, err := signer.Sign(tx)
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+	assert.Equal(t, stx, signed.SignedTransaction)
+
+	data, err = json.Marshal(signed)
+	assert.Nilf(t, err, "json.Marshal returned error: %s", err)
+
+	decodedSigned := &SignedTransactionFile{}
+	err = json.Unmarshal(data, decodedSigned)
+	assert.Nilf(t, err, "json.Unmarshal returned error: %s", err)
+	assert.Equal(t, MijinTest, decodedSigned.NetworkType)
+	assert.Equal(t, stx.Hash, decodedSigned.SignedTransaction.Hash)
+	assert.Equal(t, strings.ToUpper(stx.Payload), decodedSigned.SignedTransaction.Payload)
+}
+
+func TestSignedTransactionFile_AddCosignatures(t *testing.T) {
+	initiator, cosigner1, cosigner2 := newOfflineAccount(t), newOfflineAccount(t), newOfflineAccount(t)
+	agtx := newOfflineAggregate(t, initiator, cosigner1, cosigner2)
+
+	file, err := NewUnsignedTransactionFile(agtx, offlineGenerationHash)
+	assert.Nilf(t, err, "NewUnsignedTransactionFile returned error: %s", err)
+
+	data, err := json.Marshal(file)
+	assert.Nilf(t, err, "json.Marshal returned error: %s", err)
+
+	decoded := &UnsignedTransactionFile{}
+	err = json.Unmarshal(data, decoded)
+	assert.Nilf(t, err, "json.Unmarshal returned error: %s", err)
+
+	signed, err := decoded.Sign(initiator)
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	cosign := func(cosigner *Account) *CosignatureFile {
+		cf, err := signed.Cosign(cosigner)
+		assert.Nilf(t, err, "Cosign returned error: %s", err)
+
+		data, err := json.Marshal(cf)
+		assert.Nilf(t, err, "json.Marshal returned error: %s", err)
+
+		decoded := &CosignatureFile{}
+		err = json.Unmarshal(data, decoded)
+		assert.Nilf(t, err, "json.Unmarshal returned error: %s", err)
+
+		return decoded
+	}
+
+	first, second := cosign(cosigner1), cosign(cosigner2)
+
+	merged, err := MergeCosignatureFiles(first, second, first)
+	assert.Nilf(t, err, "MergeCosignatureFiles returned error: %s", err)
+	assert.Len(t, merged.Cosignatures, 2)
+
+	completed, err := signed.AddCosignatures(merged, second)
+	assert.Nilf(t, err, "AddCosignatures returned error: %s", err)
+
+	expected, err := initiator.SignWithCosignatures(agtx, []*Account{cosigner1, cosigner2})
+	assert.Nilf(t, err, "SignWithCosignatures returned error: %s", err)
+	assert.Equal(t, strings.ToUpper(expected.Payload), strings.ToUpper(completed.SignedTransaction.Payload))
+	assert.Equal(t, expected.Hash, completed.SignedTransaction.Hash)
+
+	tx, err := completed.Transaction()
+	assert.Nilf(t, err, "Transaction returned error: %s", err)
+	assert.Len(t, tx.(*AggregateTransaction).Cosignatures, 2)
+
+	again, err := completed.AddCosignatures(first)
+	assert.Nilf(t, err, "AddCosignatures returned error: %s", err)
+	assert.Equal(t, completed.SignedTransaction.Payload, again.SignedTransaction.Payload)
+}
+
+func TestOfflineFiles_Errors(t *testing.T) {
+	tx := newOfflineTransfer(t, newOfflineAccount(t).Address)
+
+	file, err := NewUnsignedTransactionFile(tx, offlineGenerationHash)
+	assert.Nilf(t, err, "NewUnsignedTransactionFile returned error: %s", err)
+
+	data, err := json.Marshal(file)
+	assert.Nilf(t, err, "json.Marshal returned error: %s", err)
+
+	err = (&SignedTransactionFile{}).UnmarshalJSON(data)
+	assert.Equal(t, ErrUnexpectedFileKind, err)
+
+	future := strings.Replace(string(data), `"version":1`, `"version":2`, 1)
+	err = (&UnsignedTransactionFile{}).UnmarshalJSON([]byte(future))
+	assert.Equal(t, ErrUnsupportedFileVersion, err)
+
+	signed, err := file.Sign(newOfflineAccount(t))
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	_, err = signed.Cosign(newOfflineAccount(t))
+	assert.Equal(t, ErrNotAggregate, err)
+
+	a, b := newOfflineAccount(t), newOfflineAccount(t)
+	first, err := NewUnsignedTransactionFile(newOfflineAggregate(t, a, b), offlineGenerationHash)
+	assert.Nil(t, err)
+	second, err := NewUnsignedTransactionFile(newOfflineAggregate(t, b, a), offlineGenerationHash)
+	assert.Nil(t, err)
+
+	firstSigned, err := first.Sign(a)
+	assert.Nil(t, err)
+	secondSigned, err := second.Sign(b)
+	assert.Nil(t, err)
+
+	firstCosignature, err := firstSigned.Cosign(b)
+	assert.Nil(t, err)
+	secondCosignature, err := secondSigned.Cosign(a)
+	assert.Nil(t, err)
+
+	_, err = MergeCosignatureFiles(firstCosignature, secondCosignature)
+	assert.Equal(t, ErrCosignatureMismatch, err)
+
+	_, err = firstSigned.AddCosignatures(secondCosignature)
+	assert.Equal(t, ErrCosignatureMismatch, err)
+}
+
+func TestSignedTransactionFile_TamperedHash(t *testing.T) {
+	signer, cosigner := newOfflineAccount(t), newOfflineAccount(t)
+
+	reviewed, err := signer.Sign(newOfflineAggregate(t, signer, cosigner))
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	evil, err := signer.Sign(newOfflineAggregate(t, cosigner, signer))
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	// payload of one transaction with hash of another
+	tampered := &SignedTransaction{reviewed.EntityType, reviewed.Payload, evil.Hash}
+
+	_, err = NewSignedTransactionFile(tampered, offlineGenerationHash)
+	assert.Equal(t, ErrSignedTransactionMismatch, err)
+
+	file := &SignedTransactionFile{MijinTest, offlineGenerationHash, tampered}
+	_, err = file.Cosign(cosigner)
+	assert.Equal(t, ErrSignedTransactionMismatch, err)
+
+	valid, err := NewSignedTransactionFile(reviewed, offlineGenerationHash)
+	assert.Nilf(t, err, "NewSignedTransactionFile returned error: %s", err)
+
+	data, err := json.Marshal(valid)
+	assert.Nilf(t, err, "json.Marshal returned error: %s", err)
+
+	data = []byte(strings.Replace(string(data), reviewed.Hash.String(), evil.Hash.String(), 1))
+	err = (&SignedTransactionFile{}).UnmarshalJSON(data)
+	assert.Equal(t, ErrSignedTransactionMismatch, err)
+
+	// hash matches payload with forged signature
+	payload, err := hex.DecodeString(reviewed.Payload)
+	assert.Nil(t, err)
+	evilPayload, err := hex.DecodeString(evil.Payload)
+	assert.Nil(t, err)
+	copy(payload[SizeSize:SizeSize+SignatureSize], evilPayload[SizeSize:])
+
+	forgedHash, err := createTransactionHash(payload, offlineGenerationHash)
+	assert.Nil(t, err)
+
+	forged := &SignedTransaction{reviewed.EntityType, hex.EncodeToString(payload), forgedHash}
+	_, err = NewSignedTransactionFile(forged, offlineGenerationHash)
+	assert.Equal(t, ErrSignedTransactionMismatch, err)
+}
+
+func TestSignedTransactionFile_AddCosignatures_Invalid(t *testing.T) {
+	signer, cosigner := newOfflineAccount(t), newOfflineAccount(t)
+
+	stx, err := signer.Sign(newOfflineAggregate(t, signer, cosigner))
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	file, err := NewSignedTransactionFile(stx, offlineGenerationHash)
+	assert.Nilf(t, err, "NewSignedTransactionFile returned error: %s", err)
+
+	cosignature, err := file.Cosign(cosigner)
+	assert.Nilf(t, err, "Cosign returned error: %s", err)
+
+	// cosignature is attributed to another signer
+	cosignature.Cosignatures[0].Signer = newOfflineAccount(t).PublicAccount.PublicKey
+
+	_, err = file.AddCosignatures(cosignature)
+	assert.Equal(t, ErrInvalidCosignature, err)
+}
@@ -0,0 +1,234 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maximum number of ids which bulk endpoints of node accept in one request
+	DefaultMaxBatchSize = 100
+)
+
+// RateLimitConfig limits requests of Client to every node separately
+type RateLimitConfig struct {
+	// number of requests per second to one node, requests are not limited if it is 0
+	RequestsPerSecond float64
+	// number of requests which can be sent at once after idle period, it is 1 if it is less than 1
+	Burst int
+	// maximum number of requests to one node which wait for response, it is unlimited if it is 0
+	MaxInFlight int
+}
+
+// nodeLimiter is a token bucket with a cap of in-flight requests of one node.
+// node can block requests for a while with Retry-After header
+type nodeLimiter struct {
+	sync.Mutex
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	inFlight     chan struct{}
+}
+
+func newNodeLimiter(config *RateLimitConfig) *nodeLimiter {
+	l := &nodeLimiter{}
+
+	if config == nil {
+		return l
+	}
+
+	l.rate = config.RequestsPerSecond
+	l.burst = float64(config.Burst)
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	l.tokens = l.burst
+
+	if config.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+
+	return l
+}
+
+// waits until request can be sent to node, returns time of waiting.
+// release should be called after response is received if error is nil
+func (l *nodeLimiter) acquire(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	if delay := l.reserve(start); delay > 0 {
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.cancel()
+			return time.Since(start), ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.inFlight != nil {
+		select {
+		case <-ctx.Done():
+			l.cancel()
+			return time.Since(start), ctx.Err()
+		case l.inFlight <- struct{}{}:
+		}
+	}
+
+	return time.Since(start), nil
+}
+
+func (l *nodeLimiter) release() {
+	if l.inFlight != nil {
+		<-l.inFlight
+	}
+}
+
+// takes token from bucket and returns delay before request can be sent
+func (l *nodeLimiter) reserve(now time.Time) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+
+	var delay time.Duration
+	if l.blockedUntil.After(now) {
+		delay = l.blockedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return delay
+	}
+
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+
+	if l.tokens < 0 {
+		if d := time.Duration(-l.tokens / l.rate * float64(time.Second)); d > delay {
+			delay = d
+		}
+	}
+
+	return delay
+}
+
+// returns token of request which wasn't sent
+func (l *nodeLimiter) cancel() {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.rate > 0 {
+		l.tokens++
+	}
+}
+
+// blocks requests to node for passed duration
+func (l *nodeLimiter) block(d time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// returns limiter of passed node
+func (c *Client) limiter(node *url.URL) *nodeLimiter {
+	c.limitersMutex.Lock()
+	defer c.limitersMutex.Unlock()
+
+	if c.limiters == nil {
+		c.limiters = make(map[string]*nodeLimiter)
+	}
+
+	l, ok := c.limiters[node.String()]
+	if !ok {
+		l = newNodeLimiter(c.config.RateLimit)
+		c.limiters[node.String()] = l
+	}
+
+	return l
+}
+
+// returns delay from Retry-After header which contains seconds or HTTP date, 0 is returned if header is invalid
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// returns maximum number of ids sent in one request of bulk endpoint
+func (c *Config) maxBatchSize() int {
+	if c.MaxBatchSize > 0 {
+		return c.MaxBatchSize
+	}
+
+	return DefaultMaxBatchSize
+}
+
+// calls fn for every batch [from, to) of total ids which doesn't exceed max batch size of config.
+// Node answers ErrResourceNotFound when none of ids of batch is found, such batch is treated as empty.
+// ErrResourceNotFound is returned only if every batch is not found, as it is for request without batches
+func (c *Client) forEachBatch(total int, fn func(from, to int) error) error {
+	size := c.config.maxBatchSize()
+
+	var notFound error
+	found := false
+	for from := 0; from < total; from += size {
+		to := from + size
+		if to > total {
+			to = total
+		}
+
+		err := fn(from, to)
+		if errors.Is(err, ErrResourceNotFound) {
+			notFound = err
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		found = true
+	}
+
+	if !found && notFound != nil {
+		return notFound
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeLimiter_Rate(t *testing.T) {
+	limiter := newNodeLimiter(&RateLimitConfig{RequestsPerSecond: 100, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		_, err := limiter.acquire(ctx)
+		assert.Nilf(t, err, "nodeLimiter.acquire returned error: %s", err)
+		limiter.release()
+	}
+
+	// the first two requests are burst, every next one waits 10ms
+	assert.True(t, time.Since(start) >= time.Millisecond*35, "requests were sent in %s", time.Since(start))
+}
+
+func TestNodeLimiter_Unlimited(t *testing.T) {
+	limiter := newNodeLimiter(nil)
+
+	for i := 0; i < 100; i++ {
+		wait, err := limiter.acquire(ctx)
+		assert.Nilf(t, err, "nodeLimiter.acquire returned error: %s", err)
+		assert.True(t, wait < time.Millisecond*10)
+	}
+}
+
+func TestNodeLimiter_Cancel(t *testing.T) {
+	limiter := newNodeLimiter(&RateLimitConfig{MaxInFlight: 1})
+
+	_, err := limiter.acquire(ctx)
+	assert.Nilf(t, err, "nodeLimiter.acquire returned error: %s", err)
+
+	cancelCtx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+
+	_, err = limiter.acquire(cancelCtx)
+	assert.NotNil(t, err)
+
+	limiter.release()
+
+	_, err = limiter.acquire(ctx)
+	assert.Nilf(t, err, "nodeLimiter.acquire returned error: %s", err)
+}
+
+func TestRateLimit_MaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 20)
+		w.Write([]byte(`{"height": [42, 0]}`))
+	}))
+	defer server.Close()
+
+	client := newRetryClient(t, server, NoRetryPolicy())
+	client.config.RateLimit = &RateLimitConfig{MaxInFlight: 2}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := client.Blockchain.GetBlockchainHeight(ctx)
+			assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestRateLimit_RetryAfter(t *testing.T) {
+	requests := new(int32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Write([]byte(`{"height": [42, 0]}`))
+	}))
+	defer server.Close()
+
+	metrics := NewOperationMetrics()
+
+	client := newRetryClient(t, server, fastRetryPolicy(2))
+	client.config.Interceptors = []Interceptor{metrics.Interceptor()}
+
+	start := time.Now()
+
+	height, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	assert.Equal(t, Height(42), height)
+	assert.True(t, time.Since(start) >= time.Millisecond*900, "request was repeated after %s", time.Since(start))
+
+	stats := metrics.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, uint64(2), stats[0].Count)
+	assert.True(t, stats[0].MaxWait >= time.Millisecond*900, "max wait is %s", stats[0].MaxWait)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2019, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Second*120, parseRetryAfter("120", now))
+	assert.Equal(t, time.Second*30, parseRetryAfter(now.Add(time.Second*30).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-1", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
+func TestTransactionService_GetTransactionStatuses_Batches(t *testing.T) {
+	var mutex sync.Mutex
+	batches := make([]int, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dto := TransactionHashesDTO{}
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mutex.Lock()
+		batches = append(batches, len(dto.Hashes))
+		mutex.Unlock()
+
+		w.Write([]byte("["))
+		for i, hash := range dto.Hashes {
+			if i > 0 {
+				w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+		}
+		w.Write([]byte("]"))
+	}))
+	defer server.Close()
+
+	client := newRetryClient(t, server, NoRetryPolicy())
+	client.config.MaxBatchSize = 100
+
+	hashes := make([]string, 250)
+	for i := range hashes {
+		hashes[i] = fmt.Sprintf("%064x", i)
+	}
+
+	statuses, err := client.Transaction.GetTransactionStatuses(ctx, hashes)
+	assert.Nilf(t, err, "TransactionService.GetTransactionStatuses returned error: %s", err)
+	assert.Len(t, statuses, 250)
+	assert.Equal(t, []int{100, 100, 50}, batches)
+	assert.Equal(t, hashes[249], statuses[249].Hash.String())
+}
+
+func TestAccountService_GetAccountsInfo_BatchNotFound(t *testing.T) {
+	unused := &Address{MijinTest, nemTestAddress2}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dto := struct {
+			Addresses []string `json:"addresses"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// node answers 404 when none of addresses of request is known
+		if len(dto.Addresses) == 1 && dto.Addresses[0] == unused.Address {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "ResourceNotFound", "message": "no resource exists"}`))
+			return
+		}
+
+		w.Write([]byte("[" + accountInfoJson + "]"))
+	}))
+	defer server.Close()
+
+	client := newRetryClient(t, server, NoRetryPolicy())
+	client.config.MaxBatchSize = 1
+
+	infos, err := client.Account.GetAccountsInfo(ctx, unused, &Address{MijinTest, nemTestAddress1})
+	assert.Nilf(t, err, "AccountService.GetAccountsInfo returned error: %s", err)
+	assert.Len(t, infos, 1)
+
+	_, err = client.Account.GetAccountsInfo(ctx, unused, unused)
+	assert.True(t, errors.Is(err, ErrResourceNotFound))
+}
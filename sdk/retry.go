@@ -0,0 +1,136 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = time.Millisecond * 200
+	DefaultRetryMaxBackoff     = time.Second * 5
+	DefaultRetryMultiplier     = 2.0
+	DefaultRetryJitter         = 0.5
+)
+
+// RetryPolicy describes when and how often failed REST requests are repeated.
+// Every request of REST API is safe to repeat: GET and POST requests only read data,
+// PUT requests announce transactions and node ignores transaction with already known hash
+type RetryPolicy struct {
+	// number of attempts including the first one, request is not repeated if it is less than 2
+	MaxAttempts int
+	// delay before the second attempt, every next delay is multiplied by Multiplier up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// share of delay which is randomized, from 0 to 1
+	Jitter float64
+	// HTTP status codes of responses which are repeated
+	RetryableStatusCodes []int
+	// returns true if request failed with passed error should be repeated.
+	// if it is nil, status codes from RetryableStatusCodes and temporary network errors are repeated
+	IsRetryable func(err error) bool
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Multiplier:     DefaultRetryMultiplier,
+		Jitter:         DefaultRetryJitter,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// returns RetryPolicy which never repeats requests
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// returns true if request failed with passed error should be repeated
+func (p *RetryPolicy) retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if p.IsRetryable != nil {
+		return p.IsRetryable(err)
+	}
+
+	switch e := err.(type) {
+	case *ApiError:
+		for _, code := range p.RetryableStatusCodes {
+			if code == e.StatusCode {
+				return true
+			}
+		}
+
+		return false
+	case net.Error:
+		return e.Timeout() || e.Temporary()
+	default:
+		return false
+	}
+}
+
+// returns delay before passed attempt, attempts are counted from 1
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-2))
+
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d -= d * jitter * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+// waits before passed attempt, returns error if context is done earlier
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// returns retry policy of passed service, policy of service overrides default one
+func (c *Config) retryPolicy(service ServiceName) *RetryPolicy {
+	if p, ok := c.ServiceRetryPolicies[service]; ok && p != nil {
+		return p
+	}
+
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
+	}
+
+	return NoRetryPolicy()
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// returns server which responds with 503 to first failures requests
+func newBurstServer(failures int32, body string) (*httptest.Server, *int32) {
+	requests := new(int32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+
+	return server, requests
+}
+
+func newRetryClient(t *testing.T, server *httptest.Server, policy *RetryPolicy) *Client {
+	conf, err := NewConfigWithReputation([]string{server.URL}, MijinTest, &defaultRepConfig, DefaultWebsocketReconnectionTimeout, nil, DefaultFeeCalculationStrategy)
+	assert.Nilf(t, err, "NewConfigWithReputation returned error: %s", err)
+
+	conf.RetryPolicy = policy
+
+	return NewClient(nil, conf)
+}
+
+func fastRetryPolicy(attempts int) *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = attempts
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = time.Millisecond * 5
+
+	return policy
+}
+
+func TestRetryPolicy_Burst(t *testing.T) {
+	server, requests := newBurstServer(2, `{"height": [42, 0]}`)
+	defer server.Close()
+
+	client := newRetryClient(t, server, fastRetryPolicy(3))
+
+	height, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	assert.Equal(t, Height(42), height)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_AttemptsExceeded(t *testing.T) {
+	server, requests := newBurstServer(5, `{"height": [42, 0]}`)
+	defer server.Close()
+
+	client := newRetryClient(t, server, fastRetryPolicy(3))
+
+	_, err := client.Blockchain.GetBlockchainHeight(ctx)
+	httpErr, ok := err.(*ApiError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_ServiceOverride(t *testing.T) {
+	server, requests := newBurstServer(1, `{"height": [42, 0]}`)
+	defer server.Close()
+
+	client := newRetryClient(t, server, fastRetryPolicy(3))
+	client.config.ServiceRetryPolicies = map[ServiceName]*RetryPolicy{
+		BlockchainServiceName: NoRetryPolicy(),
+	}
+
+	_, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_NotRetryable(t *testing.T) {
+	requests := new(int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newRetryClient(t, server, fastRetryPolicy(3))
+
+	_, err := client.Blockchain.GetBlockByHeight(ctx, Height(1))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_Announce(t *testing.T) {
+	server, requests := newBurstServer(2, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	defer server.Close()
+
+	client := newRetryClient(t, server, fastRetryPolicy(3))
+
+	_, err := client.Transaction.Announce(ctx, &SignedTransaction{EntityType: Transfer, Payload: "00", Hash: &Hash{1}})
+	assert.Nilf(t, err, "Announce returned error: %s", err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_Cancel(t *testing.T) {
+	server, requests := newBurstServer(5, `{"height": [42, 0]}`)
+	defer server.Close()
+
+	policy := fastRetryPolicy(5)
+	policy.InitialBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+
+	client := newRetryClient(t, server, policy)
+
+	cancelCtx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+
+	_, err := client.Blockchain.GetBlockchainHeight(cancelCtx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Second * 3, Multiplier: 2}
+
+	assert.Equal(t, time.Second, policy.backoff(2))
+	assert.Equal(t, time.Second*2, policy.backoff(3))
+	assert.Equal(t, time.Second*3, policy.backoff(4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		d := policy.backoff(3)
+		assert.True(t, d >= time.Second && d <= time.Second*2, "backoff %s is out of range", d)
+	}
+}
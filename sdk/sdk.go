@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -22,18 +23,15 @@ import (
 )
 
 const (
-	DefaultWebsocketReconnectionTimeout = time.Second * 5
-	DefaultFeeCalculationStrategy       = MiddleCalculationStrategy
-	DefaultMaxFee                       = 5 * 1000000
+	DefaultWebsocketReconnectionTimeout  = time.Second * 5
+	DefaultWebsocketMaxReconnectionDelay = time.Minute
+	DefaultWebsocketPingInterval         = time.Second * 30
+	DefaultFeeCalculationStrategy        = MiddleCalculationStrategy
+	DefaultMaxFee                        = 5 * 1000000
 )
 
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
-type HttpError struct {
-	error
-	StatusCode int
-}
-
 type FeeCalculationStrategy uint32
 
 // FeeCalculationStrategy enums
@@ -46,12 +44,47 @@ const (
 // Provides service configuration
 type Config struct {
 	reputationConfig      *reputationConfig
+	usedBaseUrlMutex      sync.RWMutex
 	BaseURLs              []*url.URL
 	UsedBaseUrl           *url.URL
 	WsReconnectionTimeout time.Duration
 	GenerationHash        *Hash
 	NetworkType
 	FeeCalculationStrategy
+	// maximum delay between websocket reconnection attempts. Delay starts from WsReconnectionTimeout
+	// and is doubled after every failed attempt, it isn't increased if WsMaxReconnectionDelay is not greater than WsReconnectionTimeout
+	WsMaxReconnectionDelay time.Duration
+	// interval of pings sent by websocket client, connection is considered dead when nothing is received during two intervals.
+	// Pings are not sent if it is 0
+	WsPingInterval time.Duration
+	// policy of repeating failed requests, requests are not repeated if it is nil
+	RetryPolicy *RetryPolicy
+	// policies which override RetryPolicy for requests of particular services
+	ServiceRetryPolicies map[ServiceName]*RetryPolicy
+	// interceptors of every REST request, the first interceptor is the outermost one
+	Interceptors []Interceptor
+	// configuration of cache of immutable chain data, results are not cached if it is nil
+	Cache *CacheConfig
+	// limits of requests to every node, requests are limited only by Retry-After headers of nodes if it is nil
+	RateLimit *RateLimitConfig
+	// maximum number of ids sent in one request of bulk endpoints, DefaultMaxBatchSize is used if it is 0
+	MaxBatchSize int
+}
+
+// returns url of the node which served the last request.
+// UsedBaseUrl should be accessed through GetUsedBaseUrl and SetUsedBaseUrl when config is shared between goroutines
+func (c *Config) GetUsedBaseUrl() *url.URL {
+	c.usedBaseUrlMutex.RLock()
+	defer c.usedBaseUrlMutex.RUnlock()
+
+	return c.UsedBaseUrl
+}
+
+func (c *Config) SetUsedBaseUrl(u *url.URL) {
+	c.usedBaseUrlMutex.Lock()
+	defer c.usedBaseUrlMutex.Unlock()
+
+	c.UsedBaseUrl = u
 }
 
 type reputationConfig struct {
@@ -138,6 +171,8 @@ func NewConfigWithReputation(
 		BaseURLs:               urls,
 		UsedBaseUrl:            urls[0],
 		WsReconnectionTimeout:  wsReconnectionTimeout,
+		WsMaxReconnectionDelay: DefaultWebsocketMaxReconnectionDelay,
+		WsPingInterval:         DefaultWebsocketPingInterval,
 		NetworkType:            networkType,
 		reputationConfig:       repConf,
 		GenerationHash:         generationHash,
@@ -151,7 +186,9 @@ func NewConfigWithReputation(
 type Client struct {
 	client *http.Client // HTTP client used to communicate with the API.
 	config *Config
-	common service // Reuse a single struct instead of allocating one for each service on the heap.
+	// rate limiters of nodes by base url
+	limitersMutex sync.Mutex
+	limiters      map[string]*nodeLimiter
 	// Services for communicating to the Catapult REST APIs
 	Blockchain    *BlockchainService
 	Exchange      *ExchangeService
@@ -166,10 +203,14 @@ type Client struct {
 	Lock          *LockService
 	Contract      *ContractService
 	Metadata      *MetadataService
+	Fee           *FeeEstimator
+	Nodes         *NodePool
+	Cache         *ChainCache
 }
 
 type service struct {
 	client *Client
+	name   ServiceName
 }
 
 // returns catapult http.Client from passed existing client and configuration
@@ -190,24 +231,30 @@ func NewClient(httpClient *http.Client, conf *Config) *Client {
 	}
 
 	c := &Client{client: httpClient, config: conf}
-	c.common.client = c
-	c.Blockchain = (*BlockchainService)(&c.common)
-	c.Mosaic = (*MosaicService)(&c.common)
-	c.Namespace = (*NamespaceService)(&c.common)
-	c.Network = &NetworkService{&c.common, c.Blockchain}
-	c.Resolve = &ResolverService{&c.common, c.Namespace, c.Mosaic}
-	c.Transaction = &TransactionService{&c.common, c.Blockchain}
-	c.Exchange = &ExchangeService{&c.common, c.Resolve}
-	c.Account = (*AccountService)(&c.common)
-	c.Lock = (*LockService)(&c.common)
-	c.Storage = &StorageService{&c.common, c.Lock}
-	c.SuperContract = (*SuperContractService)(&c.common)
-	c.Contract = (*ContractService)(&c.common)
-	c.Metadata = (*MetadataService)(&c.common)
+	c.Blockchain = (*BlockchainService)(c.newService(BlockchainServiceName))
+	c.Mosaic = (*MosaicService)(c.newService(MosaicServiceName))
+	c.Namespace = (*NamespaceService)(c.newService(NamespaceServiceName))
+	c.Network = &NetworkService{c.newService(NetworkServiceName), c.Blockchain}
+	c.Resolve = &ResolverService{c.newService(ResolverServiceName), c.Namespace, c.Mosaic}
+	c.Transaction = &TransactionService{c.newService(TransactionServiceName), c.Blockchain}
+	c.Exchange = &ExchangeService{c.newService(ExchangeServiceName), c.Resolve}
+	c.Account = (*AccountService)(c.newService(AccountServiceName))
+	c.Lock = (*LockService)(c.newService(LockServiceName))
+	c.Storage = &StorageService{c.newService(StorageServiceName), c.Lock}
+	c.SuperContract = (*SuperContractService)(c.newService(SuperContractServiceName))
+	c.Contract = (*ContractService)(c.newService(ContractServiceName))
+	c.Metadata = (*MetadataService)(c.newService(MetadataServiceName))
+	c.Fee = NewFeeEstimator(c.Blockchain, nil)
+	c.Nodes = newNodePool(c, conf.BaseURLs, nil)
+	c.Cache = newChainCache(c, conf.Cache)
 
 	return c
 }
 
+func (c *Client) newService(name ServiceName) *service {
+	return &service{client: c, name: name}
+}
+
 func (c *Client) NetworkType() NetworkType {
 	return c.config.NetworkType
 }
@@ -221,39 +268,76 @@ func (c *Client) AdaptAccount(account *Account) (*Account, error) {
 	return c.NewAccountFromPrivateKey(account.PrivateKey.String())
 }
 
-// doNewRequest creates new request, Do it & return result in V
-func (c *Client) doNewRequest(ctx context.Context, method string, path string, body interface{}, v interface{}) (*http.Response, error) {
-	req, err := c.newRequest(method, path, body)
+// doNewRequest creates new request of passed operation, Do it & return result in V.
+// failed request is repeated according to retry policy of operation service
+func (c *Client) doNewRequest(ctx context.Context, op RequestOperation, method string, path string, body interface{}, v interface{}) (*http.Response, error) {
+	policy := c.config.retryPolicy(op.Service)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doNodesRequest(ctx, op, method, path, body, v)
+		if attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return resp, err
+		}
+
+		if waitErr := policy.wait(ctx, attempt+1); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+}
+
+// doNodesRequest sends request to the node chosen by NodePool, next nodes are tried on network errors
+func (c *Client) doNodesRequest(ctx context.Context, op RequestOperation, method string, path string, body interface{}, v interface{}) (*http.Response, error) {
+	var err error
+
+	for _, node := range c.Nodes.candidates() {
+		start := time.Now()
+
+		var resp *http.Response
+		resp, err = c.doRequestToNode(ctx, op, node, method, path, body, v)
+		c.Nodes.report(node, time.Since(start), err, false)
+
+		if _, ok := err.(*url.Error); ok {
+			continue
+		}
+
+		c.config.SetUsedBaseUrl(node)
+
+		return resp, err
+	}
+
+	return nil, err
+}
+
+// doRequestToNode creates new request to passed node, Do it through interceptors of config & return result in V
+func (c *Client) doRequestToNode(ctx context.Context, op RequestOperation, node *url.URL, method string, path string, body interface{}, v interface{}) (*http.Response, error) {
+	req, err := c.newRequest(node, method, path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.do(ctx, req, v)
-	if err != nil {
-		switch err.(type) {
-		case *url.Error:
-			for _, url := range c.config.BaseURLs {
-				if c.config.UsedBaseUrl == url {
-					continue
-				}
-
-				req.URL.Host = url.Host
-				resp, err = c.do(ctx, req, v)
-				if err != nil {
-					continue
-				}
-
-				c.config.UsedBaseUrl = url
-				return resp, nil
-			}
+	invoke := chainInterceptors(c.config.Interceptors, func(ctx context.Context, call *Call) (*http.Response, error) {
+		limiter := c.limiter(node)
 
-			return nil, err
-		default:
+		wait, err := limiter.acquire(ctx)
+		call.Wait = wait
+		if err != nil {
 			return nil, err
 		}
-	}
+		defer limiter.release()
+
+		resp, err := c.do(ctx, call.Request, v)
+		if err == nil {
+			call.Response = v
+		}
 
-	return resp, nil
+		if apiErr, ok := err.(*ApiError); ok && apiErr.RetryAfter > 0 {
+			limiter.block(apiErr.RetryAfter)
+		}
+
+		return resp, err
+	})
+
+	return invoke(ctx, &Call{Operation: op, Request: req})
 }
 
 // do sends an API Request and returns a parsed response
@@ -279,11 +363,9 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	if resp.StatusCode > 226 || resp.StatusCode < 200 {
 		b := &bytes.Buffer{}
 		b.ReadFrom(resp.Body)
-		httpError := HttpError{
-			fmt.Errorf("sdk do request: %s", b.String()),
-			resp.StatusCode,
-		}
-		return nil, &httpError
+		apiErr := newApiError(resp.StatusCode, b.Bytes())
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, apiErr
 	}
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
@@ -302,10 +384,10 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	return resp, err
 }
 
-func (c *Client) newRequest(method, urlStr string, body interface{}) (*http.Request, error) {
-	u, err := c.config.UsedBaseUrl.Parse(urlStr)
+func (c *Client) newRequest(node *url.URL, method, urlStr string, body interface{}) (*http.Request, error) {
+	u, err := node.Parse(urlStr)
 	if err != nil {
-		return nil, fmt.Errorf("sdk.newRequest config.UsedBaseUrl.Parse: %v", err)
+		return nil, fmt.Errorf("sdk.newRequest node.Parse: %v", err)
 	}
 
 	var buf io.ReadWriter
@@ -0,0 +1,138 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdktest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CloseConnection is a status code of Failure which closes connection without response
+const CloseConnection = -1
+
+// Failure describes requests which Node answers with error instead of serving them
+type Failure struct {
+	// method of failed requests, requests of any method fail if it is empty
+	Method string
+	// prefix of path of failed requests, requests of any path fail if it is empty
+	Path string
+	// status code of response, http.StatusInternalServerError is used if it is 0.
+	// Connection is closed without response if it is CloseConnection
+	StatusCode int
+	// body of response, error in format of Catapult REST is written if it is empty
+	Body string
+	// value of Retry-After header, header isn't written if it is 0
+	RetryAfter time.Duration
+	// delay of response
+	Delay time.Duration
+	// number of failed requests, all matched requests fail until ClearFailures if it is 0
+	Times int
+}
+
+func (f *Failure) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+func (f *Failure) write(w http.ResponseWriter) {
+	if f.Delay > 0 {
+		time.Sleep(f.Delay)
+	}
+
+	if f.StatusCode == CloseConnection {
+		closeConnection(w)
+		return
+	}
+
+	status := f.StatusCode
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+	}
+
+	if f.Body != "" {
+		w.WriteHeader(status)
+		w.Write([]byte(f.Body))
+		return
+	}
+
+	writeError(w, status, strings.Replace(http.StatusText(status), " ", "", -1), "failure is scripted by sdktest node")
+}
+
+// makes Node answer requests with error, every failure is applied to the requests matched by it.
+// Failures are checked in the order of adding, the first matched one is applied
+func (n *Node) Fail(failures ...Failure) {
+	n.failuresMutex.Lock()
+	defer n.failuresMutex.Unlock()
+
+	for i := range failures {
+		f := failures[i]
+		n.failures = append(n.failures, &f)
+	}
+}
+
+// removes all failures added by Fail
+func (n *Node) ClearFailures() {
+	n.failuresMutex.Lock()
+	defer n.failuresMutex.Unlock()
+
+	n.failures = nil
+}
+
+// sets delay of every response of Node
+func (n *Node) SetLatency(latency time.Duration) {
+	n.failuresMutex.Lock()
+	defer n.failuresMutex.Unlock()
+
+	n.latency = latency
+}
+
+// closes all websocket connections, clients can connect again
+func (n *Node) DropWebsockets() {
+	n.hub.closeAll()
+}
+
+// counts request and returns latency and failure which is applied to request
+func (n *Node) intercept(r *http.Request) (time.Duration, *Failure) {
+	n.failuresMutex.Lock()
+	defer n.failuresMutex.Unlock()
+
+	n.requests[r.Method+" "+r.URL.Path]++
+
+	for i, f := range n.failures {
+		if !f.matches(r) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				n.failures = append(n.failures[:i], n.failures[i+1:]...)
+			}
+		}
+
+		return n.latency, f
+	}
+
+	return n.latency, nil
+}
+
+func closeConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+
+	conn.Close()
+}
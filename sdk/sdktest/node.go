@@ -0,0 +1,406 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+// Package sdktest provides an in-memory fake Catapult node for tests of code which uses sdk.
+// Node serves REST routes and websocket channels of Catapult REST, accepts announced transactions and applies
+// transfers, namespaces and mosaics to a simple state model when blocks are generated
+package sdktest
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const (
+	// private key of nemesis account of DefaultNodeConfig
+	DefaultNemesisPrivateKey = "A97B139EB641BCC841A610231870925EB301BA680D07BBCF9AEE4FA1FBAFE0D2"
+	// generation hash of DefaultNodeConfig
+	DefaultGenerationHash = "86258172F90639811F2ABD055747D1E11B55A64B68AED2CEA9A34FBD6C0BE790"
+	// XPX owned by nemesis account at start, XPX has divisibility 6
+	NemesisXpxAmount = 9000000000000000
+	// number of transactions in a page of account or block transactions if page size isn't passed
+	defaultPageSize = 10
+	// greater page size is capped by node
+	maxPageSize = 100
+)
+
+// id of XPX mosaic which is linked to sdk.XpxNamespaceId
+var XpxMosaicId, _ = sdk.NewMosaicId(0x0DC67FBE1CAD29E3)
+
+// NodeConfig describes network of Node
+type NodeConfig struct {
+	NetworkType    sdk.NetworkType
+	GenerationHash *sdk.Hash
+	// private key of nemesis account which owns namespace prx.xpx and all XPX at start
+	NemesisPrivateKey string
+	// interval of automatic generation of blocks, blocks are generated only by GenerateBlock if it is 0
+	BlockInterval time.Duration
+}
+
+// returns NodeConfig of MijinTest network which generates blocks only by GenerateBlock
+func DefaultNodeConfig() *NodeConfig {
+	hash, _ := sdk.StringToHash(DefaultGenerationHash)
+
+	return &NodeConfig{
+		NetworkType:       sdk.MijinTest,
+		GenerationHash:    hash,
+		NemesisPrivateKey: DefaultNemesisPrivateKey,
+	}
+}
+
+// transaction is announced transaction with its status
+type transaction struct {
+	tx     sdk.Transaction
+	hash   string
+	id     string
+	group  string
+	status string
+	height sdk.Height
+	index  uint32
+	// raw addresses of accounts which are notified about transaction
+	addresses []string
+}
+
+type block struct {
+	height       sdk.Height
+	hash         string
+	previousHash string
+	timestamp    uint64
+	transactions []*transaction
+	// state after block, it isn't changed later
+	state *state
+}
+
+// Node is a fake Catapult node which runs HTTP server on local address.
+// Node is safe for concurrent use
+type Node struct {
+	config  *NodeConfig
+	nemesis *sdk.Account
+	server  *httptest.Server
+	routes  []*route
+
+	mutex        sync.Mutex
+	state        *state
+	blocks       []*block
+	transactions map[string]*transaction
+	unconfirmed  []*transaction
+	objects      int
+	forks        int
+
+	failuresMutex sync.Mutex
+	failures      []*Failure
+	latency       time.Duration
+	requests      map[string]int
+
+	hub  *hub
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// returns started Node with nemesis block, node with DefaultNodeConfig is returned if passed config is nil.
+// Node should be closed by Close
+func NewNode(config *NodeConfig) (*Node, error) {
+	if config == nil {
+		config = DefaultNodeConfig()
+	}
+
+	nemesis, err := sdk.NewAccountFromPrivateKey(config.NemesisPrivateKey, config.NetworkType, config.GenerationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Node{
+		config:       config,
+		nemesis:      nemesis,
+		state:        newState(),
+		transactions: make(map[string]*transaction),
+		requests:     make(map[string]int),
+		hub:          newHub(),
+		stop:         make(chan struct{}),
+	}
+
+	n.initNemesis()
+	n.routes = n.restRoutes()
+	n.server = httptest.NewServer(http.HandlerFunc(n.serve))
+
+	if config.BlockInterval > 0 {
+		n.done.Add(1)
+		go n.generateBlocks(config.BlockInterval)
+	}
+
+	return n, nil
+}
+
+// returns base url of Node which is passed to sdk.NewConfig
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// returns config of sdk.Client which is filled by information of Node
+func (n *Node) Config(ctx context.Context) (*sdk.Config, error) {
+	return sdk.NewConfig(ctx, []string{n.URL()})
+}
+
+// returns nemesis account which owns namespace prx.xpx and XPX at start
+func (n *Node) Nemesis() *sdk.Account {
+	return n.nemesis
+}
+
+// stops generation of blocks, closes websocket connections and HTTP server
+func (n *Node) Close() {
+	close(n.stop)
+	n.done.Wait()
+
+	n.hub.closeAll()
+	n.server.CloseClientConnections()
+	n.server.Close()
+}
+
+// returns current height of blockchain
+func (n *Node) Height() sdk.Height {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.height()
+}
+
+func (n *Node) height() sdk.Height {
+	return n.blocks[len(n.blocks)-1].height
+}
+
+// returns number of requests with passed method and path which were received by Node
+func (n *Node) Requests(method, path string) int {
+	n.failuresMutex.Lock()
+	defer n.failuresMutex.Unlock()
+
+	return n.requests[method+" "+path]
+}
+
+// creates nemesis block with namespace prx.xpx linked to XPX mosaic owned by nemesis account
+func (n *Node) initNemesis() {
+	owner := n.state.signer(n.nemesis.PublicAccount, 1)
+
+	prx, _ := sdk.NewNamespaceIdFromName("prx")
+
+	n.state.namespaces[prx.Id()] = &namespace{
+		id:          prx.Id(),
+		name:        "prx",
+		levels:      []uint64{prx.Id()},
+		owner:       owner.publicKey,
+		startHeight: 1,
+		endHeight:   eternalHeight,
+	}
+
+	n.state.namespaces[sdk.XpxNamespaceId.Id()] = &namespace{
+		id:          sdk.XpxNamespaceId.Id(),
+		name:        "prx.xpx",
+		parent:      prx.Id(),
+		levels:      []uint64{prx.Id(), sdk.XpxNamespaceId.Id()},
+		owner:       owner.publicKey,
+		startHeight: 1,
+		endHeight:   eternalHeight,
+		aliasType:   sdk.MosaicAliasType,
+		aliasMosaic: XpxMosaicId.Id(),
+	}
+
+	n.state.mosaics[XpxMosaicId.Id()] = &mosaic{
+		id:           XpxMosaicId.Id(),
+		supply:       NemesisXpxAmount,
+		height:       1,
+		owner:        owner.publicKey,
+		revision:     1,
+		transferable: true,
+		divisibility: 6,
+	}
+
+	owner.balances[XpxMosaicId.Id()] = NemesisXpxAmount
+
+	nemesisBlock := n.newBlock(1, strings.Repeat("0", 64))
+	nemesisBlock.state = n.state
+	n.blocks = append(n.blocks, nemesisBlock)
+}
+
+func (n *Node) newBlock(height sdk.Height, previousHash string) *block {
+	// blocks generated after fork differ from blocks of abandoned chain at the same height
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%s/%d", n.config.GenerationHash, height, previousHash, n.forks)))
+
+	return &block{
+		height:       height,
+		hash:         fmt.Sprintf("%X", hash),
+		previousHash: previousHash,
+		timestamp:    blockchainTimestamp(time.Now()),
+	}
+}
+
+// returns new id of database object, ids grow in the order of creation
+func (n *Node) newObjectId() string {
+	n.objects++
+
+	return fmt.Sprintf("%024X", n.objects)
+}
+
+func (n *Node) generateBlocks(interval time.Duration) {
+	defer n.done.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			n.GenerateBlock()
+		}
+	}
+}
+
+// generates block with all unconfirmed transactions and returns its height.
+// Every transaction is applied to state separately, failed transactions don't change state and are reported to status channel
+func (n *Node) GenerateBlock() sdk.Height {
+	n.mutex.Lock()
+
+	last := n.blocks[len(n.blocks)-1]
+	b := n.newBlock(last.height+1, last.hash)
+	now := time.Now()
+
+	confirmed := make([]*transaction, 0, len(n.unconfirmed))
+	failed := make([]*transaction, 0)
+
+	for _, t := range n.unconfirmed {
+		status := StatusPastDeadline
+		var next *state
+
+		if deadline := t.tx.GetAbstractTransaction().Deadline; deadline == nil || deadline.After(now) {
+			next = n.state.clone()
+			status = next.apply(t.tx, b.height)
+		}
+
+		t.status = status
+
+		if status != StatusSuccess {
+			t.group = groupFailed
+			failed = append(failed, t)
+			continue
+		}
+
+		n.state = next
+		t.group = groupConfirmed
+		t.height = b.height
+		t.index = uint32(len(b.transactions))
+		t.addresses = n.state.addresses(t.tx)
+		b.transactions = append(b.transactions, t)
+		confirmed = append(confirmed, t)
+	}
+
+	n.unconfirmed = nil
+	b.state = n.state
+	n.blocks = append(n.blocks, b)
+
+	messages := make([]*message, 0, 1+len(confirmed)+len(failed))
+	messages = append(messages, n.blockMessage(b))
+	for _, t := range confirmed {
+		messages = append(messages, n.transactionMessages(channelConfirmedAdded, t)...)
+	}
+	for _, t := range failed {
+		messages = append(messages, n.statusMessages(t)...)
+	}
+
+	n.mutex.Unlock()
+
+	n.hub.publish(messages...)
+
+	return b.height
+}
+
+// drops blocks above passed height as if node switched to another fork of chain.
+// Transactions of dropped blocks become unconfirmed again and following blocks get hashes different from dropped ones
+func (n *Node) Fork(height sdk.Height) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if height < 1 || height >= n.height() {
+		return
+	}
+
+	var unconfirmed []*transaction
+	for _, b := range n.blocks[height:] {
+		for _, t := range b.transactions {
+			t.group = groupUnconfirmed
+			t.height = 0
+			t.index = 0
+			unconfirmed = append(unconfirmed, t)
+		}
+	}
+
+	n.blocks = n.blocks[:height]
+	n.state = n.blocks[height-1].state
+	n.unconfirmed = append(unconfirmed, n.unconfirmed...)
+	n.forks++
+}
+
+// adds announced transaction to unconfirmed transactions, transaction which is already known is ignored
+func (n *Node) announce(tx sdk.Transaction, hash string, group string) {
+	n.mutex.Lock()
+
+	if _, ok := n.transactions[hash]; ok {
+		n.mutex.Unlock()
+		return
+	}
+
+	t := &transaction{
+		tx:        tx,
+		hash:      hash,
+		group:     group,
+		status:    StatusSuccess,
+		id:        n.newObjectId(),
+		addresses: n.state.addresses(tx),
+	}
+
+	n.transactions[hash] = t
+
+	var messages []*message
+
+	switch {
+	case !supported(tx, group):
+		t.group = groupFailed
+		t.status = StatusUnsupported
+		messages = n.statusMessages(t)
+	case group == groupUnconfirmed:
+		n.unconfirmed = append(n.unconfirmed, t)
+		messages = n.transactionMessages(channelUnconfirmedAdded, t)
+	case group == groupPartial:
+		messages = n.transactionMessages(channelPartialAdded, t)
+	}
+
+	n.mutex.Unlock()
+
+	n.hub.publish(messages...)
+}
+
+// returns true if Node can render and confirm passed transaction of group
+func supported(tx sdk.Transaction, group string) bool {
+	if _, err := renderTransactionBody(tx, false); err != nil {
+		return false
+	}
+
+	agtx, ok := tx.(*sdk.AggregateTransaction)
+	if !ok {
+		return group == groupUnconfirmed
+	}
+
+	if group == groupPartial {
+		return agtx.Type == sdk.AggregateBonded
+	}
+
+	return agtx.Type == sdk.AggregateCompleted
+}
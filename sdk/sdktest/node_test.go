@@ -0,0 +1,265 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdktest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+func newTestClient(t *testing.T) (*Node, *sdk.Client) {
+	node, err := NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+
+	cfg, err := node.Config(context.Background())
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	return node, sdk.NewClient(nil, cfg)
+}
+
+func announce(t *testing.T, client *sdk.Client, signer *sdk.Account, tx sdk.Transaction) *sdk.SignedTransaction {
+	stx, err := signer.Sign(tx)
+	assert.Nilf(t, err, "Sign returned error: %s", err)
+
+	_, err = client.Transaction.Announce(context.Background(), stx)
+	assert.Nilf(t, err, "Announce returned error: %s", err)
+
+	return stx
+}
+
+// waits until some websocket connection of node subscribes to passed topic
+func waitSubscribed(t *testing.T, node *Node, topic string) {
+	for i := 0; i < 100; i++ {
+		node.hub.mutex.Lock()
+		subscribed := false
+		for _, c := range node.hub.connections {
+			subscribed = subscribed || c.subscribed(topic)
+		}
+		node.hub.mutex.Unlock()
+
+		if subscribed {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("websocket client didn't subscribe to %s", topic)
+}
+
+func TestNode_Config(t *testing.T) {
+	node, client := newTestClient(t)
+	defer node.Close()
+
+	assert.Equal(t, sdk.MijinTest, client.NetworkType())
+	assert.Equal(t, DefaultNodeConfig().GenerationHash, client.GenerationHash())
+
+	height, err := client.Blockchain.GetBlockchainHeight(context.Background())
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	assert.Equal(t, sdk.Height(1), height)
+}
+
+func TestNode_Transfer(t *testing.T) {
+	node, client := newTestClient(t)
+	defer node.Close()
+
+	ctx := context.Background()
+	nemesis := node.Nemesis()
+
+	recipient, err := sdk.NewAccount(sdk.MijinTest, client.GenerationHash())
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	tx, err := client.NewTransferTransaction(sdk.NewDeadline(time.Hour), recipient.Address, []*sdk.Mosaic{sdk.Xpx(1000)}, sdk.NewPlainMessage("test"))
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx := announce(t, client, nemesis, tx)
+
+	assert.Equal(t, sdk.Height(2), node.GenerateBlock())
+
+	status, err := client.Transaction.GetTransactionStatus(ctx, stx.Hash.String())
+	assert.Nilf(t, err, "GetTransactionStatus returned error: %s", err)
+	assert.Equal(t, StatusSuccess, status.Status)
+	assert.Equal(t, sdk.Height(2), status.Height)
+
+	info, err := client.Account.GetAccountInfo(ctx, recipient.Address)
+	assert.Nilf(t, err, "GetAccountInfo returned error: %s", err)
+	assert.Len(t, info.Mosaics, 1)
+	assert.Equal(t, XpxMosaicId.Id(), info.Mosaics[0].AssetId.Id())
+	assert.Equal(t, sdk.Amount(1000), info.Mosaics[0].Amount)
+
+	confirmed, err := client.Transaction.GetTransaction(ctx, stx.Hash.String())
+	assert.Nilf(t, err, "GetTransaction returned error: %s", err)
+	assert.Equal(t, sdk.Transfer, confirmed.GetAbstractTransaction().Type)
+
+	txs, err := client.Account.IncomingTransactions(ctx, recipient.PublicAccount, nil)
+	assert.Nilf(t, err, "IncomingTransactions returned error: %s", err)
+	assert.Len(t, txs, 1)
+}
+
+func TestNode_InsufficientBalance(t *testing.T) {
+	node, client := newTestClient(t)
+	defer node.Close()
+
+	sender, err := sdk.NewAccount(sdk.MijinTest, client.GenerationHash())
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	tx, err := client.NewTransferTransaction(sdk.NewDeadline(time.Hour), node.Nemesis().Address, []*sdk.Mosaic{sdk.Xpx(1)}, sdk.NewPlainMessage(""))
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx := announce(t, client, sender, tx)
+	node.GenerateBlock()
+
+	status, err := client.Transaction.GetTransactionStatus(context.Background(), stx.Hash.String())
+	assert.Nilf(t, err, "GetTransactionStatus returned error: %s", err)
+	assert.Equal(t, StatusInsufficientBalance, status.Status)
+	assert.Equal(t, groupFailed, status.Group)
+}
+
+func TestNode_RegisterNamespace(t *testing.T) {
+	node, client := newTestClient(t)
+	defer node.Close()
+
+	tx, err := client.NewRegisterRootNamespaceTransaction(sdk.NewDeadline(time.Hour), "sdktest", sdk.Duration(100))
+	assert.Nilf(t, err, "NewRegisterRootNamespaceTransaction returned error: %s", err)
+
+	announce(t, client, node.Nemesis(), tx)
+	node.GenerateBlock()
+
+	nsId, err := sdk.NewNamespaceIdFromName("sdktest")
+	assert.Nilf(t, err, "NewNamespaceIdFromName returned error: %s", err)
+
+	info, err := client.Namespace.GetNamespaceInfo(context.Background(), nsId)
+	assert.Nilf(t, err, "GetNamespaceInfo returned error: %s", err)
+	assert.True(t, info.Active)
+	assert.Equal(t, sdk.Height(2), info.StartHeight)
+	assert.Equal(t, sdk.Height(102), info.EndHeight)
+	assert.Equal(t, strings.ToUpper(node.Nemesis().PublicAccount.PublicKey), strings.ToUpper(info.Owner.PublicKey))
+}
+
+func TestNode_Fail(t *testing.T) {
+	node, err := NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+	defer node.Close()
+
+	ctx := context.Background()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+	cfg.RetryPolicy = sdk.DefaultRetryPolicy()
+
+	client := sdk.NewClient(nil, cfg)
+
+	node.Fail(Failure{Method: http.MethodGet, Path: "/chain/height", StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+	_, err = client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+	assert.Equal(t, 2, node.Requests(http.MethodGet, "/chain/height"), "unavailable node should be retried")
+
+	node.Fail(Failure{Method: http.MethodGet, Path: "/chain/height", StatusCode: http.StatusConflict})
+
+	_, err = client.Blockchain.GetBlockchainHeight(ctx)
+	assert.NotNil(t, err)
+
+	node.ClearFailures()
+
+	_, err = client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nilf(t, err, "GetBlockchainHeight returned error: %s", err)
+}
+
+func TestNode_Fork(t *testing.T) {
+	node, client := newTestClient(t)
+	defer node.Close()
+
+	ctx := context.Background()
+
+	tx, err := client.NewTransferTransaction(sdk.NewDeadline(time.Hour), node.Nemesis().Address, []*sdk.Mosaic{sdk.Xpx(1)}, sdk.NewPlainMessage(""))
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx := announce(t, client, node.Nemesis(), tx)
+	node.GenerateBlock()
+
+	abandoned, err := client.Blockchain.GetBlockByHeight(ctx, 2)
+	assert.Nilf(t, err, "GetBlockByHeight returned error: %s", err)
+
+	node.Fork(1)
+	assert.Equal(t, sdk.Height(1), node.Height())
+
+	status, err := client.Transaction.GetTransactionStatus(ctx, stx.Hash.String())
+	assert.Nilf(t, err, "GetTransactionStatus returned error: %s", err)
+	assert.Equal(t, groupUnconfirmed, status.Group)
+
+	node.GenerateBlock()
+
+	block, err := client.Blockchain.GetBlockByHeight(ctx, 2)
+	assert.Nilf(t, err, "GetBlockByHeight returned error: %s", err)
+	assert.NotEqual(t, abandoned.BlockHash, block.BlockHash)
+	assert.Equal(t, abandoned.PreviousBlockHash, block.PreviousBlockHash)
+	assert.Equal(t, uint64(1), block.NumTransactions)
+}
+
+func TestNode_Websocket(t *testing.T) {
+	node, client := newTestClient(t)
+	defer node.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	wsc, err := websocket.NewClient(ctx, cfg)
+	assert.Nilf(t, err, "websocket.NewClient returned error: %s", err)
+	defer wsc.Close()
+
+	go wsc.Listen()
+
+	recipient, err := sdk.NewAccount(sdk.MijinTest, client.GenerationHash())
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	blocks := make(chan sdk.Height, 1)
+	_, err = wsc.AddBlockHandlers(func(info *sdk.BlockInfo) bool {
+		blocks <- info.Height
+		return true
+	})
+	assert.Nilf(t, err, "AddBlockHandlers returned error: %s", err)
+
+	confirmed := make(chan sdk.Transaction, 1)
+	_, err = wsc.AddConfirmedAddedHandlers(recipient.Address, func(tx sdk.Transaction) bool {
+		confirmed <- tx
+		return true
+	})
+	assert.Nilf(t, err, "AddConfirmedAddedHandlers returned error: %s", err)
+
+	waitSubscribed(t, node, channelBlock)
+	waitSubscribed(t, node, channelConfirmedAdded+"/"+rawAddress(recipient.Address))
+
+	tx, err := client.NewTransferTransaction(sdk.NewDeadline(time.Hour), recipient.Address, []*sdk.Mosaic{sdk.Xpx(1)}, sdk.NewPlainMessage(""))
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	announce(t, client, node.Nemesis(), tx)
+	node.GenerateBlock()
+
+	select {
+	case height := <-blocks:
+		assert.Equal(t, sdk.Height(2), height)
+	case <-ctx.Done():
+		t.Fatal("block wasn't received")
+	}
+
+	select {
+	case tx := <-confirmed:
+		assert.Equal(t, sdk.Transfer, tx.GetAbstractTransaction().Type)
+	case <-ctx.Done():
+		t.Fatal("confirmed transaction wasn't received")
+	}
+}
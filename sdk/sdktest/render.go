@@ -0,0 +1,322 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdktest
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// object is a JSON object of Catapult REST response
+type object map[string]interface{}
+
+var errUnsupportedTransaction = errors.New("transaction type is not supported by sdktest node")
+
+// returns uint64 in format of Catapult REST, which is an array of lower and higher 32 bits
+func uint64DTO(v uint64) []uint32 {
+	return []uint32{uint32(v), uint32(v >> 32)}
+}
+
+// returns blockchain timestamp of passed time in milliseconds since nemesis block
+func blockchainTimestamp(t time.Time) uint64 {
+	return uint64(t.UnixNano()/int64(time.Millisecond) - sdk.TimestampNemesisBlockMilliseconds)
+}
+
+// returns version of entity with network type in the highest byte
+func entityVersion(networkType sdk.NetworkType, version sdk.EntityVersion) int32 {
+	return int32(uint32(networkType)<<24 | uint32(version))
+}
+
+// returns JSON of confirmed or unconfirmed transaction with meta
+func renderTransaction(t *transaction) (object, error) {
+	body, err := renderTransactionBody(t.tx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := object{
+		"height":              uint64DTO(uint64(t.height)),
+		"hash":                t.hash,
+		"merkleComponentHash": t.hash,
+		"index":               t.index,
+		"id":                  t.id,
+	}
+
+	if agtx, ok := t.tx.(*sdk.AggregateTransaction); ok {
+		inner := make([]object, len(agtx.InnerTransactions))
+
+		for i, itx := range agtx.InnerTransactions {
+			ibody, err := renderTransactionBody(itx, true)
+			if err != nil {
+				return nil, err
+			}
+
+			inner[i] = object{
+				"meta": object{
+					"height":        uint64DTO(uint64(t.height)),
+					"aggregateHash": t.hash,
+					"aggregateId":   t.id,
+					"index":         i,
+					"id":            fmt.Sprintf("%s%04X", t.id[:20], i),
+				},
+				"transaction": ibody,
+			}
+		}
+
+		body["transactions"] = inner
+	}
+
+	return object{"meta": meta, "transaction": body}, nil
+}
+
+// returns transaction part of JSON of passed transaction, embedded transactions don't have signature, fee and deadline
+func renderTransactionBody(tx sdk.Transaction, embedded bool) (object, error) {
+	atx := tx.GetAbstractTransaction()
+
+	body := object{
+		"type":    uint16(atx.Type),
+		"version": entityVersion(atx.NetworkType, atx.Version),
+		"signer":  sdk.EmptyPublicKey,
+	}
+
+	if atx.Signer != nil {
+		body["signer"] = strings.ToUpper(atx.Signer.PublicKey)
+	}
+
+	if !embedded {
+		body["signature"] = atx.Signature
+		body["maxFee"] = uint64DTO(uint64(atx.MaxFee))
+
+		if atx.Deadline != nil {
+			body["deadline"] = uint64DTO(blockchainTimestamp(atx.Deadline.Time))
+		}
+	}
+
+	switch t := tx.(type) {
+	case *sdk.TransferTransaction:
+		mosaics := make([]object, len(t.Mosaics))
+		for i, m := range t.Mosaics {
+			mosaics[i] = object{"id": uint64DTO(m.AssetId.Id()), "amount": uint64DTO(uint64(m.Amount))}
+		}
+
+		message := object{"type": sdk.PlainMessageType, "payload": ""}
+		if t.Message != nil {
+			message = object{"type": t.Message.Type(), "payload": strings.ToUpper(hex.EncodeToString(t.Message.Payload()))}
+		}
+
+		body["recipient"] = rawAddress(t.Recipient)
+		body["mosaics"] = mosaics
+		body["message"] = message
+	case *sdk.RegisterNamespaceTransaction:
+		body["namespaceType"] = t.NamespaceType
+		body["namespaceId"] = uint64DTO(t.NamespaceId.Id())
+		body["name"] = t.NamspaceName
+
+		if t.NamespaceType == sdk.Root {
+			body["duration"] = uint64DTO(uint64(t.Duration))
+		} else {
+			body["parentId"] = uint64DTO(t.ParentId.Id())
+		}
+	case *sdk.MosaicDefinitionTransaction:
+		body["mosaicNonce"] = t.MosaicNonce
+		body["mosaicId"] = uint64DTO(t.MosaicId.Id())
+		body["properties"] = renderMosaicProperties(t.SupplyMutable, t.Transferable, t.Divisibility, uint64(t.MosaicProperties.Duration()))
+	case *sdk.MosaicSupplyChangeTransaction:
+		body["direction"] = t.MosaicSupplyType
+		body["mosaicId"] = uint64DTO(t.AssetId.Id())
+		body["delta"] = uint64DTO(uint64(t.Delta))
+	case *sdk.AddressAliasTransaction:
+		body["namespaceId"] = uint64DTO(t.NamespaceId.Id())
+		body["aliasAction"] = t.ActionType
+		body["address"] = rawAddress(t.Address)
+	case *sdk.MosaicAliasTransaction:
+		body["namespaceId"] = uint64DTO(t.NamespaceId.Id())
+		body["aliasAction"] = t.ActionType
+		body["mosaicId"] = uint64DTO(t.MosaicId.Id())
+	case *sdk.AggregateTransaction:
+		cosignatures := make([]object, len(t.Cosignatures))
+		for i, c := range t.Cosignatures {
+			cosignatures[i] = object{"signature": c.Signature, "signer": strings.ToUpper(c.Signer.PublicKey)}
+		}
+
+		for _, inner := range t.InnerTransactions {
+			if _, err := renderTransactionBody(inner, true); err != nil {
+				return nil, err
+			}
+		}
+
+		body["cosignatures"] = cosignatures
+		body["transactions"] = []object{}
+	default:
+		return nil, errUnsupportedTransaction
+	}
+
+	return body, nil
+}
+
+func renderMosaicProperties(supplyMutable, transferable bool, divisibility uint8, duration uint64) []object {
+	flags := uint64(0)
+	if supplyMutable {
+		flags |= sdk.Supply_Mutable
+	}
+	if transferable {
+		flags |= sdk.Transferable
+	}
+
+	return []object{
+		{"id": sdk.MosaicPropertyFlagsId, "value": uint64DTO(flags)},
+		{"id": sdk.MosaicPropertyDivisibilityId, "value": uint64DTO(uint64(divisibility))},
+		{"id": sdk.MosaicPropertyDurationId, "value": uint64DTO(duration)},
+	}
+}
+
+func renderStatus(t *transaction) object {
+	deadline := uint64(0)
+	if atx := t.tx.GetAbstractTransaction(); atx.Deadline != nil {
+		deadline = blockchainTimestamp(atx.Deadline.Time)
+	}
+
+	return object{
+		"group":    t.group,
+		"status":   t.status,
+		"hash":     t.hash,
+		"deadline": uint64DTO(deadline),
+		"height":   uint64DTO(uint64(t.height)),
+	}
+}
+
+func renderAccount(acc *account) object {
+	ids := make([]uint64, 0, len(acc.balances))
+	for id := range acc.balances {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	mosaics := make([]object, 0, len(ids))
+	for _, id := range ids {
+		mosaics = append(mosaics, object{"id": uint64DTO(id), "amount": uint64DTO(acc.balances[id])})
+	}
+
+	publicKey := acc.publicKey
+	if publicKey == "" {
+		publicKey = sdk.EmptyPublicKey
+	}
+
+	return object{
+		"meta": object{},
+		"account": object{
+			"address":          acc.address,
+			"addressHeight":    uint64DTO(uint64(acc.addressHeight)),
+			"publicKey":        publicKey,
+			"publicKeyHeight":  uint64DTO(uint64(acc.publicKeyHeight)),
+			"accountType":      sdk.UnlinkedAccount,
+			"linkedAccountKey": sdk.EmptyPublicKey,
+			"mosaics":          mosaics,
+		},
+	}
+}
+
+func renderMosaic(m *mosaic) object {
+	return object{
+		"mosaic": object{
+			"mosaicId":   uint64DTO(m.id),
+			"supply":     uint64DTO(m.supply),
+			"height":     uint64DTO(uint64(m.height)),
+			"owner":      m.owner,
+			"revision":   m.revision,
+			"properties": renderMosaicProperties(m.supplyMutable, m.transferable, m.divisibility, m.duration),
+		},
+	}
+}
+
+func renderNamespace(ns *namespace, networkType sdk.NetworkType, height sdk.Height) object {
+	dto := object{
+		"type":        sdk.Root,
+		"depth":       len(ns.levels),
+		"parentId":    uint64DTO(ns.parent),
+		"owner":       ns.owner,
+		"startHeight": uint64DTO(uint64(ns.startHeight)),
+		"endHeight":   uint64DTO(uint64(ns.endHeight)),
+		"alias":       object{"type": ns.aliasType},
+	}
+
+	if ns.parent != 0 {
+		dto["type"] = sdk.Sub
+	}
+
+	for i, level := range ns.levels {
+		dto[fmt.Sprintf("level%d", i)] = uint64DTO(level)
+	}
+
+	if owner, err := sdk.NewAccountFromPublicKey(ns.owner, networkType); err == nil {
+		dto["ownerAddress"] = rawAddress(owner.Address)
+	}
+
+	switch ns.aliasType {
+	case sdk.MosaicAliasType:
+		dto["alias"] = object{"type": ns.aliasType, "mosaicId": uint64DTO(ns.aliasMosaic)}
+	case sdk.AddressAliasType:
+		dto["alias"] = object{"type": ns.aliasType, "address": ns.aliasAddress}
+	}
+
+	return object{
+		"meta": object{
+			"active": height >= ns.startHeight && height < ns.endHeight,
+			"index":  0,
+			"id":     fmt.Sprintf("%024X", ns.id),
+		},
+		"namespace": dto,
+	}
+}
+
+func renderBlock(b *block, networkType sdk.NetworkType, generationHash string, signer string) object {
+	totalFee := uint64(0)
+	for _, t := range b.transactions {
+		totalFee += uint64(t.tx.GetAbstractTransaction().MaxFee)
+	}
+
+	blockType := sdk.Block
+	if b.height == 1 {
+		blockType = sdk.NemesisBlock
+	}
+
+	return object{
+		"meta": object{
+			"hash":                b.hash,
+			"generationHash":      generationHash,
+			"totalFee":            uint64DTO(totalFee),
+			"subCacheMerkleRoots": []string{},
+			"numTransactions":     len(b.transactions),
+		},
+		"block": object{
+			"signature":              strings.Repeat("0", 128),
+			"signer":                 signer,
+			"version":                entityVersion(networkType, 3),
+			"type":                   uint16(blockType),
+			"height":                 uint64DTO(uint64(b.height)),
+			"timestamp":              uint64DTO(b.timestamp),
+			"difficulty":             uint64DTO(100000000000000),
+			"feeMultiplier":          0,
+			"previousBlockHash":      b.previousHash,
+			"blockTransactionsHash":  sdk.EmptyPublicKey,
+			"blockReceiptsHash":      sdk.EmptyPublicKey,
+			"stateHash":              sdk.EmptyPublicKey,
+			"beneficiary":            sdk.EmptyPublicKey,
+			"feeInterest":            1,
+			"feeInterestDenominator": 1,
+		},
+	}
+}
+
+// returns JSON error in format of Catapult REST
+func renderError(code, message string) object {
+	return object{"code": code, "message": message}
+}
@@ -0,0 +1,828 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdktest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// groups of transaction statuses
+const (
+	groupUnconfirmed = "unconfirmed"
+	groupConfirmed   = "confirmed"
+	groupPartial     = "partial"
+	groupFailed      = "failed"
+)
+
+// names of networks for /network route and identifiers of networks in network config
+var networkNames = map[sdk.NetworkType][2]string{
+	sdk.Mijin:       {"mijin", "mijin"},
+	sdk.MijinTest:   {"mijinTest", "mijin-test"},
+	sdk.Public:      {"public", "public"},
+	sdk.PublicTest:  {"publicTest", "public-test"},
+	sdk.Private:     {"private", "private"},
+	sdk.PrivateTest: {"privateTest", "private-test"},
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params []string)
+
+// route matches method and path, segment "{}" of pattern matches any segment of path and is passed to handler
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+func (r *route) match(method string, segments []string) ([]string, bool) {
+	if r.method != method || len(r.segments) != len(segments) {
+		return nil, false
+	}
+
+	params := make([]string, 0)
+	for i, s := range r.segments {
+		switch {
+		case s == "{}":
+			params = append(params, segments[i])
+		case s != segments[i]:
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// returns routes of Catapult REST which are served by Node
+func (n *Node) restRoutes() []*route {
+	routes := make([]*route, 0)
+	handle := func(method, pattern string, handler handlerFunc) {
+		routes = append(routes, &route{method, splitPath(pattern), handler})
+	}
+
+	// chain, blocks and network
+	handle(http.MethodGet, "/chain/height", n.getChainHeight)
+	handle(http.MethodGet, "/chain/score", n.getChainScore)
+	handle(http.MethodGet, "/diagnostic/storage", n.getStorageInfo)
+	handle(http.MethodGet, "/block/{}", n.getBlock)
+	handle(http.MethodGet, "/block/{}/transactions", n.getBlockTransactions)
+	handle(http.MethodGet, "/blocks/{}/limit/{}", n.getBlocks)
+	handle(http.MethodGet, "/network", n.getNetwork)
+	handle(http.MethodGet, "/config/{}", n.getConfig)
+	handle(http.MethodGet, "/upgrade/{}", notFound)
+
+	// accounts
+	handle(http.MethodPost, "/account", n.getAccounts)
+	handle(http.MethodGet, "/account/{}", n.getAccount)
+	handle(http.MethodPost, "/account/names", n.getAccountNames)
+	handle(http.MethodPost, "/account/properties", emptyList)
+	handle(http.MethodGet, "/account/{}/properties", notFound)
+	handle(http.MethodGet, "/account/{}/multisig", notFound)
+	handle(http.MethodGet, "/account/{}/multisig/graph", notFound)
+	handle(http.MethodGet, "/account/{}/transactions", n.accountTransactions(groupConfirmed, true, true))
+	handle(http.MethodGet, "/account/{}/transactions/incoming", n.accountTransactions(groupConfirmed, true, false))
+	handle(http.MethodGet, "/account/{}/transactions/outgoing", n.accountTransactions(groupConfirmed, false, true))
+	handle(http.MethodGet, "/account/{}/transactions/unconfirmed", n.accountTransactions(groupUnconfirmed, true, true))
+	handle(http.MethodGet, "/account/{}/transactions/partial", n.accountTransactions(groupPartial, true, true))
+
+	// namespaces and mosaics
+	handle(http.MethodGet, "/namespace/{}", n.getNamespace)
+	handle(http.MethodPost, "/namespace/names", n.getNamespaceNames)
+	handle(http.MethodGet, "/account/{}/namespaces", n.getAccountNamespaces)
+	handle(http.MethodPost, "/account/namespaces", n.getAccountsNamespaces)
+	handle(http.MethodGet, "/mosaic/{}", n.getMosaic)
+	handle(http.MethodPost, "/mosaic", n.getMosaics)
+	handle(http.MethodPost, "/mosaic/names", n.getMosaicNames)
+
+	// transactions
+	handle(http.MethodGet, "/transaction/{}", n.getTransaction)
+	handle(http.MethodPost, "/transaction", n.getTransactions)
+	handle(http.MethodGet, "/transaction/{}/status", n.getTransactionStatus)
+	handle(http.MethodPost, "/transaction/statuses", n.getTransactionStatuses)
+	handle(http.MethodPut, "/transaction", n.announceTransaction(groupUnconfirmed))
+	handle(http.MethodPut, "/transaction/partial", n.announceTransaction(groupPartial))
+	handle(http.MethodPut, "/transaction/cosignature", n.announceCosignature)
+
+	// entities which are not modelled by Node are never found
+	handle(http.MethodGet, "/account/{}/lock/hash", emptyList)
+	handle(http.MethodGet, "/account/{}/lock/secret", emptyList)
+	handle(http.MethodGet, "/lock/hash/{}", notFound)
+	handle(http.MethodGet, "/lock/compositeHash/{}", notFound)
+	handle(http.MethodGet, "/lock/secret/{}", emptyList)
+	handle(http.MethodPost, "/contract", emptyList)
+	handle(http.MethodGet, "/account/{}/contracts", emptyList)
+	handle(http.MethodPost, "/metadata", emptyList)
+	handle(http.MethodGet, "/metadata/{}", notFound)
+	handle(http.MethodGet, "/account/{}/metadata", notFound)
+	handle(http.MethodGet, "/mosaic/{}/metadata", notFound)
+	handle(http.MethodGet, "/namespace/{}/metadata", notFound)
+	handle(http.MethodGet, "/drive/{}", notFound)
+	handle(http.MethodGet, "/account/{}/drive", emptyList)
+	handle(http.MethodGet, "/account/{}/drive/{}", emptyList)
+	handle(http.MethodGet, "/downloads/{}", notFound)
+	handle(http.MethodGet, "/drive/{}/downloads", emptyList)
+	handle(http.MethodGet, "/account/{}/downloads", emptyList)
+	handle(http.MethodGet, "/drive/{}/supercontracts", emptyList)
+	handle(http.MethodGet, "/supercontract/{}", notFound)
+	handle(http.MethodGet, "/account/{}/operations", emptyList)
+	handle(http.MethodGet, "/operation/{}", notFound)
+	handle(http.MethodGet, "/account/{}/exchange", notFound)
+	handle(http.MethodGet, "/exchange/{}/{}", emptyList)
+
+	return routes
+}
+
+func (n *Node) serve(w http.ResponseWriter, r *http.Request) {
+	latency, failure := n.intercept(r)
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	if failure != nil {
+		failure.write(w)
+		return
+	}
+
+	if r.URL.Path == "/ws" {
+		n.hub.serve(w, r)
+		return
+	}
+
+	segments := splitPath(r.URL.Path)
+	for _, route := range n.routes {
+		if params, ok := route.match(r.Method, segments); ok {
+			route.handler(w, r, params)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("%s does not exist", r.URL.Path))
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJson(w, status, renderError(code, message))
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("no resource exists with id '%s'", id))
+}
+
+func writeInvalidArgument(w http.ResponseWriter, name string) {
+	writeError(w, http.StatusConflict, "InvalidArgument", fmt.Sprintf("%s has an invalid format", name))
+}
+
+// decodes JSON body of request, invalid content error is written if body is invalid
+func readJson(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidContent", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func notFound(w http.ResponseWriter, r *http.Request, params []string) {
+	writeNotFound(w, strings.Join(params, "/"))
+}
+
+func emptyList(w http.ResponseWriter, r *http.Request, params []string) {
+	writeJson(w, http.StatusOK, []object{})
+}
+
+func parseHeight(s string) (sdk.Height, bool) {
+	h, err := strconv.ParseUint(s, 10, 63)
+	if err != nil {
+		return 0, false
+	}
+
+	return sdk.Height(h), true
+}
+
+func parseId(s string) (uint64, bool) {
+	id, err := strconv.ParseUint(s, 16, 64)
+
+	return id, err == nil
+}
+
+func (n *Node) getChainHeight(w http.ResponseWriter, r *http.Request, params []string) {
+	writeJson(w, http.StatusOK, object{"height": uint64DTO(uint64(n.Height()))})
+}
+
+func (n *Node) getChainScore(w http.ResponseWriter, r *http.Request, params []string) {
+	writeJson(w, http.StatusOK, object{"scoreHigh": uint64DTO(0), "scoreLow": uint64DTO(uint64(n.Height()))})
+}
+
+func (n *Node) getStorageInfo(w http.ResponseWriter, r *http.Request, params []string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	numTransactions := 0
+	for _, b := range n.blocks {
+		numTransactions += len(b.transactions)
+	}
+
+	writeJson(w, http.StatusOK, object{
+		"numBlocks":       len(n.blocks),
+		"numTransactions": numTransactions,
+		"numAccounts":     len(n.state.accounts),
+	})
+}
+
+// returns block at passed height, nil is returned if block doesn't exist
+func (n *Node) block(height sdk.Height) *block {
+	if height == 0 || int(height) > len(n.blocks) {
+		return nil
+	}
+
+	return n.blocks[height-1]
+}
+
+func (n *Node) getBlock(w http.ResponseWriter, r *http.Request, params []string) {
+	height, ok := parseHeight(params[0])
+	if !ok {
+		writeInvalidArgument(w, "height")
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	b := n.block(height)
+	if b == nil {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	writeJson(w, http.StatusOK, n.renderBlock(b))
+}
+
+func (n *Node) getBlockTransactions(w http.ResponseWriter, r *http.Request, params []string) {
+	height, ok := parseHeight(params[0])
+	if !ok {
+		writeInvalidArgument(w, "height")
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	b := n.block(height)
+	if b == nil {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	// transactions of block are paged as transactions of account
+	txs := append([]*transaction(nil), b.transactions...)
+	writeJson(w, http.StatusOK, renderTransactions(paginate(txs, r)))
+}
+
+func (n *Node) getBlocks(w http.ResponseWriter, r *http.Request, params []string) {
+	height, ok := parseHeight(params[0])
+	if !ok {
+		writeInvalidArgument(w, "height")
+		return
+	}
+
+	limit, err := strconv.Atoi(params[1])
+	if err != nil || limit <= 0 {
+		writeInvalidArgument(w, "limit")
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	blocks := make([]object, 0, limit)
+	for h := height; len(blocks) < limit; h++ {
+		b := n.block(h)
+		if b == nil {
+			break
+		}
+
+		blocks = append(blocks, n.renderBlock(b))
+	}
+
+	writeJson(w, http.StatusOK, blocks)
+}
+
+func (n *Node) renderBlock(b *block) object {
+	return renderBlock(b, n.config.NetworkType, n.config.GenerationHash.String(), n.nemesis.PublicAccount.PublicKey)
+}
+
+func (n *Node) getNetwork(w http.ResponseWriter, r *http.Request, params []string) {
+	writeJson(w, http.StatusOK, object{
+		"name":        networkNames[n.config.NetworkType][0],
+		"description": "sdktest in-memory node",
+	})
+}
+
+func (n *Node) getConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := parseHeight(params[0]); !ok {
+		writeInvalidArgument(w, "height")
+		return
+	}
+
+	config := fmt.Sprintf(
+		"[network]\n\nidentifier = %s\npublicKey = %s\ngenerationHash = %s\n\n",
+		networkNames[n.config.NetworkType][1],
+		n.nemesis.PublicAccount.PublicKey,
+		n.config.GenerationHash,
+	)
+
+	writeJson(w, http.StatusOK, object{
+		"networkConfig": object{
+			"height":                  uint64DTO(1),
+			"networkConfig":           config,
+			"supportedEntityVersions": `{"entities": []}`,
+		},
+	})
+}
+
+type addressesDTO struct {
+	Addresses []string `json:"addresses"`
+}
+
+func (n *Node) getAccount(w http.ResponseWriter, r *http.Request, params []string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	acc, ok := n.state.findAccount(params[0], n.config.NetworkType)
+	if !ok {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	writeJson(w, http.StatusOK, renderAccount(acc))
+}
+
+func (n *Node) getAccounts(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := addressesDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	accounts := make([]object, 0, len(dto.Addresses))
+	for _, address := range dto.Addresses {
+		if acc, ok := n.state.findAccount(address, n.config.NetworkType); ok {
+			accounts = append(accounts, renderAccount(acc))
+		}
+	}
+
+	writeJson(w, http.StatusOK, accounts)
+}
+
+func (n *Node) getAccountNames(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := addressesDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	names := make([]object, 0, len(dto.Addresses))
+	for _, address := range dto.Addresses {
+		raw := rawAddress(&sdk.Address{Address: address})
+		names = append(names, object{
+			"address": raw,
+			"names":   n.state.aliasNames(sdk.AddressAliasType, 0, raw),
+		})
+	}
+
+	writeJson(w, http.StatusOK, names)
+}
+
+// returns handler of transactions of account from passed group,
+// incoming transactions notify account and outgoing transactions are signed by account
+func (n *Node) accountTransactions(group string, incoming, outgoing bool) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		pa, err := sdk.NewAccountFromPublicKey(params[0], n.config.NetworkType)
+		if err != nil {
+			writeInvalidArgument(w, "publicKey")
+			return
+		}
+
+		address := rawAddress(pa.Address)
+
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+
+		txs := make([]*transaction, 0)
+		for _, t := range n.transactions {
+			if t.group != group {
+				continue
+			}
+
+			signer := t.tx.GetAbstractTransaction().Signer
+			signed := signer != nil && rawAddress(signer.Address) == address
+
+			if (outgoing && signed) || (incoming && !signed && contains(t.addresses, address)) {
+				txs = append(txs, t)
+			}
+		}
+
+		writeJson(w, http.StatusOK, renderTransactions(paginate(txs, r)))
+	}
+}
+
+// returns page of transactions by page size, id and ordering of query
+func paginate(txs []*transaction, r *http.Request) []*transaction {
+	query := r.URL.Query()
+	ascending := query.Get("ordering") == "id"
+
+	sort.Slice(txs, func(i, j int) bool {
+		if ascending {
+			return txs[i].id < txs[j].id
+		}
+
+		return txs[i].id > txs[j].id
+	})
+
+	if id := strings.ToUpper(query.Get("id")); id != "" {
+		from := len(txs)
+		for i, t := range txs {
+			if (ascending && t.id > id) || (!ascending && t.id < id) {
+				from = i
+				break
+			}
+		}
+
+		txs = txs[from:]
+	}
+
+	pageSize, err := strconv.Atoi(query.Get("pageSize"))
+	if err != nil || pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	if len(txs) > pageSize {
+		txs = txs[:pageSize]
+	}
+
+	return txs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func renderTransactions(txs []*transaction) []object {
+	objects := make([]object, 0, len(txs))
+
+	for _, t := range txs {
+		if o, err := renderTransaction(t); err == nil {
+			objects = append(objects, o)
+		}
+	}
+
+	return objects
+}
+
+type namespaceIdsDTO struct {
+	NamespaceIds []string `json:"namespaceIds"`
+}
+
+type mosaicIdsDTO struct {
+	MosaicIds []string `json:"mosaicIds"`
+}
+
+func (n *Node) getNamespace(w http.ResponseWriter, r *http.Request, params []string) {
+	id, ok := parseId(params[0])
+	if !ok {
+		writeInvalidArgument(w, "namespaceId")
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	ns, ok := n.state.namespaces[id]
+	if !ok {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	writeJson(w, http.StatusOK, renderNamespace(ns, n.config.NetworkType, n.height()))
+}
+
+func (n *Node) getNamespaceNames(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := namespaceIdsDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	names := make([]object, 0, len(dto.NamespaceIds))
+	for _, s := range dto.NamespaceIds {
+		id, ok := parseId(s)
+		if !ok {
+			writeInvalidArgument(w, "namespaceId")
+			return
+		}
+
+		if ns, ok := n.state.namespaces[id]; ok {
+			names = append(names, object{"namespaceId": uint64DTO(ns.id), "name": ns.name, "parentId": uint64DTO(ns.parent)})
+		}
+	}
+
+	writeJson(w, http.StatusOK, names)
+}
+
+func (n *Node) renderOwnedNamespaces(addresses ...string) []object {
+	namespaces := make([]object, 0)
+
+	for _, address := range addresses {
+		acc, ok := n.state.findAccount(address, n.config.NetworkType)
+		if !ok || acc.publicKey == "" {
+			continue
+		}
+
+		for _, ns := range n.state.ownedNamespaces(acc.publicKey) {
+			namespaces = append(namespaces, renderNamespace(ns, n.config.NetworkType, n.height()))
+		}
+	}
+
+	return namespaces
+}
+
+func (n *Node) getAccountNamespaces(w http.ResponseWriter, r *http.Request, params []string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	writeJson(w, http.StatusOK, n.renderOwnedNamespaces(params[0]))
+}
+
+func (n *Node) getAccountsNamespaces(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := addressesDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	writeJson(w, http.StatusOK, n.renderOwnedNamespaces(dto.Addresses...))
+}
+
+func (n *Node) getMosaic(w http.ResponseWriter, r *http.Request, params []string) {
+	id, ok := parseId(params[0])
+	if !ok {
+		writeInvalidArgument(w, "mosaicId")
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	m, ok := n.state.mosaics[id]
+	if !ok {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	writeJson(w, http.StatusOK, renderMosaic(m))
+}
+
+func (n *Node) getMosaics(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := mosaicIdsDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	mosaics := make([]object, 0, len(dto.MosaicIds))
+	for _, s := range dto.MosaicIds {
+		id, ok := parseId(s)
+		if !ok {
+			writeInvalidArgument(w, "mosaicId")
+			return
+		}
+
+		if m, ok := n.state.mosaics[id]; ok {
+			mosaics = append(mosaics, renderMosaic(m))
+		}
+	}
+
+	writeJson(w, http.StatusOK, mosaics)
+}
+
+func (n *Node) getMosaicNames(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := mosaicIdsDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	names := make([]object, 0, len(dto.MosaicIds))
+	for _, s := range dto.MosaicIds {
+		id, ok := parseId(s)
+		if !ok {
+			writeInvalidArgument(w, "mosaicId")
+			return
+		}
+
+		names = append(names, object{"mosaicId": uint64DTO(id), "names": n.state.aliasNames(sdk.MosaicAliasType, id, "")})
+	}
+
+	writeJson(w, http.StatusOK, names)
+}
+
+// returns confirmed transaction by hash or object id
+func (n *Node) confirmedTransaction(id string) (*transaction, bool) {
+	id = strings.ToUpper(id)
+
+	if t, ok := n.transactions[id]; ok && t.group == groupConfirmed {
+		return t, true
+	}
+
+	for _, t := range n.transactions {
+		if t.id == id && t.group == groupConfirmed {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+func (n *Node) getTransaction(w http.ResponseWriter, r *http.Request, params []string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	t, ok := n.confirmedTransaction(params[0])
+	if !ok {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	o, err := renderTransaction(t)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Internal", err.Error())
+		return
+	}
+
+	writeJson(w, http.StatusOK, o)
+}
+
+func (n *Node) getTransactions(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := sdk.TransactionIdsDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	txs := make([]*transaction, 0, len(dto.Ids))
+	for _, id := range dto.Ids {
+		if t, ok := n.confirmedTransaction(id); ok {
+			txs = append(txs, t)
+		}
+	}
+
+	writeJson(w, http.StatusOK, renderTransactions(txs))
+}
+
+func (n *Node) getTransactionStatus(w http.ResponseWriter, r *http.Request, params []string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	t, ok := n.transactions[strings.ToUpper(params[0])]
+	if !ok {
+		writeNotFound(w, params[0])
+		return
+	}
+
+	writeJson(w, http.StatusOK, renderStatus(t))
+}
+
+func (n *Node) getTransactionStatuses(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := sdk.TransactionHashesDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	statuses := make([]object, 0, len(dto.Hashes))
+	for _, hash := range dto.Hashes {
+		if t, ok := n.transactions[strings.ToUpper(hash)]; ok {
+			statuses = append(statuses, renderStatus(t))
+		}
+	}
+
+	writeJson(w, http.StatusOK, statuses)
+}
+
+type signedTransactionDTO struct {
+	Payload string `json:"payload"`
+	Hash    string `json:"hash"`
+}
+
+type cosignatureDTO struct {
+	ParentHash string `json:"parentHash"`
+	Signature  string `json:"signature"`
+	Signer     string `json:"signer"`
+}
+
+// returns handler which parses announced payload and adds transaction to passed group
+func (n *Node) announceTransaction(group string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		dto := signedTransactionDTO{}
+		if !readJson(w, r, &dto) {
+			return
+		}
+
+		payload, err := hex.DecodeString(dto.Payload)
+		if err != nil {
+			writeInvalidArgument(w, "payload")
+			return
+		}
+
+		tx, err := sdk.ParseTransactionPayload(payload)
+		if err != nil {
+			writeInvalidArgument(w, "payload")
+			return
+		}
+
+		if _, err := sdk.StringToHash(dto.Hash); err != nil {
+			writeInvalidArgument(w, "hash")
+			return
+		}
+
+		n.announce(tx, strings.ToUpper(dto.Hash), group)
+
+		writeJson(w, http.StatusAccepted, object{"message": fmt.Sprintf("packet 9 was pushed to the network via %s", r.URL.Path)})
+	}
+}
+
+func (n *Node) announceCosignature(w http.ResponseWriter, r *http.Request, params []string) {
+	dto := cosignatureDTO{}
+	if !readJson(w, r, &dto) {
+		return
+	}
+
+	signer, err := sdk.NewAccountFromPublicKey(dto.Signer, n.config.NetworkType)
+	if err != nil {
+		writeInvalidArgument(w, "signer")
+		return
+	}
+
+	n.mutex.Lock()
+
+	var messages []*message
+	if t, ok := n.transactions[strings.ToUpper(dto.ParentHash)]; ok && t.group == groupPartial {
+		agtx := t.tx.(*sdk.AggregateTransaction)
+		agtx.Cosignatures = append(agtx.Cosignatures, &sdk.AggregateTransactionCosignature{
+			Signature: strings.ToUpper(dto.Signature),
+			Signer:    signer,
+		})
+
+		messages = n.cosignatureMessages(t, &dto)
+	}
+
+	n.mutex.Unlock()
+
+	n.hub.publish(messages...)
+
+	writeJson(w, http.StatusAccepted, object{"message": fmt.Sprintf("packet 9 was pushed to the network via %s", r.URL.Path)})
+}
@@ -0,0 +1,543 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdktest
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// statuses of transactions which are reported by Node
+const (
+	StatusSuccess                     = "Success"
+	StatusPastDeadline                = "Failure_Core_Past_Deadline"
+	StatusInsufficientBalance         = "Failure_Core_Insufficient_Balance"
+	StatusNamespaceOwnerConflict      = "Failure_Namespace_Owner_Conflict"
+	StatusNamespaceAlreadyExists      = "Failure_Namespace_Already_Exists"
+	StatusNamespaceParentUnknown      = "Failure_Namespace_Parent_Unknown"
+	StatusNamespaceTooDeep            = "Failure_Namespace_Too_Deep"
+	StatusNamespaceAliasUnknown       = "Failure_Namespace_Alias_Namespace_Unknown"
+	StatusNamespaceAliasExists        = "Failure_Namespace_Alias_Already_Exists"
+	StatusNamespaceAliasDoesNotExist  = "Failure_Namespace_Alias_Does_Not_Exist"
+	StatusNamespaceAliasOwnerConflict = "Failure_Namespace_Alias_Owner_Conflict"
+	StatusNamespaceAliasInconsistency = "Failure_Namespace_Alias_Unlink_Data_Inconsistency"
+	StatusMosaicExpired               = "Failure_Mosaic_Expired"
+	StatusMosaicOwnerConflict         = "Failure_Mosaic_Owner_Conflict"
+	StatusMosaicSupplyImmutable       = "Failure_Mosaic_Supply_Immutable"
+	StatusMosaicSupplyNegative        = "Failure_Mosaic_Supply_Negative"
+	// transaction type isn't modelled by Node
+	StatusUnsupported = "Failure_Sdktest_Unsupported_Transaction"
+)
+
+const (
+	maxNamespaceDepth = 3
+	eternalHeight     = sdk.Height(math.MaxInt64)
+	aliasAddressByte  = 0x91
+)
+
+type account struct {
+	address         string
+	addressHeight   sdk.Height
+	publicKey       string
+	publicKeyHeight sdk.Height
+	balances        map[uint64]uint64
+}
+
+type namespace struct {
+	id          uint64
+	name        string
+	parent      uint64
+	levels      []uint64
+	owner       string
+	startHeight sdk.Height
+	endHeight   sdk.Height
+	aliasType   sdk.AliasType
+	aliasMosaic uint64
+	// raw address in hex
+	aliasAddress string
+}
+
+type mosaic struct {
+	id            uint64
+	supply        uint64
+	height        sdk.Height
+	owner         string
+	revision      uint32
+	supplyMutable bool
+	transferable  bool
+	divisibility  uint8
+	duration      uint64
+}
+
+// state is a simple model of chain state which is changed by transfers, namespaces and mosaics
+type state struct {
+	accounts   map[string]*account
+	namespaces map[uint64]*namespace
+	mosaics    map[uint64]*mosaic
+}
+
+func newState() *state {
+	return &state{
+		accounts:   make(map[string]*account),
+		namespaces: make(map[uint64]*namespace),
+		mosaics:    make(map[uint64]*mosaic),
+	}
+}
+
+// returns deep copy of state, transaction is applied to copy and copy replaces state only if transaction succeeds
+func (s *state) clone() *state {
+	c := newState()
+
+	for k, v := range s.accounts {
+		acc := *v
+		acc.balances = make(map[uint64]uint64, len(v.balances))
+		for id, amount := range v.balances {
+			acc.balances[id] = amount
+		}
+		c.accounts[k] = &acc
+	}
+
+	for k, v := range s.namespaces {
+		ns := *v
+		ns.levels = append([]uint64(nil), v.levels...)
+		c.namespaces[k] = &ns
+	}
+
+	for k, v := range s.mosaics {
+		m := *v
+		c.mosaics[k] = &m
+	}
+
+	return c
+}
+
+// returns account of raw address, account is created at passed height if it is unknown
+func (s *state) account(address string, height sdk.Height) *account {
+	acc, ok := s.accounts[address]
+	if !ok {
+		acc = &account{address: address, addressHeight: height, balances: make(map[uint64]uint64)}
+		s.accounts[address] = acc
+	}
+
+	return acc
+}
+
+// returns account of signer, public key of account becomes known at passed height
+func (s *state) signer(signer *sdk.PublicAccount, height sdk.Height) *account {
+	acc := s.account(rawAddress(signer.Address), height)
+
+	if acc.publicKey == "" {
+		acc.publicKey = strings.ToUpper(signer.PublicKey)
+		acc.publicKeyHeight = height
+	}
+
+	return acc
+}
+
+// returns account which is found by raw address, base32 address or public key
+func (s *state) findAccount(id string, networkType sdk.NetworkType) (*account, bool) {
+	id = strings.ToUpper(id)
+
+	if acc, ok := s.accounts[id]; ok {
+		return acc, true
+	}
+
+	if len(id) == 64 {
+		for _, acc := range s.accounts {
+			if acc.publicKey == id {
+				return acc, true
+			}
+		}
+
+		pa, err := sdk.NewAccountFromPublicKey(id, networkType)
+		if err != nil {
+			return nil, false
+		}
+
+		id = pa.Address.Address
+	}
+
+	acc, ok := s.accounts[rawAddress(&sdk.Address{Address: id})]
+
+	return acc, ok
+}
+
+// returns raw address of recipient, address is resolved if it is alias of namespace
+func (s *state) resolveAddress(address *sdk.Address) (string, bool) {
+	raw := rawAddress(address)
+
+	b, err := hex.DecodeString(raw)
+	if err != nil || len(b) == 0 {
+		return "", false
+	}
+
+	if b[0] != aliasAddressByte {
+		return raw, true
+	}
+
+	if len(b) < 9 {
+		return "", false
+	}
+
+	ns, ok := s.namespaces[binary.LittleEndian.Uint64(b[1:9])]
+	if !ok || ns.aliasType != sdk.AddressAliasType {
+		return "", false
+	}
+
+	return ns.aliasAddress, true
+}
+
+// returns id of mosaic, id is resolved if it is alias of namespace
+func (s *state) resolveMosaic(assetId sdk.AssetId) (uint64, bool) {
+	if assetId == nil {
+		return 0, false
+	}
+
+	if assetId.Type() == sdk.MosaicAssetIdType {
+		_, ok := s.mosaics[assetId.Id()]
+		return assetId.Id(), ok
+	}
+
+	ns, ok := s.namespaces[assetId.Id()]
+	if !ok || ns.aliasType != sdk.MosaicAliasType {
+		return 0, false
+	}
+
+	return ns.aliasMosaic, true
+}
+
+// returns full names of namespaces which are aliases of passed mosaic or raw address
+func (s *state) aliasNames(aliasType sdk.AliasType, mosaicId uint64, address string) []string {
+	names := make([]string, 0)
+
+	for _, ns := range s.namespaces {
+		if ns.aliasType != aliasType {
+			continue
+		}
+
+		if (aliasType == sdk.MosaicAliasType && ns.aliasMosaic == mosaicId) ||
+			(aliasType == sdk.AddressAliasType && ns.aliasAddress == address) {
+			names = append(names, ns.name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// returns namespaces of owner sorted by name
+func (s *state) ownedNamespaces(owner string) []*namespace {
+	namespaces := make([]*namespace, 0)
+
+	for _, ns := range s.namespaces {
+		if ns.owner == owner {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].name < namespaces[j].name
+	})
+
+	return namespaces
+}
+
+// changes state by passed transaction and returns status of transaction
+func (s *state) apply(tx sdk.Transaction, height sdk.Height) string {
+	switch t := tx.(type) {
+	case *sdk.TransferTransaction:
+		return s.applyTransfer(t, height)
+	case *sdk.RegisterNamespaceTransaction:
+		return s.applyRegisterNamespace(t, height)
+	case *sdk.MosaicDefinitionTransaction:
+		return s.applyMosaicDefinition(t, height)
+	case *sdk.MosaicSupplyChangeTransaction:
+		return s.applyMosaicSupplyChange(t, height)
+	case *sdk.AddressAliasTransaction:
+		return s.applyAlias(&t.AliasTransaction, height, sdk.AddressAliasType, 0, rawAddress(t.Address))
+	case *sdk.MosaicAliasTransaction:
+		return s.applyAlias(&t.AliasTransaction, height, sdk.MosaicAliasType, t.MosaicId.Id(), "")
+	case *sdk.AggregateTransaction:
+		if t.Type != sdk.AggregateCompleted {
+			return StatusUnsupported
+		}
+
+		for _, inner := range t.InnerTransactions {
+			if status := s.apply(inner, height); status != StatusSuccess {
+				return status
+			}
+		}
+
+		return StatusSuccess
+	default:
+		return StatusUnsupported
+	}
+}
+
+func (s *state) applyTransfer(tx *sdk.TransferTransaction, height sdk.Height) string {
+	sender := s.signer(tx.Signer, height)
+
+	address, ok := s.resolveAddress(tx.Recipient)
+	if !ok {
+		return StatusNamespaceAliasDoesNotExist
+	}
+
+	recipient := s.account(address, height)
+
+	for _, m := range tx.Mosaics {
+		id, ok := s.resolveMosaic(m.AssetId)
+		if !ok {
+			return StatusInsufficientBalance
+		}
+
+		amount := uint64(m.Amount)
+		if sender.balances[id] < amount {
+			return StatusInsufficientBalance
+		}
+
+		sender.balances[id] -= amount
+		recipient.balances[id] += amount
+	}
+
+	return StatusSuccess
+}
+
+func (s *state) applyRegisterNamespace(tx *sdk.RegisterNamespaceTransaction, height sdk.Height) string {
+	owner := s.signer(tx.Signer, height).publicKey
+	id := tx.NamespaceId.Id()
+
+	if tx.NamespaceType == sdk.Root {
+		endHeight := eternalHeight
+		if tx.Duration != 0 {
+			endHeight = height + sdk.Height(tx.Duration)
+		}
+
+		if ns, ok := s.namespaces[id]; ok {
+			if ns.owner != owner {
+				return StatusNamespaceOwnerConflict
+			}
+
+			// renewal of root namespace extends all its children
+			for _, child := range s.namespaces {
+				if child.levels[0] == id {
+					child.endHeight = endHeight
+				}
+			}
+
+			return StatusSuccess
+		}
+
+		s.namespaces[id] = &namespace{
+			id:          id,
+			name:        tx.NamspaceName,
+			levels:      []uint64{id},
+			owner:       owner,
+			startHeight: height,
+			endHeight:   endHeight,
+		}
+
+		return StatusSuccess
+	}
+
+	parent, ok := s.namespaces[tx.ParentId.Id()]
+	if !ok {
+		return StatusNamespaceParentUnknown
+	}
+
+	if parent.owner != owner {
+		return StatusNamespaceOwnerConflict
+	}
+
+	if len(parent.levels) >= maxNamespaceDepth {
+		return StatusNamespaceTooDeep
+	}
+
+	if _, ok := s.namespaces[id]; ok {
+		return StatusNamespaceAlreadyExists
+	}
+
+	s.namespaces[id] = &namespace{
+		id:          id,
+		name:        parent.name + "." + tx.NamspaceName,
+		parent:      parent.id,
+		levels:      append(append([]uint64(nil), parent.levels...), id),
+		owner:       owner,
+		startHeight: parent.startHeight,
+		endHeight:   parent.endHeight,
+	}
+
+	return StatusSuccess
+}
+
+func (s *state) applyMosaicDefinition(tx *sdk.MosaicDefinitionTransaction, height sdk.Height) string {
+	owner := s.signer(tx.Signer, height).publicKey
+	id := tx.MosaicId.Id()
+
+	m, ok := s.mosaics[id]
+	if !ok {
+		m = &mosaic{id: id, height: height, owner: owner}
+		s.mosaics[id] = m
+	} else if m.owner != owner {
+		return StatusMosaicOwnerConflict
+	}
+
+	m.revision++
+	m.supplyMutable = tx.SupplyMutable
+	m.transferable = tx.Transferable
+	m.divisibility = tx.Divisibility
+	m.duration = uint64(tx.MosaicProperties.Duration())
+
+	return StatusSuccess
+}
+
+func (s *state) applyMosaicSupplyChange(tx *sdk.MosaicSupplyChangeTransaction, height sdk.Height) string {
+	owner := s.signer(tx.Signer, height)
+
+	id, ok := s.resolveMosaic(tx.AssetId)
+	if !ok {
+		return StatusMosaicExpired
+	}
+
+	m := s.mosaics[id]
+	if m.owner != owner.publicKey {
+		return StatusMosaicOwnerConflict
+	}
+
+	// supply of immutable mosaic can be changed only while owner holds the whole supply
+	if !m.supplyMutable && owner.balances[id] != m.supply {
+		return StatusMosaicSupplyImmutable
+	}
+
+	delta := uint64(tx.Delta)
+
+	if tx.MosaicSupplyType == sdk.Increase {
+		m.supply += delta
+		owner.balances[id] += delta
+
+		return StatusSuccess
+	}
+
+	if owner.balances[id] < delta {
+		return StatusMosaicSupplyNegative
+	}
+
+	m.supply -= delta
+	owner.balances[id] -= delta
+
+	return StatusSuccess
+}
+
+func (s *state) applyAlias(tx *sdk.AliasTransaction, height sdk.Height, aliasType sdk.AliasType, mosaicId uint64, address string) string {
+	owner := s.signer(tx.Signer, height).publicKey
+
+	ns, ok := s.namespaces[tx.NamespaceId.Id()]
+	if !ok {
+		return StatusNamespaceAliasUnknown
+	}
+
+	if ns.owner != owner {
+		return StatusNamespaceAliasOwnerConflict
+	}
+
+	if aliasType == sdk.MosaicAliasType {
+		if m, ok := s.mosaics[mosaicId]; !ok || m.owner != owner {
+			return StatusNamespaceAliasOwnerConflict
+		}
+	}
+
+	if tx.ActionType == sdk.AliasLink {
+		if ns.aliasType != sdk.NoneAliasType {
+			return StatusNamespaceAliasExists
+		}
+
+		ns.aliasType, ns.aliasMosaic, ns.aliasAddress = aliasType, mosaicId, address
+
+		return StatusSuccess
+	}
+
+	if ns.aliasType == sdk.NoneAliasType {
+		return StatusNamespaceAliasDoesNotExist
+	}
+
+	if ns.aliasType != aliasType || ns.aliasMosaic != mosaicId || ns.aliasAddress != address {
+		return StatusNamespaceAliasInconsistency
+	}
+
+	ns.aliasType, ns.aliasMosaic, ns.aliasAddress = sdk.NoneAliasType, 0, ""
+
+	return StatusSuccess
+}
+
+// returns raw addresses of accounts which are changed or notified by transaction
+func (s *state) addresses(tx sdk.Transaction) []string {
+	addresses := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(address string) {
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+
+	var walk func(tx sdk.Transaction)
+	walk = func(tx sdk.Transaction) {
+		if signer := tx.GetAbstractTransaction().Signer; signer != nil {
+			add(rawAddress(signer.Address))
+		}
+
+		switch t := tx.(type) {
+		case *sdk.TransferTransaction:
+			if address, ok := s.resolveAddress(t.Recipient); ok {
+				add(address)
+			}
+		case *sdk.AddressAliasTransaction:
+			add(rawAddress(t.Address))
+		case *sdk.AggregateTransaction:
+			for _, c := range t.Cosignatures {
+				add(rawAddress(c.Signer.Address))
+			}
+
+			for _, inner := range t.InnerTransactions {
+				walk(inner)
+			}
+		}
+	}
+
+	walk(tx)
+
+	return addresses
+}
+
+// returns upper-case hex representation of base32 address
+func rawAddress(address *sdk.Address) string {
+	if address == nil {
+		return ""
+	}
+
+	b, err := base32.StdEncoding.DecodeString(address.Address)
+	if err != nil {
+		return strings.ToUpper(address.Address)
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+// returns base32 address of raw address
+func base32Address(raw string) string {
+	b, err := hex.DecodeString(raw)
+	if err != nil {
+		return raw
+	}
+
+	return base32.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,265 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// websocket channels which are published by Node
+const (
+	channelBlock            = "block"
+	channelConfirmedAdded   = "confirmedAdded"
+	channelUnconfirmedAdded = "unconfirmedAdded"
+	channelPartialAdded     = "partialAdded"
+	channelStatus           = "status"
+	channelCosignature      = "cosignature"
+)
+
+// message is a websocket message which is sent to connections subscribed to its channel and address
+type message struct {
+	channel string
+	// raw address of message, it is empty for block channel
+	address string
+	body    []byte
+}
+
+// returns topic of subscription which receives message
+func (m *message) topic() string {
+	if m.address == "" {
+		return m.channel
+	}
+
+	return m.channel + "/" + m.address
+}
+
+type subscriptionDTO struct {
+	Uid         string `json:"uid"`
+	Subscribe   string `json:"subscribe"`
+	Unsubscribe string `json:"unsubscribe"`
+}
+
+type connection struct {
+	// guards writes to conn and topics
+	sync.Mutex
+	conn   *websocket.Conn
+	topics map[string]bool
+}
+
+func (c *connection) write(body []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.conn.WriteMessage(websocket.TextMessage, body)
+}
+
+func (c *connection) subscribed(topic string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.topics[topic]
+}
+
+func (c *connection) setSubscribed(topic string, subscribed bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if subscribed {
+		c.topics[topic] = true
+	} else {
+		delete(c.topics, topic)
+	}
+}
+
+// hub keeps websocket connections of Node and publishes messages to them
+type hub struct {
+	upgrader websocket.Upgrader
+
+	mutex       sync.Mutex
+	connections map[string]*connection
+	uids        int
+}
+
+func newHub() *hub {
+	return &hub{
+		upgrader:    websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+		connections: make(map[string]*connection),
+	}
+}
+
+// upgrades request to websocket connection, sends uid to it and serves subscriptions until connection is closed
+func (h *hub) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	h.mutex.Lock()
+	h.uids++
+	uid := fmt.Sprintf("%024X", h.uids)
+	c := &connection{conn: conn, topics: make(map[string]bool)}
+	h.connections[uid] = c
+	h.mutex.Unlock()
+
+	defer func() {
+		h.mutex.Lock()
+		delete(h.connections, uid)
+		h.mutex.Unlock()
+
+		conn.Close()
+	}()
+
+	body, err := json.Marshal(object{"uid": uid})
+	if err != nil || c.write(body) != nil {
+		return
+	}
+
+	for {
+		dto := subscriptionDTO{}
+		if err := conn.ReadJSON(&dto); err != nil {
+			return
+		}
+
+		if dto.Subscribe != "" {
+			c.setSubscribed(normalizeTopic(dto.Subscribe), true)
+		}
+
+		if dto.Unsubscribe != "" {
+			c.setSubscribed(normalizeTopic(dto.Unsubscribe), false)
+		}
+	}
+}
+
+// returns topic with raw address, clients subscribe to topics with base32 addresses
+func normalizeTopic(topic string) string {
+	parts := strings.SplitN(topic, "/", 2)
+	if len(parts) == 1 {
+		return topic
+	}
+
+	return parts[0] + "/" + rawAddress(&sdk.Address{Address: parts[1]})
+}
+
+// sends messages to subscribed connections, connections which can't receive message are closed
+func (h *hub) publish(messages ...*message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	connections := make([]*connection, 0, len(h.connections))
+	for _, c := range h.connections {
+		connections = append(connections, c)
+	}
+	h.mutex.Unlock()
+
+	for _, m := range messages {
+		topic := m.topic()
+
+		for _, c := range connections {
+			if !c.subscribed(topic) {
+				continue
+			}
+
+			if err := c.write(m.body); err != nil {
+				c.conn.Close()
+			}
+		}
+	}
+}
+
+// closes all websocket connections
+func (h *hub) closeAll() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, c := range h.connections {
+		c.conn.Close()
+	}
+}
+
+// returns JSON of passed object with channel name and address in meta
+func newMessage(channel, address string, o object) *message {
+	meta, ok := o["meta"].(object)
+	if !ok {
+		meta = object{}
+	}
+
+	withChannel := object{"channelName": channel}
+	for k, v := range meta {
+		withChannel[k] = v
+	}
+
+	if address != "" {
+		withChannel["address"] = address
+	}
+
+	withMeta := object{}
+	for k, v := range o {
+		withMeta[k] = v
+	}
+	withMeta["meta"] = withChannel
+
+	body, _ := json.Marshal(withMeta)
+
+	return &message{channel: channel, address: address, body: body}
+}
+
+func (n *Node) blockMessage(b *block) *message {
+	return newMessage(channelBlock, "", n.renderBlock(b))
+}
+
+// returns messages of transaction for every account which is notified about it
+func (n *Node) transactionMessages(channel string, t *transaction) []*message {
+	o, err := renderTransaction(t)
+	if err != nil {
+		return nil
+	}
+
+	messages := make([]*message, 0, len(t.addresses))
+	for _, address := range t.addresses {
+		messages = append(messages, newMessage(channel, address, o))
+	}
+
+	return messages
+}
+
+// returns status messages of failed transaction for every account which is notified about it
+func (n *Node) statusMessages(t *transaction) []*message {
+	status := renderStatus(t)
+	delete(status, "group")
+	delete(status, "height")
+
+	messages := make([]*message, 0, len(t.addresses))
+	for _, address := range t.addresses {
+		messages = append(messages, newMessage(channelStatus, address, status))
+	}
+
+	return messages
+}
+
+// returns cosignature messages of partial transaction for every account which is notified about it
+func (n *Node) cosignatureMessages(t *transaction, dto *cosignatureDTO) []*message {
+	cosignature := object{
+		"parentHash": strings.ToUpper(dto.ParentHash),
+		"signature":  strings.ToUpper(dto.Signature),
+		"signer":     strings.ToUpper(dto.Signer),
+	}
+
+	messages := make([]*message, 0, len(t.addresses))
+	for _, address := range t.addresses {
+		messages = append(messages, newMessage(channelCosignature, address, cosignature))
+	}
+
+	return messages
+}
@@ -6,8 +6,8 @@ package sdk
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/pkg/errors"
 	"github.com/proximax-storage/go-xpx-utils/net"
 	"net/http"
 )
@@ -26,7 +26,7 @@ func (s *StorageService) GetDrive(ctx context.Context, driveKey *PublicAccount)
 
 	dto := &driveDTO{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetDrive"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +55,7 @@ func (s *StorageService) GetAccountDrives(ctx context.Context, driveKey *PublicA
 
 	dto := &driveDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetAccountDrives"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		// Skip ErrResourceNotFound
 		// not return err
@@ -81,18 +81,11 @@ func (s *StorageService) GetVerificationStatus(ctx context.Context, driveKey *Pu
 
 	lockInfo, err := s.LockService.GetSecretLockInfo(ctx, compositeHash)
 	if err != nil {
-		switch e := err.(type) {
-		case *HttpError:
-			if e.StatusCode == 404 {
-				return &VerificationStatus{
-					Active:    false,
-					Available: true,
-				}, nil
-			} else {
-				return nil, err
-			}
-		default:
-			return nil, err
+		if errors.Is(err, ErrResourceNotFound) {
+			return &VerificationStatus{
+				Active:    false,
+				Available: true,
+			}, nil
 		}
 
 		return nil, err
@@ -117,7 +110,7 @@ func (s *StorageService) GetDownloadInfo(ctx context.Context, operationToken *Ha
 
 	dto := &downloadInfoDTO{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetDownloadInfo"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +131,7 @@ func (s *StorageService) GetAccountDownloadInfos(ctx context.Context, recipient
 
 	dto := &downloadInfoDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetAccountDownloadInfos"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +152,7 @@ func (s *StorageService) GetDriveDownloadInfos(ctx context.Context, drive *Publi
 
 	dto := &downloadInfoDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetDriveDownloadInfos"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +22,7 @@ func (s *SuperContractService) GetSuperContract(ctx context.Context, contractKey
 
 	dto := &superContractDTO{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetSuperContract"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +43,7 @@ func (s *SuperContractService) GetDriveSuperContracts(ctx context.Context, drive
 
 	dto := &superContractDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetDriveSuperContracts"}, http.MethodGet, url.Encode(), nil, dto)
 
 	if err = handleResponseStatusCode(resp, map[int]error{404: ErrResourceNotFound, 409: ErrArgumentNotValid}); err != nil {
 		return nil, err
@@ -61,7 +61,7 @@ func (s *SuperContractService) GetOperation(ctx context.Context, operationHash *
 
 	dto := &operationDTO{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetOperation"}, http.MethodGet, url.Encode(), nil, dto)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +82,7 @@ func (s *SuperContractService) GetOperationsByAccount(ctx context.Context, accou
 
 	dto := &operationDTOs{}
 
-	resp, err := s.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	resp, err := s.client.doNewRequest(ctx, RequestOperation{s.name, "GetOperationsByAccount"}, http.MethodGet, url.Encode(), nil, dto)
 
 	if err = handleResponseStatusCode(resp, map[int]error{404: ErrResourceNotFound, 409: ErrArgumentNotValid}); err != nil {
 		return nil, err
@@ -20,11 +20,15 @@ type TransactionService struct {
 
 // returns Transaction for passed transaction id or hash
 func (txs *TransactionService) GetTransaction(ctx context.Context, id string) (Transaction, error) {
+	if v, ok := txs.client.Cache.get(transactionCacheOperation, id); ok {
+		return v.(Transaction), nil
+	}
+
 	var b bytes.Buffer
 
 	url := net.NewUrl(fmt.Sprintf(transactionRoute, id))
 
-	resp, err := txs.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, &b)
+	resp, err := txs.client.doNewRequest(ctx, RequestOperation{txs.name, "GetTransaction"}, http.MethodGet, url.Encode(), nil, &b)
 	if err != nil {
 		return nil, err
 	}
@@ -33,17 +37,47 @@ func (txs *TransactionService) GetTransaction(ctx context.Context, id string) (T
 		return nil, err
 	}
 
-	return MapTransaction(&b, txs.client.GenerationHash())
+	tx, err := MapTransaction(&b, txs.client.GenerationHash())
+	if err != nil {
+		return nil, err
+	}
+
+	if txs.client.Cache.isConfirmed(ctx, tx.GetAbstractTransaction().Height) {
+		txs.client.Cache.set(transactionCacheOperation, id, tx)
+	}
+
+	return tx, nil
 }
 
 // returns an array of Transaction's for passed array of transaction ids or hashes
 func (txs *TransactionService) GetTransactions(ctx context.Context, ids []string) ([]Transaction, error) {
+	result := make([]Transaction, 0, len(ids))
+
+	// node accepts limited number of ids in one request
+	err := txs.client.forEachBatch(len(ids), func(from, to int) error {
+		transactions, err := txs.getTransactions(ctx, ids[from:to])
+		if err != nil {
+			return err
+		}
+
+		result = append(result, transactions...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (txs *TransactionService) getTransactions(ctx context.Context, ids []string) ([]Transaction, error) {
 	var b bytes.Buffer
 	txIds := &TransactionIdsDTO{
 		ids,
 	}
 
-	resp, err := txs.client.doNewRequest(ctx, http.MethodPost, transactionsRoute, txIds, &b)
+	resp, err := txs.client.doNewRequest(ctx, RequestOperation{txs.name, "GetTransactions"}, http.MethodPost, transactionsRoute, txIds, &b)
 	if err != nil {
 		return nil, err
 	}
@@ -55,14 +89,15 @@ func (txs *TransactionService) GetTransactions(ctx context.Context, ids []string
 	return MapTransactions(&b, txs.client.GenerationHash())
 }
 
-// returns transaction hash after announcing passed SignedTransaction
+// returns transaction hash after announcing passed SignedTransaction.
+// failed announce is safely repeated by retry policy, because node accepts transaction with the same hash only once
 func (txs *TransactionService) Announce(ctx context.Context, tx *SignedTransaction) (string, error) {
 	dto := signedTransactionDto{
 		tx.EntityType,
 		tx.Payload,
 		tx.Hash.String(),
 	}
-	return txs.announceTransaction(ctx, &dto, transactionsRoute)
+	return txs.announceTransaction(ctx, "Announce", &dto, transactionsRoute)
 }
 
 // returns transaction hash after announcing passed aggregate bounded SignedTransaction
@@ -72,7 +107,7 @@ func (txs *TransactionService) AnnounceAggregateBonded(ctx context.Context, tx *
 		tx.Payload,
 		tx.Hash.String(),
 	}
-	return txs.announceTransaction(ctx, &dto, announceAggregateRoute)
+	return txs.announceTransaction(ctx, "AnnounceAggregateBonded", &dto, announceAggregateRoute)
 }
 
 // returns transaction hash after announcing passed CosignatureSignedTransaction
@@ -82,14 +117,14 @@ func (txs *TransactionService) AnnounceAggregateBondedCosignature(ctx context.Co
 		c.Signature.String(),
 		c.Signer,
 	}
-	return txs.announceTransaction(ctx, &dto, announceAggregateCosignatureRoute)
+	return txs.announceTransaction(ctx, "AnnounceAggregateBondedCosignature", &dto, announceAggregateCosignatureRoute)
 }
 
 // returns TransactionStatus for passed transaction id or hash
 func (txs *TransactionService) GetTransactionStatus(ctx context.Context, id string) (*TransactionStatus, error) {
 	ts := &transactionStatusDTO{}
 
-	resp, err := txs.client.doNewRequest(ctx, http.MethodGet, fmt.Sprintf(transactionStatusRoute, id), nil, ts)
+	resp, err := txs.client.doNewRequest(ctx, RequestOperation{txs.name, "GetTransactionStatus"}, http.MethodGet, fmt.Sprintf(transactionStatusRoute, id), nil, ts)
 	if err != nil {
 		return nil, err
 	}
@@ -103,12 +138,33 @@ func (txs *TransactionService) GetTransactionStatus(ctx context.Context, id stri
 
 // returns an array of TransactionStatus's for passed transaction ids or hashes
 func (txs *TransactionService) GetTransactionStatuses(ctx context.Context, hashes []string) ([]*TransactionStatus, error) {
+	statuses := make([]*TransactionStatus, 0, len(hashes))
+
+	// node accepts limited number of hashes in one request
+	err := txs.client.forEachBatch(len(hashes), func(from, to int) error {
+		batch, err := txs.getTransactionStatuses(ctx, hashes[from:to])
+		if err != nil {
+			return err
+		}
+
+		statuses = append(statuses, batch...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (txs *TransactionService) getTransactionStatuses(ctx context.Context, hashes []string) ([]*TransactionStatus, error) {
 	txIds := &TransactionHashesDTO{
 		hashes,
 	}
 
 	dtos := transactionStatusDTOs(make([]*transactionStatusDTO, len(hashes)))
-	resp, err := txs.client.doNewRequest(ctx, http.MethodPost, transactionsStatusRoute, txIds, &dtos)
+	resp, err := txs.client.doNewRequest(ctx, RequestOperation{txs.name, "GetTransactionStatuses"}, http.MethodPost, transactionsStatusRoute, txIds, &dtos)
 	if err != nil {
 		return nil, err
 	}
@@ -120,12 +176,12 @@ func (txs *TransactionService) GetTransactionStatuses(ctx context.Context, hashe
 	return dtos.toStruct()
 }
 
-func (txs *TransactionService) announceTransaction(ctx context.Context, tx interface{}, path string) (string, error) {
+func (txs *TransactionService) announceTransaction(ctx context.Context, operation string, tx interface{}, path string) (string, error) {
 	m := struct {
 		Message string `json:"message"`
 	}{}
 
-	resp, err := txs.client.doNewRequest(ctx, http.MethodPut, path, tx, &m)
+	resp, err := txs.client.doNewRequest(ctx, RequestOperation{txs.name, operation}, http.MethodPut, path, tx, &m)
 	if err != nil {
 		return "", err
 	}
@@ -159,6 +159,8 @@ type TransactionInfo struct {
 	AggregateHash       *Hash
 	UniqueAggregateHash *Hash
 	AggregateId         string
+	// is true when websocket client delivers transaction fetched through REST after reconnection
+	Backfilled bool
 }
 
 func (ti *TransactionInfo) String() string {
@@ -214,14 +216,14 @@ func (dto *transactionInfoDTO) toStruct() (*TransactionInfo, error) {
 	}
 
 	ref := TransactionInfo{
-		dto.Height.toStruct(),
-		dto.Index,
-		dto.Id,
-		transactionHash,
-		merkleComponentHash,
-		aggregateHash,
-		uniqueAggregateHash,
-		dto.AggregateId,
+		Height:              dto.Height.toStruct(),
+		Index:               dto.Index,
+		Id:                  dto.Id,
+		TransactionHash:     transactionHash,
+		MerkleComponentHash: merkleComponentHash,
+		AggregateHash:       aggregateHash,
+		UniqueAggregateHash: uniqueAggregateHash,
+		AggregateId:         dto.AggregateId,
 	}
 
 	return &ref, nil
@@ -3300,6 +3302,8 @@ const (
 	AliasActionSize                          int = 1
 	AliasTransactionHeaderSize                   = TransactionHeaderSize + NamespaceSize + AliasActionSize
 	AggregateBondedHeaderSize                    = TransactionHeaderSize + SizeSize
+	AggregateCosignatureSize                     = SignerSize + SignatureSize
+	EmbeddedTransactionHeaderSize                = SizeSize + SignerSize + VersionSize + TypeSize
 	NetworkConfigHeaderSize                      = TransactionHeaderSize + BaseInt64Size + MaxStringSize + MaxStringSize
 	BlockchainUpgradeTransactionSize             = TransactionHeaderSize + DurationSize + BaseInt64Size
 	HashTypeSize                             int = 1
@@ -3751,8 +3755,24 @@ func toAggregateTransactionBytes(tx Transaction) ([]byte, error) {
 	return rB, nil
 }
 
-func signTransactionWith(tx Transaction, a *Account) (*SignedTransaction, error) {
-	s := crypto.NewSignerFromKeyPair(a.KeyPair, nil)
+func signerPublicKey(signer Signer) ([]byte, error) {
+	if signer == nil || signer.SignerAccount() == nil {
+		return nil, ErrNilSigner
+	}
+
+	pk, err := hex.DecodeString(signer.SignerAccount().PublicKey)
+	if err != nil || len(pk) != SignerSize {
+		return nil, ErrInvalidSignerPublicKey
+	}
+
+	return pk, nil
+}
+
+func signTransactionWith(tx Transaction, signer Signer, generationHash *Hash) (*SignedTransaction, error) {
+	pk, err := signerPublicKey(signer)
+	if err != nil {
+		return nil, err
+	}
 	b, err := tx.Bytes()
 	if err != nil {
 		return nil, err
@@ -3760,27 +3780,33 @@ func signTransactionWith(tx Transaction, a *Account) (*SignedTransaction, error)
 	sb := make([]byte, len(b)-SizeSize-SignerSize-SignatureSize)
 	copy(sb, b[SizeSize+SignerSize+SignatureSize:])
 
-	if a.generationHash != nil {
-		sb = append(a.generationHash[:], sb...)
+	if generationHash != nil {
+		sb = append(generationHash[:], sb...)
 	}
-	signature, err := s.Sign(sb)
+	signature, err := signer.SignBytes(sb)
 	if err != nil {
 		return nil, err
 	}
 
 	p := make([]byte, len(b))
 	copy(p[:SizeSize], b[:SizeSize])
-	copy(p[SizeSize:SizeSize+SignatureSize], signature.Bytes())
-	copy(p[SizeSize+SignatureSize:SizeSize+SignatureSize+SignerSize], a.KeyPair.PublicKey.Raw)
+	copy(p[SizeSize:SizeSize+SignatureSize], signature[:])
+	copy(p[SizeSize+SignatureSize:SizeSize+SignatureSize+SignerSize], pk)
 	copy(p[SizeSize+SignatureSize+SignerSize:], b[SizeSize+SignatureSize+SignerSize:])
 
-	h, err := createTransactionHash(p, a.generationHash)
+	h, err := createTransactionHash(p, generationHash)
 	if err != nil {
 		return nil, err
 	}
 	return &SignedTransaction{tx.GetAbstractTransaction().Type, strings.ToUpper(hex.EncodeToString(p)), h}, nil
 }
 
+// signs Transaction with passed Signer for network with passed generation hash
+// returns announced SignedTransaction
+func SignTransaction(signer Signer, tx Transaction, generationHash *Hash) (*SignedTransaction, error) {
+	return signTransactionWith(tx, signer, generationHash)
+}
+
 func InnerTransactionHash(tx Transaction) *Hash {
 	b, err := toAggregateTransactionBytes(tx)
 	if err != nil {
@@ -3835,20 +3861,36 @@ func UniqueAggregateHash(aggregateTx *AggregateTransaction, tx Transaction, gene
 	return bytesToHash(r)
 }
 
-func signTransactionWithCosignatures(tx *AggregateTransaction, a *Account, cosignatories []*Account) (*SignedTransaction, error) {
-	stx, err := signTransactionWith(tx, a)
+func signTransactionWithCosignatures(tx *AggregateTransaction, signer Signer, cosignatories []Signer, generationHash *Hash) (*SignedTransaction, error) {
+	stx, err := signTransactionWith(tx, signer, generationHash)
 	if err != nil {
 		return nil, err
 	}
 
-	p := stx.Payload
-	for _, cos := range cosignatories {
-		s := crypto.NewSignerFromKeyPair(cos.KeyPair, nil)
-		sb, err := s.Sign(stx.Hash[:])
+	cosignatures := make([]*CosignatureSignedTransaction, len(cosignatories))
+	for i, cos := range cosignatories {
+		pk, err := signerPublicKey(cos)
 		if err != nil {
 			return nil, err
 		}
-		p += cos.KeyPair.PublicKey.String() + hex.EncodeToString(sb.Bytes())
+		sb, err := cos.SignBytes(stx.Hash[:])
+		if err != nil {
+			return nil, err
+		}
+		cosignatures[i] = &CosignatureSignedTransaction{stx.Hash, sb, hex.EncodeToString(pk)}
+	}
+
+	return appendCosignatures(stx, cosignatures)
+}
+
+// returns SignedTransaction of aggregate with passed cosignatures appended to its payload
+func appendCosignatures(stx *SignedTransaction, cosignatures []*CosignatureSignedTransaction) (*SignedTransaction, error) {
+	p := stx.Payload
+	for _, cos := range cosignatures {
+		if len(cos.Signer) != 2*SignerSize || cos.Signature == nil {
+			return nil, ErrInvalidCosignaturesSize
+		}
+		p += cos.Signer + cos.Signature.String()
 	}
 
 	pb, err := hex.DecodeString(p)
@@ -3861,28 +3903,36 @@ func signTransactionWithCosignatures(tx *AggregateTransaction, a *Account, cosig
 
 	copy(pb[:len(s)], s)
 
-	return &SignedTransaction{tx.Type, hex.EncodeToString(pb), stx.Hash}, nil
+	return &SignedTransaction{stx.EntityType, hex.EncodeToString(pb), stx.Hash}, nil
+}
+
+// signs AggregateTransaction with passed Signer and with every passed cosignatory Signer
+// returns announced Aggregate SignedTransaction
+func SignTransactionWithCosignatures(signer Signer, tx *AggregateTransaction, cosignatories []Signer, generationHash *Hash) (*SignedTransaction, error) {
+	return signTransactionWithCosignatures(tx, signer, cosignatories, generationHash)
 }
 
-func signCosignatureTransaction(a *Account, tx *CosignatureTransaction) (*CosignatureSignedTransaction, error) {
+func signCosignatureTransaction(signer Signer, tx *CosignatureTransaction) (*CosignatureSignedTransaction, error) {
 	if tx.TransactionToCosign.TransactionInfo.TransactionHash.Empty() {
 		return nil, errors.New("cosignature transaction hash is nil")
 	}
 
-	s := crypto.NewSignerFromKeyPair(a.KeyPair, nil)
-	b := tx.TransactionToCosign.TransactionInfo.TransactionHash[:]
-
-	sb, err := s.Sign(b)
-	if err != nil {
+	if _, err := signerPublicKey(signer); err != nil {
 		return nil, err
 	}
 
-	signature, err := bytesToSignature(sb.Bytes())
+	signature, err := signer.SignBytes(tx.TransactionToCosign.TransactionInfo.TransactionHash[:])
 	if err != nil {
 		return nil, err
 	}
 
-	return &CosignatureSignedTransaction{tx.TransactionToCosign.TransactionInfo.TransactionHash, signature, a.PublicAccount.PublicKey}, nil
+	return &CosignatureSignedTransaction{tx.TransactionToCosign.TransactionInfo.TransactionHash, signature, signer.SignerAccount().PublicKey}, nil
+}
+
+// signs CosignatureTransaction with passed Signer
+// returns announced CosignatureSignedTransaction
+func SignCosignatureTransaction(signer Signer, tx *CosignatureTransaction) (*CosignatureSignedTransaction, error) {
+	return signCosignatureTransaction(signer, tx)
 }
 
 func cosignatoryModificationArrayToBuffer(builder *flatbuffers.Builder, modifications []*MultisigCosignatoryModification) (flatbuffers.UOffsetT, error) {
@@ -0,0 +1,999 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// returns Transaction parsed from passed catapult binary payload
+func ParseTransactionPayload(payload []byte) (Transaction, error) {
+	r := newPayloadReader(payload)
+
+	tx, err := r.transaction(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.len() != 0 {
+		return nil, ErrPayloadSizeMismatch
+	}
+
+	return tx, nil
+}
+
+// returns Transaction parsed from payload of passed SignedTransaction
+func ParseSignedTransaction(stx *SignedTransaction) (Transaction, error) {
+	if stx == nil {
+		return nil, errors.New("signed transaction must not be nil")
+	}
+
+	payload, err := hex.DecodeString(stx.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := ParseTransactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.GetAbstractTransaction().Type != stx.EntityType {
+		return nil, fmt.Errorf("entity type of payload %s doesn't match %s", tx.GetAbstractTransaction().Type, stx.EntityType)
+	}
+
+	if stx.Hash != nil {
+		tx.GetAbstractTransaction().TransactionHash = stx.Hash
+
+		// inner transactions don't have own hashes, they refer to the aggregate one as REST does
+		if agtx, ok := tx.(*AggregateTransaction); ok {
+			for _, itx := range agtx.InnerTransactions {
+				itx.GetAbstractTransaction().AggregateHash = stx.Hash
+			}
+		}
+	}
+
+	return tx, nil
+}
+
+// returns catapult binary payload of passed Transaction with its signature, signer and cosignatures.
+// It is the inverse of ParseTransactionPayload, e.g. it restores payload of transaction returned by REST
+func TransactionPayload(tx Transaction) ([]byte, error) {
+	b, err := tx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	atx := tx.GetAbstractTransaction()
+
+	if atx.Signature != "" {
+		signature, err := StringToSignature(atx.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		copy(b[SizeSize:SizeSize+SignatureSize], signature[:])
+	}
+
+	if atx.Signer != nil {
+		signer, err := payloadPublicKey(atx.Signer)
+		if err != nil {
+			return nil, err
+		}
+
+		copy(b[SizeSize+SignatureSize:SizeSize+SignatureSize+SignerSize], signer)
+	}
+
+	agtx, ok := tx.(*AggregateTransaction)
+	if !ok || len(agtx.Cosignatures) == 0 {
+		return b, nil
+	}
+
+	for _, c := range agtx.Cosignatures {
+		signer, err := payloadPublicKey(c.Signer)
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := StringToSignature(c.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		b = append(b, signer...)
+		b = append(b, signature[:]...)
+	}
+
+	binary.LittleEndian.PutUint32(b[:SizeSize], uint32(len(b)))
+
+	return b, nil
+}
+
+func payloadPublicKey(pa *PublicAccount) ([]byte, error) {
+	if pa == nil {
+		return nil, ErrNilSigner
+	}
+
+	pk, err := hex.DecodeString(pa.PublicKey)
+	if err != nil || len(pk) != SignerSize {
+		return nil, ErrInvalidSignerPublicKey
+	}
+
+	return pk, nil
+}
+
+type payloadReader struct {
+	buf []byte
+	err error
+}
+
+func newPayloadReader(b []byte) *payloadReader {
+	return &payloadReader{buf: b}
+}
+
+func (r *payloadReader) len() int {
+	return len(r.buf)
+}
+
+// returns the next n bytes of payload, nil is returned if payload is shorter.
+// Sizes are taken from payload, so nothing is allocated on error
+func (r *payloadReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if n < 0 || len(r.buf) < n {
+		r.err = ErrPayloadTooShort
+		return nil
+	}
+
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+// returns the next n bytes of fixed size value, zeros are returned on error
+func (r *payloadReader) fixed(n int) []byte {
+	if b := r.next(n); b != nil {
+		return b
+	}
+
+	return make([]byte, n)
+}
+
+// returns reader limited by the next n bytes of payload
+func (r *payloadReader) sub(n int) *payloadReader {
+	return &payloadReader{buf: r.next(n), err: r.err}
+}
+
+func (r *payloadReader) uint8() uint8 {
+	return r.fixed(1)[0]
+}
+
+func (r *payloadReader) uint16() uint16 {
+	return binary.LittleEndian.Uint16(r.fixed(2))
+}
+
+func (r *payloadReader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.fixed(4))
+}
+
+func (r *payloadReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.fixed(8))
+}
+
+func (r *payloadReader) hash() *Hash {
+	var h Hash
+	copy(h[:], r.next(Hash256))
+	return &h
+}
+
+func (r *payloadReader) hexString(n int) string {
+	return strings.ToUpper(hex.EncodeToString(r.next(n)))
+}
+
+func (r *payloadReader) publicAccount(networkType NetworkType) *PublicAccount {
+	key := r.hexString(KeySize)
+	if r.err != nil {
+		return nil
+	}
+
+	pa, err := NewAccountFromPublicKey(key, networkType)
+	if err != nil {
+		r.err = err
+	}
+
+	return pa
+}
+
+func (r *payloadReader) address() *Address {
+	b := r.next(AddressSize)
+	if r.err != nil {
+		return nil
+	}
+
+	a, err := NewAddressFromRaw(base32.StdEncoding.EncodeToString(b))
+	if err != nil {
+		r.err = err
+	}
+
+	return a
+}
+
+func (r *payloadReader) mosaicId() *MosaicId {
+	id := r.uint64()
+	if r.err != nil {
+		return nil
+	}
+
+	mosaicId, err := NewMosaicId(id)
+	if err != nil {
+		r.err = err
+	}
+
+	return mosaicId
+}
+
+func (r *payloadReader) namespaceId() *NamespaceId {
+	id := r.uint64()
+	if r.err != nil {
+		return nil
+	}
+
+	namespaceId, err := NewNamespaceId(id)
+	if err != nil {
+		r.err = err
+	}
+
+	return namespaceId
+}
+
+func (r *payloadReader) assetId() AssetId {
+	id := r.uint64()
+	if r.err != nil {
+		return nil
+	}
+
+	assetId, err := NewAssetIdFromId(id)
+	if err != nil {
+		r.err = err
+	}
+
+	return assetId
+}
+
+func (r *payloadReader) mosaic() *Mosaic {
+	assetId := r.assetId()
+	amount := Amount(r.uint64())
+	if r.err != nil {
+		return nil
+	}
+
+	return newMosaicPanic(assetId, amount)
+}
+
+func (r *payloadReader) mosaics(count int) []*Mosaic {
+	ms := make([]*Mosaic, count)
+	for i := range ms {
+		ms[i] = r.mosaic()
+	}
+
+	return ms
+}
+
+func (r *payloadReader) cosignatoryModifications(count int, networkType NetworkType) []*MultisigCosignatoryModification {
+	ms := make([]*MultisigCosignatoryModification, count)
+	for i := range ms {
+		ms[i] = &MultisigCosignatoryModification{
+			MultisigCosignatoryModificationType(r.uint8()),
+			r.publicAccount(networkType),
+		}
+	}
+
+	return ms
+}
+
+func (r *payloadReader) actions(count int) []*Action {
+	as := make([]*Action, count)
+	for i := range as {
+		as[i] = &Action{
+			FileHash: r.hash(),
+			FileSize: StorageSize(r.uint64()),
+		}
+	}
+
+	return as
+}
+
+func isZeroBytes(b []byte) bool {
+	return bytes.Equal(b, make([]byte, len(b)))
+}
+
+// reads transaction from payload. Embedded transactions are stored without signature, max fee and deadline
+func (r *payloadReader) transaction(embedded bool) (Transaction, error) {
+	size := int(r.uint32())
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	headerSize := TransactionHeaderSize
+	if embedded {
+		headerSize = EmbeddedTransactionHeaderSize
+	}
+
+	if size < headerSize {
+		return nil, ErrPayloadSizeMismatch
+	}
+
+	if size-SizeSize > r.len() {
+		return nil, ErrPayloadTooShort
+	}
+
+	tr := r.sub(size - SizeSize)
+
+	atx := AbstractTransaction{}
+
+	var signature []byte
+	if !embedded {
+		signature = tr.next(SignatureSize)
+	}
+
+	signer := tr.next(SignerSize)
+	version := tr.uint32()
+	atx.NetworkType = ExtractNetworkType(int64(version))
+	atx.Version = ExtractVersion(int64(version))
+	atx.Type = EntityType(tr.uint16())
+
+	if !embedded {
+		atx.MaxFee = Amount(tr.uint64())
+		atx.Deadline = NewDeadlineFromBlockchainTimestamp(NewBlockchainTimestamp(int64(tr.uint64())))
+
+		if !isZeroBytes(signature) {
+			atx.Signature = strings.ToUpper(hex.EncodeToString(signature))
+		}
+	}
+
+	if embedded || !isZeroBytes(signer) {
+		pa, err := NewAccountFromPublicKey(strings.ToUpper(hex.EncodeToString(signer)), atx.NetworkType)
+		if err != nil {
+			return nil, err
+		}
+		atx.Signer = pa
+	}
+
+	tx, err := tr.transactionBody(atx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.err != nil {
+		return nil, tr.err
+	}
+
+	if tr.len() != 0 {
+		return nil, ErrPayloadSizeMismatch
+	}
+
+	return tx, nil
+}
+
+func (r *payloadReader) transactionBody(atx AbstractTransaction) (Transaction, error) {
+	switch atx.Type {
+	case AccountPropertyAddress:
+		return r.accountPropertiesAddressTransaction(atx)
+	case AccountPropertyMosaic:
+		return r.accountPropertiesMosaicTransaction(atx)
+	case AccountPropertyEntityType:
+		return r.accountPropertiesEntityTypeTransaction(atx)
+	case AddressAlias:
+		return r.addressAliasTransaction(atx)
+	case MosaicAlias:
+		return r.mosaicAliasTransaction(atx)
+	case AggregateBonded, AggregateCompleted:
+		return r.aggregateTransaction(atx)
+	case AddExchangeOffer:
+		return r.addExchangeOfferTransaction(atx)
+	case ExchangeOffer:
+		return r.exchangeOfferTransaction(atx)
+	case RemoveExchangeOffer:
+		return r.removeExchangeOfferTransaction(atx)
+	case NetworkConfigEntityType:
+		return r.networkConfigTransaction(atx)
+	case BlockchainUpgrade:
+		return r.blockchainUpgradeTransaction(atx)
+	case LinkAccount:
+		return r.accountLinkTransaction(atx)
+	case Lock:
+		return r.lockFundsTransaction(atx)
+	case MetadataAddress, MetadataMosaic, MetadataNamespace:
+		return r.modifyMetadataTransaction(atx)
+	case ModifyContract:
+		return r.modifyContractTransaction(atx)
+	case ModifyMultisig:
+		return r.modifyMultisigAccountTransaction(atx)
+	case MosaicDefinition:
+		return r.mosaicDefinitionTransaction(atx)
+	case MosaicSupplyChange:
+		return r.mosaicSupplyChangeTransaction(atx)
+	case RegisterNamespace:
+		return r.registerNamespaceTransaction(atx)
+	case SecretLock:
+		return r.secretLockTransaction(atx)
+	case SecretProof:
+		return r.secretProofTransaction(atx)
+	case Transfer:
+		return r.transferTransaction(atx)
+	case PrepareDrive:
+		return r.prepareDriveTransaction(atx)
+	case JoinToDrive:
+		return &JoinToDriveTransaction{atx, r.publicAccount(atx.NetworkType)}, r.err
+	case DriveFileSystem, SuperContractFileSystem:
+		return r.driveFileSystemTransaction(atx)
+	case FilesDeposit:
+		return r.filesDepositTransaction(atx)
+	case EndDrive:
+		return &EndDriveTransaction{atx, r.publicAccount(atx.NetworkType)}, r.err
+	case DriveFilesReward:
+		return r.driveFilesRewardTransaction(atx)
+	case StartDriveVerification:
+		return &StartDriveVerificationTransaction{atx, r.publicAccount(atx.NetworkType)}, r.err
+	case EndDriveVerification:
+		return r.endDriveVerificationTransaction(atx)
+	case StartFileDownload:
+		return r.startFileDownloadTransaction(atx)
+	case EndFileDownload:
+		return r.endFileDownloadTransaction(atx)
+	case OperationIdentify:
+		return &OperationIdentifyTransaction{atx, r.hash()}, r.err
+	case EndOperation, EndExecute:
+		return r.endOperationTransaction(atx)
+	case Deploy:
+		return r.deployTransaction(atx)
+	case StartExecute:
+		return r.startExecuteTransaction(atx)
+	case Deactivate:
+		return &DeactivateTransaction{atx, r.hexString(KeySize), r.hexString(KeySize)}, r.err
+	default:
+		return nil, ErrUnsupportedPayloadType
+	}
+}
+
+func (r *payloadReader) accountPropertiesAddressTransaction(atx AbstractTransaction) (Transaction, error) {
+	propertyType := PropertyType(r.uint8())
+	ms := make([]*AccountPropertiesAddressModification, r.uint8())
+	for i := range ms {
+		ms[i] = &AccountPropertiesAddressModification{
+			PropertyModificationType(r.uint8()),
+			r.address(),
+		}
+	}
+
+	return &AccountPropertiesAddressTransaction{atx, propertyType, ms}, r.err
+}
+
+func (r *payloadReader) accountPropertiesMosaicTransaction(atx AbstractTransaction) (Transaction, error) {
+	propertyType := PropertyType(r.uint8())
+	ms := make([]*AccountPropertiesMosaicModification, r.uint8())
+	for i := range ms {
+		ms[i] = &AccountPropertiesMosaicModification{
+			PropertyModificationType(r.uint8()),
+			r.assetId(),
+		}
+	}
+
+	return &AccountPropertiesMosaicTransaction{atx, propertyType, ms}, r.err
+}
+
+func (r *payloadReader) accountPropertiesEntityTypeTransaction(atx AbstractTransaction) (Transaction, error) {
+	propertyType := PropertyType(r.uint8())
+	ms := make([]*AccountPropertiesEntityTypeModification, r.uint8())
+	for i := range ms {
+		ms[i] = &AccountPropertiesEntityTypeModification{
+			PropertyModificationType(r.uint8()),
+			EntityType(r.uint16()),
+		}
+	}
+
+	return &AccountPropertiesEntityTypeTransaction{atx, propertyType, ms}, r.err
+}
+
+func (r *payloadReader) aliasTransaction(atx AbstractTransaction) AliasTransaction {
+	return AliasTransaction{
+		atx,
+		AliasActionType(r.uint8()),
+		r.namespaceId(),
+	}
+}
+
+func (r *payloadReader) addressAliasTransaction(atx AbstractTransaction) (Transaction, error) {
+	alias := r.aliasTransaction(atx)
+
+	return &AddressAliasTransaction{alias, r.address()}, r.err
+}
+
+func (r *payloadReader) mosaicAliasTransaction(atx AbstractTransaction) (Transaction, error) {
+	alias := r.aliasTransaction(atx)
+
+	return &MosaicAliasTransaction{alias, r.mosaicId()}, r.err
+}
+
+func (r *payloadReader) aggregateTransaction(atx AbstractTransaction) (Transaction, error) {
+	tr := r.sub(int(r.uint32()))
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	txs := make([]Transaction, 0)
+	for tr.len() > 0 {
+		itx, err := tr.transaction(true)
+		if err != nil {
+			return nil, err
+		}
+
+		iatx := itx.GetAbstractTransaction()
+		iatx.Deadline = atx.Deadline
+		iatx.Signature = atx.Signature
+		iatx.MaxFee = atx.MaxFee
+
+		txs = append(txs, itx)
+	}
+
+	if r.len()%AggregateCosignatureSize != 0 {
+		return nil, ErrInvalidCosignaturesSize
+	}
+
+	cs := make([]*AggregateTransactionCosignature, r.len()/AggregateCosignatureSize)
+	for i := range cs {
+		signer := r.publicAccount(atx.NetworkType)
+		cs[i] = &AggregateTransactionCosignature{
+			r.hexString(SignatureSize),
+			signer,
+		}
+	}
+
+	return &AggregateTransaction{atx, txs, cs}, r.err
+}
+
+func (r *payloadReader) addExchangeOfferTransaction(atx AbstractTransaction) (Transaction, error) {
+	offers := make([]*AddOffer, r.uint8())
+	for i := range offers {
+		mosaic := r.mosaic()
+		cost := Amount(r.uint64())
+		offerType := OfferType(r.uint8())
+		offers[i] = &AddOffer{
+			Offer{
+				Type:   offerType,
+				Mosaic: mosaic,
+				Cost:   cost,
+			},
+			Duration(r.uint64()),
+		}
+	}
+
+	return &AddExchangeOfferTransaction{atx, offers}, r.err
+}
+
+func (r *payloadReader) exchangeOfferTransaction(atx AbstractTransaction) (Transaction, error) {
+	confirmations := make([]*ExchangeConfirmation, r.uint8())
+	for i := range confirmations {
+		mosaic := r.mosaic()
+		cost := Amount(r.uint64())
+		offerType := OfferType(r.uint8())
+		confirmations[i] = &ExchangeConfirmation{
+			Offer{
+				Type:   offerType,
+				Mosaic: mosaic,
+				Cost:   cost,
+			},
+			r.publicAccount(atx.NetworkType),
+		}
+	}
+
+	return &ExchangeOfferTransaction{atx, confirmations}, r.err
+}
+
+func (r *payloadReader) removeExchangeOfferTransaction(atx AbstractTransaction) (Transaction, error) {
+	offers := make([]*RemoveOffer, r.uint8())
+	for i := range offers {
+		assetId := r.mosaicId()
+		offers[i] = &RemoveOffer{
+			Type:    OfferType(r.uint8()),
+			AssetId: assetId,
+		}
+	}
+
+	return &RemoveExchangeOfferTransaction{atx, offers}, r.err
+}
+
+func (r *payloadReader) networkConfigTransaction(atx AbstractTransaction) (Transaction, error) {
+	delta := Duration(r.uint64())
+	configSize := int(r.uint16())
+	supportedSize := int(r.uint16())
+	configB := r.next(configSize)
+	supportedB := r.next(supportedSize)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	config := NewNetworkConfig()
+	if err := config.UnmarshalBinary(configB); err != nil {
+		return nil, err
+	}
+
+	supported := NewSupportedEntities()
+	if err := supported.UnmarshalBinary(supportedB); err != nil {
+		return nil, err
+	}
+
+	return &NetworkConfigTransaction{atx, delta, config, supported}, nil
+}
+
+func (r *payloadReader) blockchainUpgradeTransaction(atx AbstractTransaction) (Transaction, error) {
+	return &BlockchainUpgradeTransaction{
+		atx,
+		Duration(r.uint64()),
+		BlockChainVersion(r.uint64()),
+	}, r.err
+}
+
+func (r *payloadReader) accountLinkTransaction(atx AbstractTransaction) (Transaction, error) {
+	return &AccountLinkTransaction{
+		atx,
+		r.publicAccount(atx.NetworkType),
+		AccountLinkAction(r.uint8()),
+	}, r.err
+}
+
+func (r *payloadReader) lockFundsTransaction(atx AbstractTransaction) (Transaction, error) {
+	mosaic := r.mosaic()
+	duration := Duration(r.uint64())
+	hash := r.hash()
+
+	return &LockFundsTransaction{
+		atx,
+		mosaic,
+		duration,
+		&SignedTransaction{AggregateBonded, "", hash},
+	}, r.err
+}
+
+func (r *payloadReader) modifyMetadataTransaction(atx AbstractTransaction) (Transaction, error) {
+	metadataType := MetadataType(r.uint8())
+
+	var id interface{}
+	switch atx.Type {
+	case MetadataAddress:
+		id = r.address()
+	case MetadataMosaic:
+		id = r.mosaicId()
+	case MetadataNamespace:
+		id = r.namespaceId()
+	}
+
+	ms := make([]*MetadataModification, 0)
+	for r.err == nil && r.len() > 0 {
+		size := int(r.uint32())
+		if r.err == nil && size < SizeSize {
+			return nil, ErrPayloadSizeMismatch
+		}
+
+		mr := r.sub(size - SizeSize)
+		modificationType := MetadataModificationType(mr.uint8())
+		keySize := int(mr.uint8())
+		valueSize := int(mr.uint16())
+		key := mr.next(keySize)
+		value := mr.next(valueSize)
+		if mr.err != nil {
+			return nil, mr.err
+		}
+
+		if mr.len() != 0 {
+			return nil, ErrPayloadSizeMismatch
+		}
+
+		ms = append(ms, &MetadataModification{modificationType, string(key), string(value)})
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	mtx := ModifyMetadataTransaction{atx, metadataType, ms}
+
+	switch id := id.(type) {
+	case *Address:
+		return &ModifyMetadataAddressTransaction{mtx, id}, nil
+	case *MosaicId:
+		return &ModifyMetadataMosaicTransaction{mtx, id}, nil
+	default:
+		return &ModifyMetadataNamespaceTransaction{mtx, id.(*NamespaceId)}, nil
+	}
+}
+
+func (r *payloadReader) modifyContractTransaction(atx AbstractTransaction) (Transaction, error) {
+	durationDelta := Duration(r.uint64())
+	hash := r.hash()
+	numCustomers := int(r.uint8())
+	numExecutors := int(r.uint8())
+	numVerifiers := int(r.uint8())
+
+	return &ModifyContractTransaction{
+		atx,
+		durationDelta,
+		hash,
+		r.cosignatoryModifications(numCustomers, atx.NetworkType),
+		r.cosignatoryModifications(numExecutors, atx.NetworkType),
+		r.cosignatoryModifications(numVerifiers, atx.NetworkType),
+	}, r.err
+}
+
+func (r *payloadReader) modifyMultisigAccountTransaction(atx AbstractTransaction) (Transaction, error) {
+	minRemovalDelta := int8(r.uint8())
+	minApprovalDelta := int8(r.uint8())
+	numModifications := int(r.uint8())
+
+	return &ModifyMultisigAccountTransaction{
+		atx,
+		minApprovalDelta,
+		minRemovalDelta,
+		r.cosignatoryModifications(numModifications, atx.NetworkType),
+	}, r.err
+}
+
+func (r *payloadReader) mosaicDefinitionTransaction(atx AbstractTransaction) (Transaction, error) {
+	nonce := r.uint32()
+	mosaicId := r.mosaicId()
+	numOptionalProperties := int(r.uint8())
+	flags := uint64(r.uint8())
+	divisibility := r.uint8()
+
+	properties := make([]MosaicProperty, numOptionalProperties)
+	for i := range properties {
+		properties[i] = MosaicProperty{
+			MosaicPropertyId(r.uint8()),
+			baseInt64(r.uint64()),
+		}
+	}
+
+	return &MosaicDefinitionTransaction{
+		atx,
+		&MosaicProperties{
+			MosaicPropertiesHeader{
+				hasBits(flags, Supply_Mutable),
+				hasBits(flags, Transferable),
+				divisibility,
+			},
+			properties,
+		},
+		nonce,
+		mosaicId,
+	}, r.err
+}
+
+func (r *payloadReader) mosaicSupplyChangeTransaction(atx AbstractTransaction) (Transaction, error) {
+	assetId := r.assetId()
+	supplyType := MosaicSupplyType(r.uint8())
+
+	return &MosaicSupplyChangeTransaction{
+		atx,
+		supplyType,
+		assetId,
+		Amount(r.uint64()),
+	}, r.err
+}
+
+func (r *payloadReader) registerNamespaceTransaction(atx AbstractTransaction) (Transaction, error) {
+	tx := RegisterNamespaceTransaction{AbstractTransaction: atx}
+
+	tx.NamespaceType = NamespaceType(r.uint8())
+	if tx.NamespaceType == Root {
+		tx.Duration = Duration(r.uint64())
+	} else {
+		tx.ParentId = r.namespaceId()
+	}
+	tx.NamespaceId = r.namespaceId()
+	tx.NamspaceName = string(r.next(int(r.uint8())))
+
+	return &tx, r.err
+}
+
+func (r *payloadReader) secretLockTransaction(atx AbstractTransaction) (Transaction, error) {
+	mosaic := r.mosaic()
+	duration := Duration(r.uint64())
+	hashType := HashType(r.uint8())
+	secretB := r.next(Hash256)
+	recipient := r.address()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	secret, err := NewSecret(secretB, hashType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretLockTransaction{atx, mosaic, duration, secret, recipient}, nil
+}
+
+func (r *payloadReader) secretProofTransaction(atx AbstractTransaction) (Transaction, error) {
+	hashType := HashType(r.uint8())
+	// secret is calculated from proof, so we don't need to keep it
+	r.next(Hash256)
+	recipient := r.address()
+	proof := r.next(int(r.uint16()))
+
+	return &SecretProofTransaction{
+		atx,
+		hashType,
+		NewProofFromBytes(append([]byte{}, proof...)),
+		recipient,
+	}, r.err
+}
+
+func (r *payloadReader) transferTransaction(atx AbstractTransaction) (Transaction, error) {
+	recipient := r.address()
+	messageSize := int(r.uint16())
+	numMosaics := int(r.uint8())
+
+	if messageSize < 1 {
+		return nil, errors.New("message of transfer transaction should contain message type")
+	}
+
+	messageType := MessageType(r.uint8())
+	messageB := append([]byte{}, r.next(messageSize-1)...)
+
+	var message Message
+	switch messageType {
+	case PlainMessageType:
+		message = NewPlainMessage(string(messageB))
+	case SecureMessageType:
+		message = NewSecureMessage(messageB)
+	default:
+		return nil, errors.New("Not supported MessageType")
+	}
+
+	return &TransferTransaction{
+		atx,
+		message,
+		r.mosaics(numMosaics),
+		recipient,
+	}, r.err
+}
+
+func (r *payloadReader) prepareDriveTransaction(atx AbstractTransaction) (Transaction, error) {
+	return &PrepareDriveTransaction{
+		AbstractTransaction: atx,
+		Owner:               r.publicAccount(atx.NetworkType),
+		Duration:            Duration(r.uint64()),
+		BillingPeriod:       Duration(r.uint64()),
+		BillingPrice:        Amount(r.uint64()),
+		DriveSize:           StorageSize(r.uint64()),
+		Replicas:            r.uint16(),
+		MinReplicators:      r.uint16(),
+		PercentApprovers:    r.uint8(),
+	}, r.err
+}
+
+func (r *payloadReader) driveFileSystemTransaction(atx AbstractTransaction) (Transaction, error) {
+	driveKey := r.hexString(KeySize)
+	rootHash := r.hash()
+	xorRootHash := r.hash()
+	addActionsCount := int(r.uint16())
+	removeActionsCount := int(r.uint16())
+
+	return &DriveFileSystemTransaction{
+		atx,
+		driveKey,
+		rootHash,
+		xorRootHash.Xor(rootHash),
+		r.actions(addActionsCount),
+		r.actions(removeActionsCount),
+	}, r.err
+}
+
+func (r *payloadReader) filesDepositTransaction(atx AbstractTransaction) (Transaction, error) {
+	driveKey := r.publicAccount(atx.NetworkType)
+	files := make([]*File, r.uint16())
+	for i := range files {
+		files[i] = &File{r.hash()}
+	}
+
+	return &FilesDepositTransaction{atx, driveKey, files}, r.err
+}
+
+func (r *payloadReader) driveFilesRewardTransaction(atx AbstractTransaction) (Transaction, error) {
+	infos := make([]*UploadInfo, r.uint16())
+	for i := range infos {
+		infos[i] = &UploadInfo{
+			r.publicAccount(atx.NetworkType),
+			Amount(r.uint64()),
+		}
+	}
+
+	return &DriveFilesRewardTransaction{atx, infos}, r.err
+}
+
+func (r *payloadReader) endDriveVerificationTransaction(atx AbstractTransaction) (Transaction, error) {
+	failures := make([]*FailureVerification, 0)
+	for r.err == nil && r.len() > 0 {
+		size := int(r.uint32())
+		if size < SizeSize+KeySize || (size-SizeSize-KeySize)%Hash256 != 0 {
+			return nil, ErrPayloadSizeMismatch
+		}
+
+		if size-SizeSize > r.len() {
+			return nil, ErrPayloadTooShort
+		}
+
+		replicator := r.publicAccount(atx.NetworkType)
+		hashes := make([]*Hash, (size-SizeSize-KeySize)/Hash256)
+		for i := range hashes {
+			hashes[i] = r.hash()
+		}
+
+		failures = append(failures, &FailureVerification{replicator, hashes})
+	}
+
+	return &EndDriveVerificationTransaction{atx, failures}, r.err
+}
+
+func (r *payloadReader) startFileDownloadTransaction(atx AbstractTransaction) (Transaction, error) {
+	drive := r.publicAccount(atx.NetworkType)
+	filesCount := int(r.uint16())
+
+	return &StartFileDownloadTransaction{atx, drive, r.actions(filesCount)}, r.err
+}
+
+func (r *payloadReader) endFileDownloadTransaction(atx AbstractTransaction) (Transaction, error) {
+	recipient := r.publicAccount(atx.NetworkType)
+	token := r.hash()
+	filesCount := int(r.uint16())
+
+	return &EndFileDownloadTransaction{atx, recipient, token, r.actions(filesCount)}, r.err
+}
+
+func (r *payloadReader) endOperationTransaction(atx AbstractTransaction) (Transaction, error) {
+	mosaicsCount := int(r.uint8())
+	token := r.hash()
+	status := OperationStatus(r.uint16())
+
+	return &EndOperationTransaction{atx, r.mosaics(mosaicsCount), token, status}, r.err
+}
+
+func (r *payloadReader) deployTransaction(atx AbstractTransaction) (Transaction, error) {
+	return &DeployTransaction{
+		atx,
+		r.publicAccount(atx.NetworkType),
+		r.publicAccount(atx.NetworkType),
+		r.hash(),
+		r.uint64(),
+	}, r.err
+}
+
+func (r *payloadReader) startExecuteTransaction(atx AbstractTransaction) (Transaction, error) {
+	superContract := r.publicAccount(atx.NetworkType)
+	functionSize := int(r.uint8())
+	mosaicsCount := int(r.uint8())
+	dataSize := int(r.uint16())
+	function := string(r.next(functionSize))
+	mosaics := r.mosaics(mosaicsCount)
+
+	if dataSize%BaseInt64Size != 0 {
+		return nil, errors.New("size of function parameters is invalid")
+	}
+
+	params := make([]int64, dataSize/BaseInt64Size)
+	for i := range params {
+		params[i] = int64(r.uint64())
+	}
+
+	return &StartExecuteTransaction{atx, superContract, function, mosaics, params}, r.err
+}
@@ -0,0 +1,33 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package sdk
+
+import "testing"
+
+func FuzzParseTransactionPayload(f *testing.F) {
+	for _, constructor := range payloadTestTransactions() {
+		tx, err := constructor()
+		if err != nil {
+			f.Fatalf("constructor returned error: %s", err)
+		}
+
+		b, err := tx.Bytes()
+		if err != nil {
+			f.Fatalf("Transaction.Bytes returned error: %s", err)
+		}
+
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		tx, err := ParseTransactionPayload(payload)
+		if err == nil && tx == nil {
+			t.Fatal("ParseTransactionPayload returned neither transaction nor error")
+		}
+	})
+}
@@ -0,0 +1,470 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	payloadTestPublicKey1 = "68B3FBB18729C1FDE225C57F8CE080FA828F0067E451A3FD81FA628842B0B763"
+	payloadTestPublicKey2 = "CF893FFCC47C33E7F68AB1DB56365C156B0736824A0C1E273F9E00B8DF8F01EB"
+	payloadTestPrivateKey = "2a2b1f5d366a5dd5dc56c3c757cf4fe6c66e2787087692cf329d7a49a594658b"
+	payloadTestCosigner   = "b8afae6f4ad13a1b8aad047b488e0738a437c7389d4ff30c359ac068910c1d59"
+)
+
+var (
+	payloadTestAccount1, _ = NewAccountFromPublicKey(payloadTestPublicKey1, MijinTest)
+	payloadTestAccount2, _ = NewAccountFromPublicKey(payloadTestPublicKey2, MijinTest)
+	payloadTestAddress     = NewAddress("SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC", MijinTest)
+	payloadTestHash1       = stringToHashPanic("CF893FFCC47C33E7F68AB1DB56365C156B0736824A0C1E273F9E00B8DF8F01EB")
+	payloadTestHash2       = stringToHashPanic("68B3FBB18729C1FDE225C57F8CE080FA828F0067E451A3FD81FA628842B0B763")
+)
+
+func payloadTestNetworkConfig() (*NetworkConfig, *SupportedEntities) {
+	config := NewNetworkConfig()
+	err := config.UnmarshalBinary([]byte("[network]\n\nidentifier = mijin-test\npublicKey = " + payloadTestPublicKey1 + "\n"))
+	if err != nil {
+		panic(err)
+	}
+
+	entities := NewSupportedEntities()
+	entities.Entities[Transfer] = &Entity{Name: "Transfer", Type: Transfer, SupportedVersions: []EntityVersion{TransferVersion}}
+
+	return config, entities
+}
+
+func payloadTestTransactions() map[string]func() (Transaction, error) {
+	modifications := []*MultisigCosignatoryModification{
+		{Add, payloadTestAccount1},
+		{Remove, payloadTestAccount2},
+	}
+
+	return map[string]func() (Transaction, error){
+		"AccountPropertiesAddress": func() (Transaction, error) {
+			return NewAccountPropertiesAddressTransaction(fakeDeadline, BlockAddress, []*AccountPropertiesAddressModification{
+				{AddProperty, payloadTestAddress},
+				{RemoveProperty, payloadTestAccount1.Address},
+			}, MijinTest)
+		},
+		"AccountPropertiesMosaic": func() (Transaction, error) {
+			return NewAccountPropertiesMosaicTransaction(fakeDeadline, AllowMosaic, []*AccountPropertiesMosaicModification{
+				{AddProperty, newMosaicIdPanic(0x2A4D1D6FDA0B8D7A)},
+				{RemoveProperty, XpxNamespaceId},
+			}, MijinTest)
+		},
+		"AccountPropertiesEntityType": func() (Transaction, error) {
+			return NewAccountPropertiesEntityTypeTransaction(fakeDeadline, BlockTransaction, []*AccountPropertiesEntityTypeModification{
+				{AddProperty, Transfer},
+				{RemoveProperty, AggregateBonded},
+			}, MijinTest)
+		},
+		"AddressAlias": func() (Transaction, error) {
+			return NewAddressAliasTransaction(fakeDeadline, payloadTestAddress, XpxNamespaceId, AliasLink, MijinTest)
+		},
+		"MosaicAlias": func() (Transaction, error) {
+			return NewMosaicAliasTransaction(fakeDeadline, newMosaicIdPanic(0x2A4D1D6FDA0B8D7A), XpxNamespaceId, AliasUnlink, MijinTest)
+		},
+		"AccountLink": func() (Transaction, error) {
+			return NewAccountLinkTransaction(fakeDeadline, payloadTestAccount1, AccountUnlink, MijinTest)
+		},
+		"NetworkConfig": func() (Transaction, error) {
+			config, entities := payloadTestNetworkConfig()
+			return NewNetworkConfigTransaction(fakeDeadline, Duration(10), config, entities, MijinTest)
+		},
+		"BlockchainUpgrade": func() (Transaction, error) {
+			return NewBlockchainUpgradeTransaction(fakeDeadline, Duration(20), NewBlockChainVersion(1, 2, 3, 4), MijinTest)
+		},
+		"ModifyMetadataAddress": func() (Transaction, error) {
+			return NewModifyMetadataAddressTransaction(fakeDeadline, payloadTestAddress, []*MetadataModification{
+				{AddMetadata, "key", "value"},
+				{AddMetadata, "other", "data"},
+			}, MijinTest)
+		},
+		"ModifyMetadataMosaic": func() (Transaction, error) {
+			return NewModifyMetadataMosaicTransaction(fakeDeadline, newMosaicIdPanic(0x2A4D1D6FDA0B8D7A), []*MetadataModification{
+				{AddMetadata, "key", "value"},
+			}, MijinTest)
+		},
+		"ModifyMetadataNamespace": func() (Transaction, error) {
+			return NewModifyMetadataNamespaceTransaction(fakeDeadline, XpxNamespaceId, []*MetadataModification{
+				{AddMetadata, "key", "value"},
+			}, MijinTest)
+		},
+		"MosaicDefinition": func() (Transaction, error) {
+			return NewMosaicDefinitionTransaction(fakeDeadline, 7, payloadTestPublicKey1, NewMosaicProperties(true, true, 6, Duration(1000)), MijinTest)
+		},
+		"MosaicSupplyChange": func() (Transaction, error) {
+			return NewMosaicSupplyChangeTransaction(fakeDeadline, newMosaicIdPanic(0x2A4D1D6FDA0B8D7A), Increase, Duration(100), MijinTest)
+		},
+		"TransferPlainMessage": func() (Transaction, error) {
+			return NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{Xpx(10), Xem(20)}, NewPlainMessage("test-message"), MijinTest)
+		},
+		"TransferSecureMessage": func() (Transaction, error) {
+			return NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{}, NewSecureMessage([]byte{1, 2, 3}), MijinTest)
+		},
+		"ModifyMultisigAccount": func() (Transaction, error) {
+			return NewModifyMultisigAccountTransaction(fakeDeadline, 2, 1, modifications, MijinTest)
+		},
+		"ModifyContract": func() (Transaction, error) {
+			return NewModifyContractTransaction(fakeDeadline, Duration(2), payloadTestHash1, modifications, modifications[:1], modifications[1:], MijinTest)
+		},
+		"RegisterRootNamespace": func() (Transaction, error) {
+			return NewRegisterRootNamespaceTransaction(fakeDeadline, "newnamespace", Duration(10000), MijinTest)
+		},
+		"RegisterSubNamespace": func() (Transaction, error) {
+			return NewRegisterSubNamespaceTransaction(fakeDeadline, "subnamespace", XpxNamespaceId, MijinTest)
+		},
+		"LockFunds": func() (Transaction, error) {
+			return NewLockFundsTransaction(fakeDeadline, Xpx(10000000), Duration(100), &SignedTransaction{AggregateBonded, "payload", payloadTestHash1}, MijinTest)
+		},
+		"SecretLock": func() (Transaction, error) {
+			secret, err := NewSecret(payloadTestHash1[:], SHA3_256)
+			if err != nil {
+				return nil, err
+			}
+			return NewSecretLockTransaction(fakeDeadline, Xpx(10), Duration(100), secret, payloadTestAddress, MijinTest)
+		},
+		"SecretProof": func() (Transaction, error) {
+			return NewSecretProofTransaction(fakeDeadline, SHA_256, NewProofFromString("proof"), payloadTestAddress, MijinTest)
+		},
+		"PrepareDrive": func() (Transaction, error) {
+			return NewPrepareDriveTransaction(fakeDeadline, payloadTestAccount1, Duration(100), Duration(10), Amount(50), StorageSize(1000), 3, 2, 66, MijinTest)
+		},
+		"JoinToDrive": func() (Transaction, error) {
+			return NewJoinToDriveTransaction(fakeDeadline, payloadTestAccount1, MijinTest)
+		},
+		"DriveFileSystem": func() (Transaction, error) {
+			return NewDriveFileSystemTransaction(fakeDeadline, payloadTestPublicKey1, payloadTestHash1, payloadTestHash2,
+				[]*Action{{payloadTestHash1, StorageSize(10)}, {payloadTestHash2, StorageSize(20)}},
+				[]*Action{{payloadTestHash2, StorageSize(30)}},
+				MijinTest)
+		},
+		"SuperContractFileSystem": func() (Transaction, error) {
+			return NewSuperContractFileSystemTransaction(fakeDeadline, payloadTestPublicKey1, payloadTestHash1, payloadTestHash2,
+				[]*Action{{payloadTestHash1, StorageSize(10)}},
+				[]*Action{},
+				MijinTest)
+		},
+		"FilesDeposit": func() (Transaction, error) {
+			return NewFilesDepositTransaction(fakeDeadline, payloadTestAccount1, []*File{{payloadTestHash1}, {payloadTestHash2}}, MijinTest)
+		},
+		"EndDrive": func() (Transaction, error) {
+			return NewEndDriveTransaction(fakeDeadline, payloadTestAccount1, MijinTest)
+		},
+		"DriveFilesReward": func() (Transaction, error) {
+			return NewDriveFilesRewardTransaction(fakeDeadline, []*UploadInfo{
+				{payloadTestAccount1, Amount(10)},
+				{payloadTestAccount2, Amount(20)},
+			}, MijinTest)
+		},
+		"StartDriveVerification": func() (Transaction, error) {
+			return NewStartDriveVerificationTransaction(fakeDeadline, payloadTestAccount1, MijinTest)
+		},
+		"EndDriveVerification": func() (Transaction, error) {
+			return NewEndDriveVerificationTransaction(fakeDeadline, []*FailureVerification{
+				{payloadTestAccount1, []*Hash{payloadTestHash1, payloadTestHash2}},
+				{payloadTestAccount2, []*Hash{payloadTestHash2}},
+			}, MijinTest)
+		},
+		"StartFileDownload": func() (Transaction, error) {
+			return NewStartFileDownloadTransaction(fakeDeadline, payloadTestAccount1, []*DownloadFile{
+				{payloadTestHash1, StorageSize(10)},
+			}, MijinTest)
+		},
+		"EndFileDownload": func() (Transaction, error) {
+			return NewEndFileDownloadTransaction(fakeDeadline, payloadTestAccount1, payloadTestHash1, []*DownloadFile{
+				{payloadTestHash1, StorageSize(10)},
+				{payloadTestHash2, StorageSize(20)},
+			}, MijinTest)
+		},
+		"AddExchangeOffer": func() (Transaction, error) {
+			return NewAddExchangeOfferTransaction(fakeDeadline, []*AddOffer{
+				{Offer{SellOffer, Xpx(10), Amount(20)}, Duration(100)},
+				{Offer{BuyOffer, newMosaicPanic(newMosaicIdPanic(0x2A4D1D6FDA0B8D7A), 30), Amount(40)}, Duration(200)},
+			}, MijinTest)
+		},
+		"ExchangeOffer": func() (Transaction, error) {
+			return NewExchangeOfferTransaction(fakeDeadline, []*ExchangeConfirmation{
+				{Offer{SellOffer, newMosaicPanic(newMosaicIdPanic(0x2A4D1D6FDA0B8D7A), 10), Amount(20)}, payloadTestAccount1},
+			}, MijinTest)
+		},
+		"RemoveExchangeOffer": func() (Transaction, error) {
+			return NewRemoveExchangeOfferTransaction(fakeDeadline, []*RemoveOffer{
+				{SellOffer, newMosaicIdPanic(0x2A4D1D6FDA0B8D7A)},
+				{BuyOffer, newMosaicIdPanic(0x1B4D1D6FDA0B8D7A)},
+			}, MijinTest)
+		},
+		"Deploy": func() (Transaction, error) {
+			return NewDeployTransaction(fakeDeadline, payloadTestAccount1, payloadTestAccount2, payloadTestHash1, 3, MijinTest)
+		},
+		"StartExecute": func() (Transaction, error) {
+			return NewStartExecuteTransaction(fakeDeadline, payloadTestAccount1, []*Mosaic{Xpx(10)}, "main", []int64{1, -2}, MijinTest)
+		},
+		"OperationIdentify": func() (Transaction, error) {
+			return NewOperationIdentifyTransaction(fakeDeadline, payloadTestHash1, MijinTest)
+		},
+		"EndOperation": func() (Transaction, error) {
+			return NewEndOperationTransaction(fakeDeadline, []*Mosaic{Xpx(10)}, payloadTestHash1, Success, MijinTest)
+		},
+		"EndExecute": func() (Transaction, error) {
+			return NewEndExecuteTransaction(fakeDeadline, []*Mosaic{Xpx(10), Xem(5)}, payloadTestHash2, Failure, MijinTest)
+		},
+		"Deactivate": func() (Transaction, error) {
+			return NewDeactivateTransaction(fakeDeadline, payloadTestPublicKey1, payloadTestPublicKey2, MijinTest)
+		},
+	}
+}
+
+func TestParseTransactionPayload_RoundTrip(t *testing.T) {
+	for name, constructor := range payloadTestTransactions() {
+		t.Run(name, func(t *testing.T) {
+			tx, err := constructor()
+			assert.Nilf(t, err, "constructor returned error: %s", err)
+
+			b, err := tx.Bytes()
+			assert.Nilf(t, err, "Transaction.Bytes returned error: %s", err)
+
+			parsed, err := ParseTransactionPayload(b)
+			assert.Nilf(t, err, "ParseTransactionPayload returned error: %s", err)
+
+			pb, err := parsed.Bytes()
+			assert.Nilf(t, err, "Transaction.Bytes of parsed transaction returned error: %s", err)
+			assert.Equal(t, b, pb)
+
+			expected, actual := tx.GetAbstractTransaction(), parsed.GetAbstractTransaction()
+			assert.Equal(t, expected.Type, actual.Type)
+			assert.Equal(t, expected.Version, actual.Version)
+			assert.Equal(t, expected.NetworkType, actual.NetworkType)
+			assert.Equal(t, expected.Deadline.ToBlockchainTimestamp(), actual.Deadline.ToBlockchainTimestamp())
+			assert.Nil(t, actual.Signer)
+			assert.Empty(t, actual.Signature)
+		})
+	}
+}
+
+func TestParseTransactionPayload_Aggregate(t *testing.T) {
+	var inner []Transaction
+	for _, constructor := range payloadTestTransactions() {
+		tx, err := constructor()
+		assert.Nilf(t, err, "constructor returned error: %s", err)
+
+		tx.GetAbstractTransaction().Signer = payloadTestAccount2
+		inner = append(inner, tx)
+	}
+
+	for _, constructor := range []func(*Deadline, []Transaction, NetworkType) (*AggregateTransaction, error){
+		NewCompleteAggregateTransaction,
+		NewBondedAggregateTransaction,
+	} {
+		atx, err := constructor(fakeDeadline, inner, MijinTest)
+		assert.Nilf(t, err, "aggregate constructor returned error: %s", err)
+
+		b, err := atx.Bytes()
+		assert.Nilf(t, err, "AggregateTransaction.Bytes returned error: %s", err)
+
+		parsed, err := ParseTransactionPayload(b)
+		assert.Nilf(t, err, "ParseTransactionPayload returned error: %s", err)
+
+		patx, ok := parsed.(*AggregateTransaction)
+		assert.True(t, ok)
+		assert.Equal(t, atx.Type, patx.Type)
+		assert.Len(t, patx.InnerTransactions, len(inner))
+		assert.Empty(t, patx.Cosignatures)
+		assert.True(t, CompareInnerTransaction(atx.InnerTransactions, patx.InnerTransactions))
+
+		for i, itx := range patx.InnerTransactions {
+			assert.Equal(t, inner[i].GetAbstractTransaction().Type, itx.GetAbstractTransaction().Type)
+			assert.Equal(t, payloadTestAccount2, itx.GetAbstractTransaction().Signer)
+			assert.Equal(t, patx.Deadline, itx.GetAbstractTransaction().Deadline)
+		}
+
+		pb, err := parsed.Bytes()
+		assert.Nilf(t, err, "Transaction.Bytes of parsed transaction returned error: %s", err)
+		assert.Equal(t, b, pb)
+	}
+}
+
+func TestParseSignedTransaction(t *testing.T) {
+	acc, err := NewAccountFromPrivateKey(payloadTestPrivateKey, MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	tx, err := NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{Xpx(10)}, NewPlainMessage("test"), MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx, err := acc.Sign(tx)
+	assert.Nilf(t, err, "Account.Sign returned error: %s", err)
+
+	parsed, err := ParseSignedTransaction(stx)
+	assert.Nilf(t, err, "ParseSignedTransaction returned error: %s", err)
+
+	atx := parsed.GetAbstractTransaction()
+	assert.Equal(t, acc.PublicAccount.Address, atx.Signer.Address)
+	assert.Equal(t, strings.ToUpper(acc.PublicAccount.PublicKey), atx.Signer.PublicKey)
+	assert.Equal(t, stx.Payload[SizeSize*2:(SizeSize+SignatureSize)*2], atx.Signature)
+	assert.Equal(t, stx.Hash, atx.TransactionHash)
+
+	restx, err := acc.Sign(parsed)
+	assert.Nilf(t, err, "Account.Sign returned error: %s", err)
+	assert.Equal(t, stx, restx)
+}
+
+func TestParseSignedTransaction_AggregateWithCosignatures(t *testing.T) {
+	acc1, err := NewAccountFromPrivateKey(payloadTestPrivateKey, MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	acc2, err := NewAccountFromPrivateKey(payloadTestCosigner, MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	ttx, err := NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{}, NewPlainMessage("test-message"), MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+	ttx.ToAggregate(acc2.PublicAccount)
+
+	ltx, err := NewLockFundsTransaction(fakeDeadline, Xpx(10), Duration(100), &SignedTransaction{AggregateBonded, "", payloadTestHash1}, MijinTest)
+	assert.Nilf(t, err, "NewLockFundsTransaction returned error: %s", err)
+	ltx.ToAggregate(acc1.PublicAccount)
+
+	atx, err := NewBondedAggregateTransaction(fakeDeadline, []Transaction{ttx, ltx}, MijinTest)
+	assert.Nilf(t, err, "NewBondedAggregateTransaction returned error: %s", err)
+
+	stx, err := acc1.SignWithCosignatures(atx, []*Account{acc2})
+	assert.Nilf(t, err, "Account.SignWithCosignatures returned error: %s", err)
+
+	parsed, err := ParseSignedTransaction(stx)
+	assert.Nilf(t, err, "ParseSignedTransaction returned error: %s", err)
+
+	patx := parsed.(*AggregateTransaction)
+	assert.Equal(t, stx.Hash, patx.TransactionHash)
+	assert.Len(t, patx.InnerTransactions, 2)
+	for _, itx := range patx.InnerTransactions {
+		assert.Nil(t, itx.GetAbstractTransaction().TransactionHash)
+		assert.Equal(t, stx.Hash, itx.GetAbstractTransaction().AggregateHash)
+	}
+
+	assert.Len(t, patx.Cosignatures, 1)
+	assert.Equal(t, acc2.PublicAccount.Address, patx.Cosignatures[0].Signer.Address)
+
+	payload, err := hex.DecodeString(stx.Payload)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.ToUpper(hex.EncodeToString(payload[len(payload)-SignatureSize:])), patx.Cosignatures[0].Signature)
+
+	restx, err := acc1.SignWithCosignatures(patx, []*Account{acc2})
+	assert.Nilf(t, err, "Account.SignWithCosignatures returned error: %s", err)
+	assert.Equal(t, strings.ToUpper(stx.Payload), strings.ToUpper(restx.Payload))
+}
+
+func TestTransactionPayload(t *testing.T) {
+	acc1, err := NewAccountFromPrivateKey(payloadTestPrivateKey, MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	acc2, err := NewAccountFromPrivateKey(payloadTestCosigner, MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	ttx, err := NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{Xpx(10)}, NewPlainMessage("test-message"), MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx, err := acc1.Sign(ttx)
+	assert.Nilf(t, err, "Account.Sign returned error: %s", err)
+
+	ttx.ToAggregate(acc2.PublicAccount)
+
+	atx, err := NewCompleteAggregateTransaction(fakeDeadline, []Transaction{ttx}, MijinTest)
+	assert.Nilf(t, err, "NewCompleteAggregateTransaction returned error: %s", err)
+
+	astx, err := acc1.SignWithCosignatures(atx, []*Account{acc2})
+	assert.Nilf(t, err, "Account.SignWithCosignatures returned error: %s", err)
+
+	for _, signed := range []*SignedTransaction{stx, astx} {
+		parsed, err := ParseSignedTransaction(signed)
+		assert.Nilf(t, err, "ParseSignedTransaction returned error: %s", err)
+
+		payload, err := TransactionPayload(parsed)
+		assert.Nilf(t, err, "TransactionPayload returned error: %s", err)
+		assert.Equal(t, strings.ToUpper(signed.Payload), strings.ToUpper(hex.EncodeToString(payload)))
+	}
+}
+
+func TestParseTransactionPayload_Errors(t *testing.T) {
+	tx, err := NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{Xpx(10)}, NewPlainMessage("test"), MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	b, err := tx.Bytes()
+	assert.Nilf(t, err, "TransferTransaction.Bytes returned error: %s", err)
+
+	_, err = ParseTransactionPayload(b[:len(b)-1])
+	assert.Equal(t, ErrPayloadTooShort, err)
+
+	_, err = ParseTransactionPayload(append(b, 0))
+	assert.Equal(t, ErrPayloadSizeMismatch, err)
+
+	unsupported := append([]byte{}, b...)
+	unsupported[SizeSize+SignatureSize+SignerSize+VersionSize] = 0
+	_, err = ParseTransactionPayload(unsupported)
+	assert.Equal(t, ErrUnsupportedPayloadType, err)
+
+	_, err = ParseSignedTransaction(&SignedTransaction{AggregateBonded, hex.EncodeToString(b), nil})
+	assert.NotNil(t, err)
+}
+
+func payloadTestBytes(t *testing.T, name string) []byte {
+	tx, err := payloadTestTransactions()[name]()
+	assert.Nilf(t, err, "constructor returned error: %s", err)
+
+	b, err := tx.Bytes()
+	assert.Nilf(t, err, "Transaction.Bytes returned error: %s", err)
+
+	return b
+}
+
+func TestParseTransactionPayload_Truncated(t *testing.T) {
+	for name := range payloadTestTransactions() {
+		b := payloadTestBytes(t, name)
+
+		for n := 0; n < len(b); n++ {
+			_, err := ParseTransactionPayload(b[:n])
+			assert.NotNilf(t, err, "%s truncated to %d bytes is parsed", name, n)
+
+			// size of transaction matches truncated payload, so inner sizes are checked too
+			if n >= SizeSize {
+				p := append([]byte{}, b[:n]...)
+				binary.LittleEndian.PutUint32(p, uint32(n))
+				_, _ = ParseTransactionPayload(p)
+			}
+		}
+	}
+}
+
+func TestParseTransactionPayload_Malformed(t *testing.T) {
+	metadata := payloadTestBytes(t, "ModifyMetadataAddress")
+	// size of the first modification doesn't include even itself
+	binary.LittleEndian.PutUint32(metadata[TransactionHeaderSize+1+AddressSize:], 1)
+	_, err := ParseTransactionPayload(metadata)
+	assert.Equal(t, ErrPayloadSizeMismatch, err)
+
+	verification := payloadTestBytes(t, "EndDriveVerification")
+	binary.LittleEndian.PutUint32(verification[TransactionHeaderSize:], uint32(SizeSize+KeySize+Hash256*0x3ffffff))
+	_, err = ParseTransactionPayload(verification)
+	assert.Equal(t, ErrPayloadTooShort, err)
+
+	inner, err := NewTransferTransaction(fakeDeadline, payloadTestAddress, []*Mosaic{Xpx(10)}, NewPlainMessage("test"), MijinTest)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+	inner.ToAggregate(payloadTestAccount1)
+
+	agtx, err := NewCompleteAggregateTransaction(fakeDeadline, []Transaction{inner}, MijinTest)
+	assert.Nilf(t, err, "NewCompleteAggregateTransaction returned error: %s", err)
+
+	aggregate, err := agtx.Bytes()
+	assert.Nilf(t, err, "AggregateTransaction.Bytes returned error: %s", err)
+
+	// payload size of aggregate is much greater than payload
+	binary.LittleEndian.PutUint32(aggregate[TransactionHeaderSize:], 0x7fffffff)
+	_, err = ParseTransactionPayload(aggregate)
+	assert.Equal(t, ErrPayloadTooShort, err)
+}
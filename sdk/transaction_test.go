@@ -821,7 +821,7 @@ func TestLockFundsTransactionSigning(t *testing.T) {
 
 	assert.Nilf(t, err, "NewLockFundsTransaction returned error: %s", err)
 
-	b, err := signTransactionWith(tx, acc)
+	b, err := signTransactionWith(tx, acc, acc.generationHash)
 
 	assert.Nilf(t, err, "signTransactionWith returned error: %s", err)
 	assert.Equal(t, lockFundsTransactionSigningCorr, b.Payload)
@@ -938,7 +938,7 @@ func TestSecretProofTransactionSigning(t *testing.T) {
 
 	assert.Nilf(t, err, "NewSecretProofTransaction returned error: %s", err)
 
-	b, err := signTransactionWith(tx, acc)
+	b, err := signTransactionWith(tx, acc, acc.generationHash)
 
 	assert.Nilf(t, err, "signTransactionWith returned error: %s", err)
 	assert.Equal(t, secretProofTransactionSigningCorr, b.Payload)
@@ -0,0 +1,1029 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sections and keys of network config which contain limits checked by Validate
+const (
+	chainConfigSection           = "chain"
+	transferPluginSection        = "plugin:catapult.plugins.transfer"
+	aggregatePluginSection       = "plugin:catapult.plugins.aggregate"
+	mosaicPluginSection          = "plugin:catapult.plugins.mosaic"
+	namespacePluginSection       = "plugin:catapult.plugins.namespace"
+	multisigPluginSection        = "plugin:catapult.plugins.multisig"
+	propertyPluginSection        = "plugin:catapult.plugins.property"
+	metadataPluginSection        = "plugin:catapult.plugins.metadata"
+	lockHashPluginSection        = "plugin:catapult.plugins.lockhash"
+	lockSecretPluginSection      = "plugin:catapult.plugins.locksecret"
+	upgradePluginSection         = "plugin:catapult.plugins.upgrade"
+	servicePluginSection         = "plugin:catapult.plugins.service"
+	exchangePluginSection        = "plugin:catapult.plugins.exchange"
+	blockGenerationTargetTimeKey = "blockGenerationTargetTime"
+)
+
+// checks passed Transaction against rules of network before it is announced.
+// limits are taken from cfg returned by NetworkService.GetNetworkConfig, rules which need a limit
+// are skipped if cfg is nil or doesn't contain the limit.
+// returns *ValidationError with violated rule and path to the invalid field
+func Validate(tx Transaction, cfg *BlockchainConfig) error {
+	if isNil(tx) {
+		return ErrNilTransaction
+	}
+
+	v := &validator{limits: networkLimits{cfg}}
+
+	return v.validate(tx, false)
+}
+
+type validator struct {
+	limits     networkLimits
+	entityType EntityType
+	prefix     string
+}
+
+func (v *validator) fail(field string, err error) error {
+	return &ValidationError{
+		EntityType: v.entityType,
+		Field:      v.prefix + field,
+		Err:        err,
+	}
+}
+
+func (v *validator) validate(tx Transaction, embedded bool) error {
+	abs := tx.GetAbstractTransaction()
+	v.entityType = abs.Type
+
+	if err := v.abstract(abs, embedded); err != nil {
+		return err
+	}
+
+	switch tx := tx.(type) {
+	case *AccountPropertiesAddressTransaction:
+		return v.addressProperties(tx)
+	case *AccountPropertiesMosaicTransaction:
+		return v.mosaicProperties(tx)
+	case *AccountPropertiesEntityTypeTransaction:
+		return v.entityTypeProperties(tx)
+	case *AddressAliasTransaction:
+		if err := v.alias(&tx.AliasTransaction); err != nil {
+			return err
+		}
+		return v.required("Address", tx.Address == nil)
+	case *MosaicAliasTransaction:
+		if err := v.alias(&tx.AliasTransaction); err != nil {
+			return err
+		}
+		return v.required("MosaicId", tx.MosaicId == nil)
+	case *AccountLinkTransaction:
+		return v.required("RemoteAccount", tx.RemoteAccount == nil)
+	case *NetworkConfigTransaction:
+		return v.networkConfig(tx)
+	case *BlockchainUpgradeTransaction:
+		return v.minBlocks("UpgradePeriod", tx.UpgradePeriod, upgradePluginSection, "minUpgradePeriod")
+	case *AggregateTransaction:
+		return v.aggregate(tx)
+	case *ModifyMetadataAddressTransaction:
+		if err := v.required("Address", tx.Address == nil); err != nil {
+			return err
+		}
+		return v.metadata(&tx.ModifyMetadataTransaction)
+	case *ModifyMetadataMosaicTransaction:
+		if err := v.required("MosaicId", tx.MosaicId == nil); err != nil {
+			return err
+		}
+		return v.metadata(&tx.ModifyMetadataTransaction)
+	case *ModifyMetadataNamespaceTransaction:
+		if err := v.required("NamespaceId", tx.NamespaceId == nil); err != nil {
+			return err
+		}
+		return v.metadata(&tx.ModifyMetadataTransaction)
+	case *MosaicDefinitionTransaction:
+		return v.mosaicDefinition(tx)
+	case *MosaicSupplyChangeTransaction:
+		return v.mosaicSupplyChange(tx)
+	case *TransferTransaction:
+		return v.transfer(tx)
+	case *ModifyMultisigAccountTransaction:
+		return v.modifyMultisig(tx)
+	case *ModifyContractTransaction:
+		return v.modifyContract(tx)
+	case *RegisterNamespaceTransaction:
+		return v.registerNamespace(tx)
+	case *LockFundsTransaction:
+		return v.lockFunds(tx)
+	case *SecretLockTransaction:
+		return v.secretLock(tx)
+	case *SecretProofTransaction:
+		return v.secretProof(tx)
+	case *PrepareDriveTransaction:
+		return v.prepareDrive(tx)
+	case *JoinToDriveTransaction:
+		return v.required("DriveKey", tx.DriveKey == nil)
+	case *DriveFileSystemTransaction:
+		return v.driveFileSystem(tx)
+	case *FilesDepositTransaction:
+		return v.filesDeposit(tx)
+	case *EndDriveTransaction:
+		return v.required("DriveKey", tx.DriveKey == nil)
+	case *DriveFilesRewardTransaction:
+		return v.driveFilesReward(tx)
+	case *StartDriveVerificationTransaction:
+		return v.required("DriveKey", tx.DriveKey == nil)
+	case *EndDriveVerificationTransaction:
+		return v.endDriveVerification(tx)
+	case *StartFileDownloadTransaction:
+		if err := v.required("Drive", tx.Drive == nil); err != nil {
+			return err
+		}
+		return v.downloadFiles("Files", tx.Files)
+	case *EndFileDownloadTransaction:
+		if err := v.required("Recipient", tx.Recipient == nil); err != nil {
+			return err
+		}
+		if err := v.required("OperationToken", tx.OperationToken == nil); err != nil {
+			return err
+		}
+		return v.downloadFiles("Files", tx.Files)
+	case *DeployTransaction:
+		return v.deploy(tx)
+	case *StartExecuteTransaction:
+		return v.startExecute(tx)
+	case *DeactivateTransaction:
+		if err := v.nonEmpty("SuperContract", len(tx.SuperContract)); err != nil {
+			return err
+		}
+		return v.nonEmpty("DriveKey", len(tx.DriveKey))
+	case *OperationIdentifyTransaction:
+		return v.required("OperationHash", tx.OperationHash == nil)
+	case *EndOperationTransaction:
+		if err := v.required("OperationToken", tx.OperationToken == nil); err != nil {
+			return err
+		}
+		return v.mosaics("UsedMosaics", tx.UsedMosaics)
+	case *AddExchangeOfferTransaction:
+		return v.addExchangeOffer(tx)
+	case *ExchangeOfferTransaction:
+		return v.exchangeOffer(tx)
+	case *RemoveExchangeOfferTransaction:
+		return v.removeExchangeOffer(tx)
+	}
+
+	return nil
+}
+
+// checks deadline and entity version, deadline of embedded transactions is ignored by network
+func (v *validator) abstract(abs *AbstractTransaction, embedded bool) error {
+	if err := v.supported(abs); err != nil {
+		return err
+	}
+
+	if embedded {
+		return nil
+	}
+
+	if abs.Deadline == nil {
+		return v.fail("Deadline", ErrRequiredField)
+	}
+
+	left := time.Until(abs.Deadline.Time)
+	if left <= 0 {
+		return v.fail("Deadline", ErrDeadlineExpired)
+	}
+
+	if lifetime, ok := v.limits.duration(chainConfigSection, "maxTransactionLifetime"); ok && left > lifetime {
+		return v.fail("Deadline", ErrDeadlineTooFar)
+	}
+
+	return nil
+}
+
+func (v *validator) supported(abs *AbstractTransaction) error {
+	if v.limits.cfg == nil || v.limits.cfg.SupportedEntityVersions == nil {
+		return nil
+	}
+
+	entity, ok := v.limits.cfg.SupportedEntityVersions.Entities[abs.Type]
+	if !ok {
+		return v.fail("Type", ErrUnsupportedEntity)
+	}
+
+	for _, version := range entity.SupportedVersions {
+		if version == abs.Version {
+			return nil
+		}
+	}
+
+	return v.fail("Version", ErrUnsupportedEntity)
+}
+
+func (v *validator) addressProperties(tx *AccountPropertiesAddressTransaction) error {
+	if err := v.modificationsCount(len(tx.Modifications), propertyPluginSection, "maxPropertyValues"); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, m := range tx.Modifications {
+		field := fmt.Sprintf("Modifications[%d]", i)
+		if m == nil || m.Address == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, m.Address.Address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) mosaicProperties(tx *AccountPropertiesMosaicTransaction) error {
+	if err := v.modificationsCount(len(tx.Modifications), propertyPluginSection, "maxPropertyValues"); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, m := range tx.Modifications {
+		field := fmt.Sprintf("Modifications[%d]", i)
+		if m == nil || isNil(m.AssetId) {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, assetIdKey(m.AssetId)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) entityTypeProperties(tx *AccountPropertiesEntityTypeTransaction) error {
+	if err := v.modificationsCount(len(tx.Modifications), propertyPluginSection, "maxPropertyValues"); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, m := range tx.Modifications {
+		field := fmt.Sprintf("Modifications[%d]", i)
+		if m == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, m.EntityType.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) alias(tx *AliasTransaction) error {
+	return v.required("NamespaceId", tx.NamespaceId == nil)
+}
+
+func (v *validator) networkConfig(tx *NetworkConfigTransaction) error {
+	if err := v.required("NetworkConfig", tx.NetworkConfig == nil); err != nil {
+		return err
+	}
+
+	return v.required("SupportedEntities", tx.SupportedEntities == nil)
+}
+
+func (v *validator) aggregate(tx *AggregateTransaction) error {
+	if err := v.nonEmpty("InnerTransactions", len(tx.InnerTransactions)); err != nil {
+		return err
+	}
+
+	if err := v.count("InnerTransactions", len(tx.InnerTransactions), aggregatePluginSection, "maxTransactionsPerAggregate"); err != nil {
+		return err
+	}
+
+	if err := v.count("Cosignatures", len(tx.Cosignatures), aggregatePluginSection, "maxCosignaturesPerAggregate"); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, c := range tx.Cosignatures {
+		field := fmt.Sprintf("Cosignatures[%d]", i)
+		if c == nil || c.Signer == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, strings.ToUpper(c.Signer.PublicKey)); err != nil {
+			return err
+		}
+	}
+
+	for i, inner := range tx.InnerTransactions {
+		field := fmt.Sprintf("InnerTransactions[%d]", i)
+		if isNil(inner) {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if _, ok := inner.(*AggregateTransaction); ok {
+			return v.fail(field, ErrUnsupportedEntity)
+		}
+
+		innerValidator := &validator{limits: v.limits, prefix: v.prefix + field + "."}
+		if err := innerValidator.validate(inner, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) metadata(tx *ModifyMetadataTransaction) error {
+	if err := v.modificationsCount(len(tx.Modifications), metadataPluginSection, "maxFields"); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, m := range tx.Modifications {
+		field := fmt.Sprintf("Modifications[%d]", i)
+		if m == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.nonEmpty(field+".Key", len(m.Key)); err != nil {
+			return err
+		}
+
+		if err := v.size(field+".Key", len(m.Key), metadataPluginSection, "maxFieldKeySize"); err != nil {
+			return err
+		}
+
+		if err := v.size(field+".Value", len(m.Value), metadataPluginSection, "maxFieldValueSize"); err != nil {
+			return err
+		}
+
+		if err := v.unique(keys, field, m.Key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) mosaicDefinition(tx *MosaicDefinitionTransaction) error {
+	if err := v.required("MosaicId", tx.MosaicId == nil); err != nil {
+		return err
+	}
+
+	if err := v.required("MosaicProperties", tx.MosaicProperties == nil); err != nil {
+		return err
+	}
+
+	if limit, ok := v.limits.uint(mosaicPluginSection, "maxMosaicDivisibility"); ok && uint64(tx.Divisibility) > limit {
+		return v.fail("MosaicProperties.Divisibility", ErrValueOutOfRange)
+	}
+
+	for _, p := range tx.OptionalProperties {
+		if p.Id == MosaicPropertyDurationId {
+			if err := v.maxBlocks("MosaicProperties.Duration", p.Value, mosaicPluginSection, "maxMosaicDuration"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) mosaicSupplyChange(tx *MosaicSupplyChangeTransaction) error {
+	if err := v.required("AssetId", isNil(tx.AssetId)); err != nil {
+		return err
+	}
+
+	if tx.Delta == 0 {
+		return v.fail("Delta", ErrValueOutOfRange)
+	}
+
+	return nil
+}
+
+func (v *validator) transfer(tx *TransferTransaction) error {
+	if err := v.required("Recipient", tx.Recipient == nil); err != nil {
+		return err
+	}
+
+	if tx.Message != nil {
+		if err := v.size("Message", tx.MessageSize(), transferPluginSection, "maxMessageSize"); err != nil {
+			return err
+		}
+	}
+
+	if err := v.count("Mosaics", len(tx.Mosaics), transferPluginSection, "maxMosaicsSize"); err != nil {
+		return err
+	}
+
+	return v.mosaics("Mosaics", tx.Mosaics)
+}
+
+func (v *validator) modifyMultisig(tx *ModifyMultisigAccountTransaction) error {
+	if len(tx.Modifications) == 0 && tx.MinApprovalDelta == 0 && tx.MinRemovalDelta == 0 {
+		return v.fail("Modifications", ErrEmptyField)
+	}
+
+	if err := v.count("Modifications", len(tx.Modifications), multisigPluginSection, "maxCosignersPerAccount"); err != nil {
+		return err
+	}
+
+	return v.cosignatoryModifications("Modifications", tx.Modifications)
+}
+
+func (v *validator) modifyContract(tx *ModifyContractTransaction) error {
+	if err := v.required("Hash", tx.Hash == nil); err != nil {
+		return err
+	}
+
+	if err := v.cosignatoryModifications("Customers", tx.Customers); err != nil {
+		return err
+	}
+
+	if err := v.cosignatoryModifications("Executors", tx.Executors); err != nil {
+		return err
+	}
+
+	return v.cosignatoryModifications("Verifiers", tx.Verifiers)
+}
+
+func (v *validator) registerNamespace(tx *RegisterNamespaceTransaction) error {
+	if err := v.nonEmpty("NamspaceName", len(tx.NamspaceName)); err != nil {
+		return err
+	}
+
+	if err := v.size("NamspaceName", len(tx.NamspaceName), namespacePluginSection, "maxNameSize"); err != nil {
+		return err
+	}
+
+	if !regValidNamespace.MatchString(tx.NamspaceName) {
+		return v.fail("NamspaceName", ErrInvalidNamespaceName)
+	}
+
+	if tx.NamespaceType != Root {
+		return v.required("ParentId", tx.ParentId == nil)
+	}
+
+	if tx.Duration == 0 {
+		return v.fail("Duration", ErrValueOutOfRange)
+	}
+
+	return v.maxBlocks("Duration", tx.Duration, namespacePluginSection, "maxNamespaceDuration")
+}
+
+func (v *validator) lockFunds(tx *LockFundsTransaction) error {
+	if err := v.required("Mosaic", tx.Mosaic == nil || isNil(tx.Mosaic.AssetId)); err != nil {
+		return err
+	}
+
+	if err := v.required("SignedTransaction", tx.SignedTransaction == nil || tx.SignedTransaction.Hash == nil); err != nil {
+		return err
+	}
+
+	if tx.SignedTransaction.EntityType != AggregateBonded {
+		return v.fail("SignedTransaction", ErrUnsupportedEntity)
+	}
+
+	if locked, ok := v.limits.uint(lockHashPluginSection, "lockedFundsPerAggregate"); ok && uint64(tx.Mosaic.Amount) != locked {
+		return v.fail("Mosaic.Amount", ErrValueOutOfRange)
+	}
+
+	if tx.Duration == 0 {
+		return v.fail("Duration", ErrValueOutOfRange)
+	}
+
+	return v.maxBlocks("Duration", tx.Duration, lockHashPluginSection, "maxHashLockDuration")
+}
+
+func (v *validator) secretLock(tx *SecretLockTransaction) error {
+	if err := v.required("Mosaic", tx.Mosaic == nil || isNil(tx.Mosaic.AssetId)); err != nil {
+		return err
+	}
+
+	if err := v.required("Secret", tx.Secret == nil); err != nil {
+		return err
+	}
+
+	if err := v.required("Recipient", tx.Recipient == nil); err != nil {
+		return err
+	}
+
+	if tx.Mosaic.Amount == 0 {
+		return v.fail("Mosaic.Amount", ErrValueOutOfRange)
+	}
+
+	if tx.Duration == 0 {
+		return v.fail("Duration", ErrValueOutOfRange)
+	}
+
+	return v.maxBlocks("Duration", tx.Duration, lockSecretPluginSection, "maxSecretLockDuration")
+}
+
+func (v *validator) secretProof(tx *SecretProofTransaction) error {
+	if err := v.required("Proof", tx.Proof == nil); err != nil {
+		return err
+	}
+
+	if err := v.required("Recipient", tx.Recipient == nil); err != nil {
+		return err
+	}
+
+	if min, ok := v.limits.uint(lockSecretPluginSection, "minProofSize"); ok && uint64(len(tx.Proof.Data)) < min {
+		return v.fail("Proof", ErrValueOutOfRange)
+	}
+
+	return v.size("Proof", len(tx.Proof.Data), lockSecretPluginSection, "maxProofSize")
+}
+
+func (v *validator) prepareDrive(tx *PrepareDriveTransaction) error {
+	if err := v.required("Owner", tx.Owner == nil); err != nil {
+		return err
+	}
+
+	switch {
+	case tx.Duration == 0:
+		return v.fail("Duration", ErrValueOutOfRange)
+	case tx.BillingPeriod == 0 || tx.BillingPeriod > tx.Duration:
+		return v.fail("BillingPeriod", ErrValueOutOfRange)
+	case tx.DriveSize == 0:
+		return v.fail("DriveSize", ErrValueOutOfRange)
+	case tx.Replicas == 0:
+		return v.fail("Replicas", ErrValueOutOfRange)
+	case tx.MinReplicators == 0 || tx.MinReplicators > tx.Replicas:
+		return v.fail("MinReplicators", ErrValueOutOfRange)
+	case tx.PercentApprovers > 100:
+		return v.fail("PercentApprovers", ErrValueOutOfRange)
+	}
+
+	return nil
+}
+
+func (v *validator) driveFileSystem(tx *DriveFileSystemTransaction) error {
+	if err := v.nonEmpty("DriveKey", len(tx.DriveKey)); err != nil {
+		return err
+	}
+
+	if err := v.required("NewRootHash", tx.NewRootHash == nil); err != nil {
+		return err
+	}
+
+	if err := v.required("OldRootHash", tx.OldRootHash == nil); err != nil {
+		return err
+	}
+
+	if err := v.nonEmpty("AddActions", len(tx.AddActions)+len(tx.RemoveActions)); err != nil {
+		return err
+	}
+
+	if err := v.count("AddActions", len(tx.AddActions), servicePluginSection, "maxFilesOnDrive"); err != nil {
+		return err
+	}
+
+	if err := v.actions("AddActions", tx.AddActions); err != nil {
+		return err
+	}
+
+	return v.actions("RemoveActions", tx.RemoveActions)
+}
+
+func (v *validator) filesDeposit(tx *FilesDepositTransaction) error {
+	if err := v.required("DriveKey", tx.DriveKey == nil); err != nil {
+		return err
+	}
+
+	if err := v.nonEmpty("Files", len(tx.Files)); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, f := range tx.Files {
+		field := fmt.Sprintf("Files[%d]", i)
+		if f == nil || f.FileHash == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, f.FileHash.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) driveFilesReward(tx *DriveFilesRewardTransaction) error {
+	if err := v.nonEmpty("UploadInfos", len(tx.UploadInfos)); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, info := range tx.UploadInfos {
+		field := fmt.Sprintf("UploadInfos[%d]", i)
+		if info == nil || info.Participant == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, strings.ToUpper(info.Participant.PublicKey)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) endDriveVerification(tx *EndDriveVerificationTransaction) error {
+	keys := make(map[string]bool)
+	for i, f := range tx.Failures {
+		field := fmt.Sprintf("Failures[%d]", i)
+		if f == nil || f.Replicator == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, strings.ToUpper(f.Replicator.PublicKey)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) deploy(tx *DeployTransaction) error {
+	if err := v.required("DriveAccount", tx.DriveAccount == nil); err != nil {
+		return err
+	}
+
+	if err := v.required("Owner", tx.Owner == nil); err != nil {
+		return err
+	}
+
+	return v.required("FileHash", tx.FileHash == nil)
+}
+
+func (v *validator) startExecute(tx *StartExecuteTransaction) error {
+	if err := v.required("SuperContract", tx.SuperContract == nil); err != nil {
+		return err
+	}
+
+	if err := v.nonEmpty("Function", len(tx.Function)); err != nil {
+		return err
+	}
+
+	return v.mosaics("LockMosaics", tx.LockMosaics)
+}
+
+func (v *validator) addExchangeOffer(tx *AddExchangeOfferTransaction) error {
+	if err := v.nonEmpty("Offers", len(tx.Offers)); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, o := range tx.Offers {
+		field := fmt.Sprintf("Offers[%d]", i)
+		if o == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.offer(field, &o.Offer); err != nil {
+			return err
+		}
+
+		if o.Duration == 0 {
+			return v.fail(field+".Duration", ErrValueOutOfRange)
+		}
+
+		if err := v.maxBlocks(field+".Duration", o.Duration, exchangePluginSection, "maxOfferDuration"); err != nil {
+			return err
+		}
+
+		if err := v.unique(keys, field, fmt.Sprintf("%s/%d", assetIdKey(o.Mosaic.AssetId), o.Type)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) exchangeOffer(tx *ExchangeOfferTransaction) error {
+	if err := v.nonEmpty("Confirmations", len(tx.Confirmations)); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, c := range tx.Confirmations {
+		field := fmt.Sprintf("Confirmations[%d]", i)
+		if c == nil || c.Owner == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.offer(field, &c.Offer); err != nil {
+			return err
+		}
+
+		key := fmt.Sprintf("%s/%d/%s", assetIdKey(c.Mosaic.AssetId), c.Type, strings.ToUpper(c.Owner.PublicKey))
+		if err := v.unique(keys, field, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) removeExchangeOffer(tx *RemoveExchangeOfferTransaction) error {
+	if err := v.nonEmpty("Offers", len(tx.Offers)); err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for i, o := range tx.Offers {
+		field := fmt.Sprintf("Offers[%d]", i)
+		if o == nil || isNil(o.AssetId) {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, fmt.Sprintf("%s/%d", assetIdKey(o.AssetId), o.Type)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) offer(field string, o *Offer) error {
+	if o.Mosaic == nil || isNil(o.Mosaic.AssetId) {
+		return v.fail(field+".Mosaic", ErrRequiredField)
+	}
+
+	if o.Type != SellOffer && o.Type != BuyOffer {
+		return v.fail(field+".Type", ErrValueOutOfRange)
+	}
+
+	if o.Mosaic.Amount == 0 {
+		return v.fail(field+".Mosaic.Amount", ErrValueOutOfRange)
+	}
+
+	if o.Cost == 0 {
+		return v.fail(field+".Cost", ErrValueOutOfRange)
+	}
+
+	return nil
+}
+
+func (v *validator) mosaics(field string, mosaics []*Mosaic) error {
+	keys := make(map[string]bool)
+	for i, m := range mosaics {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		if m == nil || isNil(m.AssetId) {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, assetIdKey(m.AssetId)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) cosignatoryModifications(field string, modifications []*MultisigCosignatoryModification) error {
+	keys := make(map[string]bool)
+	for i, m := range modifications {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		if m == nil || m.PublicAccount == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, strings.ToUpper(m.PublicKey)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) actions(field string, actions []*Action) error {
+	keys := make(map[string]bool)
+	for i, a := range actions {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		if a == nil || a.FileHash == nil {
+			return v.fail(field, ErrRequiredField)
+		}
+
+		if err := v.unique(keys, field, a.FileHash.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) downloadFiles(field string, files []*DownloadFile) error {
+	if err := v.nonEmpty(field, len(files)); err != nil {
+		return err
+	}
+
+	if err := v.actions(field, files); err != nil {
+		return err
+	}
+
+	for i, f := range files {
+		if f.FileSize == 0 {
+			return v.fail(fmt.Sprintf("%s[%d].FileSize", field, i), ErrValueOutOfRange)
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) required(field string, missing bool) error {
+	if missing {
+		return v.fail(field, ErrRequiredField)
+	}
+
+	return nil
+}
+
+func (v *validator) nonEmpty(field string, length int) error {
+	if length == 0 {
+		return v.fail(field, ErrEmptyField)
+	}
+
+	return nil
+}
+
+func (v *validator) modificationsCount(count int, section, key string) error {
+	if err := v.nonEmpty("Modifications", count); err != nil {
+		return err
+	}
+
+	return v.count("Modifications", count, section, key)
+}
+
+func (v *validator) count(field string, count int, section, key string) error {
+	if limit, ok := v.limits.uint(section, key); ok && uint64(count) > limit {
+		return v.fail(field, ErrTooManyElements)
+	}
+
+	return nil
+}
+
+func (v *validator) size(field string, size int, section, key string) error {
+	if limit, ok := v.limits.uint(section, key); ok && uint64(size) > limit {
+		return v.fail(field, ErrSizeExceeded)
+	}
+
+	return nil
+}
+
+func (v *validator) maxBlocks(field string, duration Duration, section, key string) error {
+	if limit, ok := v.limits.blocks(section, key); ok && duration > limit {
+		return v.fail(field, ErrValueOutOfRange)
+	}
+
+	return nil
+}
+
+func (v *validator) minBlocks(field string, duration Duration, section, key string) error {
+	if limit, ok := v.limits.blocks(section, key); ok && duration < limit {
+		return v.fail(field, ErrValueOutOfRange)
+	}
+
+	return nil
+}
+
+func (v *validator) unique(keys map[string]bool, field string, key string) error {
+	if keys[key] {
+		return v.fail(field, ErrDuplicateElement)
+	}
+
+	keys[key] = true
+
+	return nil
+}
+
+// networkLimits reads limits from network config, malformed values are treated as missing
+type networkLimits struct {
+	cfg *BlockchainConfig
+}
+
+func (l networkLimits) value(section, key string) (string, bool) {
+	if l.cfg == nil || l.cfg.NetworkConfig == nil {
+		return "", false
+	}
+
+	bag, ok := l.cfg.NetworkConfig.Sections[section]
+	if !ok {
+		return "", false
+	}
+
+	field, ok := bag.Fields[key]
+	if !ok {
+		return "", false
+	}
+
+	return field.Value, true
+}
+
+// returns numeric value, digit separators like in 10'000 are allowed
+func (l networkLimits) uint(section, key string) (uint64, bool) {
+	s, ok := l.value(section, key)
+	if !ok {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(strings.Replace(s, "'", "", -1), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// returns time value like 15s, 24h or 365d
+func (l networkLimits) duration(section, key string) (time.Duration, bool) {
+	s, ok := l.value(section, key)
+	if !ok {
+		return 0, false
+	}
+
+	d, err := parseConfigDuration(s)
+	if err != nil {
+		return 0, false
+	}
+
+	return d, true
+}
+
+// returns value in blocks, time values are converted by block generation target time
+func (l networkLimits) blocks(section, key string) (Duration, bool) {
+	if value, ok := l.uint(section, key); ok {
+		return Duration(value), true
+	}
+
+	d, ok := l.duration(section, key)
+	if !ok {
+		return 0, false
+	}
+
+	blockTime, ok := l.duration(chainConfigSection, blockGenerationTargetTimeKey)
+	if !ok || blockTime <= 0 {
+		return 0, false
+	}
+
+	return Duration(d / blockTime), true
+}
+
+// parses duration of catapult config format: number with one of ms, s, m, h or d units
+func parseConfigDuration(s string) (time.Duration, error) {
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"ms", time.Millisecond},
+		{"s", time.Second},
+		{"m", time.Minute},
+		{"h", time.Hour},
+		{"d", time.Hour * 24},
+	}
+
+	s = strings.Replace(s, "'", "", -1)
+
+	for _, u := range units {
+		if !strings.HasSuffix(s, u.suffix) {
+			continue
+		}
+
+		value, err := strconv.ParseUint(strings.TrimSuffix(s, u.suffix), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		return time.Duration(value) * u.unit, nil
+	}
+
+	return 0, fmt.Errorf("unknown unit of duration %s", s)
+}
+
+func assetIdKey(id AssetId) string {
+	return fmt.Sprintf("%d:%d", id.Type(), id.Id())
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
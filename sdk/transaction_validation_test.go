@@ -0,0 +1,257 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validationConfig = `
+[chain]
+blockGenerationTargetTime = 15s
+maxTransactionLifetime = 24h
+
+[plugin:catapult.plugins.transfer]
+maxMessageSize = 16
+maxMosaicsSize = 2
+
+[plugin:catapult.plugins.aggregate]
+maxTransactionsPerAggregate = 2
+maxCosignaturesPerAggregate = 15
+
+[plugin:catapult.plugins.lockhash]
+lockedFundsPerAggregate = 10'000'000
+maxHashLockDuration = 2d
+
+[plugin:catapult.plugins.locksecret]
+maxSecretLockDuration = 30d
+minProofSize = 4
+maxProofSize = 8
+
+[plugin:catapult.plugins.namespace]
+maxNameSize = 16
+maxNamespaceDuration = 365d
+
+[plugin:catapult.plugins.mosaic]
+maxMosaicDivisibility = 6
+maxMosaicDuration = 3650d
+`
+
+func validationBlockchainConfig(t *testing.T) *BlockchainConfig {
+	networkConfig := NewNetworkConfig()
+	err := networkConfig.UnmarshalBinary([]byte(validationConfig))
+	assert.Nilf(t, err, "NetworkConfig.UnmarshalBinary returned error: %s", err)
+
+	return &BlockchainConfig{NetworkConfig: networkConfig}
+}
+
+func validationTransfer(t *testing.T, deadline time.Duration, message string, mosaics ...*Mosaic) *TransferTransaction {
+	if mosaics == nil {
+		mosaics = []*Mosaic{}
+	}
+
+	tx, err := NewTransferTransaction(
+		NewDeadline(deadline),
+		NewAddress("SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC", MijinTest),
+		mosaics,
+		NewPlainMessage(message),
+		MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	return tx
+}
+
+func assertValidationError(t *testing.T, err error, field string, rule error) {
+	validationErr, ok := err.(*ValidationError)
+	if !assert.Truef(t, ok, "expected *ValidationError, got %v", err) {
+		return
+	}
+
+	assert.Equal(t, field, validationErr.Field)
+	assert.True(t, errors.Is(err, rule), "expected %s, got %s", rule, validationErr.Err)
+}
+
+func TestValidate_Transfer(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	tx := validationTransfer(t, time.Hour, "test", Xpx(10))
+	err := Validate(tx, cfg)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	tx = validationTransfer(t, time.Hour, strings.Repeat("a", 17))
+	assertValidationError(t, Validate(tx, cfg), "Message", ErrSizeExceeded)
+
+	tx = validationTransfer(t, time.Hour, "", Xpx(1), Storage(1), Streaming(1))
+	assertValidationError(t, Validate(tx, cfg), "Mosaics", ErrTooManyElements)
+
+	tx = validationTransfer(t, time.Hour, "", Xpx(1), Xpx(2))
+	assertValidationError(t, Validate(tx, cfg), "Mosaics[1]", ErrDuplicateElement)
+
+	tx = validationTransfer(t, time.Hour, "")
+	tx.Recipient = nil
+	assertValidationError(t, Validate(tx, cfg), "Recipient", ErrRequiredField)
+}
+
+func TestValidate_Deadline(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	tx := validationTransfer(t, -time.Minute, "")
+	assertValidationError(t, Validate(tx, cfg), "Deadline", ErrDeadlineExpired)
+
+	tx = validationTransfer(t, time.Hour*25, "")
+	assertValidationError(t, Validate(tx, cfg), "Deadline", ErrDeadlineTooFar)
+
+	tx.Deadline = nil
+	assertValidationError(t, Validate(tx, cfg), "Deadline", ErrRequiredField)
+
+	// without config limits of network are unknown
+	tx = validationTransfer(t, time.Hour*25, "")
+	err := Validate(tx, nil)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	assert.Equal(t, ErrNilTransaction, Validate(nil, cfg))
+}
+
+func TestValidate_SupportedEntities(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+	tx := validationTransfer(t, time.Hour, "")
+
+	cfg.SupportedEntityVersions = &SupportedEntities{
+		Entities: map[EntityType]*Entity{
+			Transfer: {Name: "Transfer", Type: Transfer, SupportedVersions: []EntityVersion{tx.Version}},
+		},
+	}
+
+	err := Validate(tx, cfg)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	cfg.SupportedEntityVersions.Entities[Transfer].SupportedVersions = []EntityVersion{tx.Version + 1}
+	assertValidationError(t, Validate(tx, cfg), "Version", ErrUnsupportedEntity)
+
+	delete(cfg.SupportedEntityVersions.Entities, Transfer)
+	assertValidationError(t, Validate(tx, cfg), "Type", ErrUnsupportedEntity)
+}
+
+func TestValidate_Aggregate(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	signer, err := NewAccountFromPublicKey("27F6BEF9A7F75E33AE2EB2EBA10EF1D6BEA4D30EBD5E39AF8EE06E96E11AE2A9", MijinTest)
+	assert.Nilf(t, err, "NewAccountFromPublicKey returned error: %s", err)
+
+	inner := validationTransfer(t, time.Hour, "", Xpx(1))
+	inner.ToAggregate(signer)
+
+	tx, err := NewCompleteAggregateTransaction(NewDeadline(time.Hour), []Transaction{inner}, MijinTest)
+	assert.Nilf(t, err, "NewCompleteAggregateTransaction returned error: %s", err)
+
+	err = Validate(tx, cfg)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	invalid := validationTransfer(t, time.Hour, "", Xpx(1), Xpx(1))
+	invalid.ToAggregate(signer)
+
+	tx.InnerTransactions = []Transaction{inner, invalid}
+	assertValidationError(t, Validate(tx, cfg), "InnerTransactions[1].Mosaics[1]", ErrDuplicateElement)
+
+	validationErr := Validate(tx, cfg).(*ValidationError)
+	assert.Equal(t, Transfer, validationErr.EntityType)
+
+	tx.InnerTransactions = []Transaction{inner, inner, inner}
+	assertValidationError(t, Validate(tx, cfg), "InnerTransactions", ErrTooManyElements)
+
+	tx.InnerTransactions = nil
+	assertValidationError(t, Validate(tx, cfg), "InnerTransactions", ErrEmptyField)
+}
+
+func TestValidate_RegisterNamespace(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	tx, err := NewRegisterRootNamespaceTransaction(NewDeadline(time.Hour), "proximax", Duration(1000), MijinTest)
+	assert.Nilf(t, err, "NewRegisterRootNamespaceTransaction returned error: %s", err)
+
+	err = Validate(tx, cfg)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	// 365 days in blocks of 15 seconds
+	tx.Duration = Duration(365*24*60*4 + 1)
+	assertValidationError(t, Validate(tx, cfg), "Duration", ErrValueOutOfRange)
+
+	tx.NamspaceName = "proximax.storage.namespace"
+	assertValidationError(t, Validate(tx, cfg), "NamspaceName", ErrSizeExceeded)
+
+	tx.NamspaceName = "Invalid!"
+	assertValidationError(t, Validate(tx, cfg), "NamspaceName", ErrInvalidNamespaceName)
+}
+
+func TestValidate_LockFunds(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	hash, err := StringToHash("86258172F90639811F2ABD055747D1E11B55A64B68AED2CEA9A34FBD6C0BE790")
+	assert.Nilf(t, err, "StringToHash returned error: %s", err)
+
+	stx := &SignedTransaction{EntityType: AggregateBonded, Hash: hash}
+
+	tx, err := NewLockFundsTransaction(NewDeadline(time.Hour), XpxRelative(10), Duration(100), stx, MijinTest)
+	assert.Nilf(t, err, "NewLockFundsTransaction returned error: %s", err)
+
+	err = Validate(tx, cfg)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	tx.Mosaic = XpxRelative(5)
+	assertValidationError(t, Validate(tx, cfg), "Mosaic.Amount", ErrValueOutOfRange)
+
+	tx.Mosaic = XpxRelative(10)
+	tx.Duration = Duration(2*24*60*4 + 1)
+	assertValidationError(t, Validate(tx, cfg), "Duration", ErrValueOutOfRange)
+}
+
+func TestValidate_SecretProof(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	tx, err := NewSecretProofTransaction(
+		NewDeadline(time.Hour),
+		SHA3_256,
+		NewProofFromString("proof"),
+		NewAddress("SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC", MijinTest),
+		MijinTest,
+	)
+	assert.Nilf(t, err, "NewSecretProofTransaction returned error: %s", err)
+
+	err = Validate(tx, cfg)
+	assert.Nilf(t, err, "Validate returned error: %s", err)
+
+	tx.Proof = NewProofFromString("long proof")
+	assertValidationError(t, Validate(tx, cfg), "Proof", ErrSizeExceeded)
+
+	tx.Proof = NewProofFromString("abc")
+	assertValidationError(t, Validate(tx, cfg), "Proof", ErrValueOutOfRange)
+}
+
+func TestValidate_AddExchangeOffer(t *testing.T) {
+	cfg := validationBlockchainConfig(t)
+
+	offer := &AddOffer{Offer: Offer{Type: SellOffer, Mosaic: Storage(10), Cost: Amount(100)}, Duration: Duration(100)}
+
+	tx, err := NewAddExchangeOfferTransaction(NewDeadline(time.Hour), []*AddOffer{offer, offer}, MijinTest)
+	assert.Nilf(t, err, "NewAddExchangeOfferTransaction returned error: %s", err)
+	assertValidationError(t, Validate(tx, cfg), "Offers[1]", ErrDuplicateElement)
+
+	tx.Offers = []*AddOffer{{Offer: Offer{Type: BuyOffer, Mosaic: Storage(10)}, Duration: Duration(100)}}
+	assertValidationError(t, Validate(tx, cfg), "Offers[0].Cost", ErrValueOutOfRange)
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{EntityType: Transfer, Field: "Mosaics[1]", Err: ErrDuplicateElement}
+
+	assert.True(t, strings.HasPrefix(err.Error(), "Mosaics[1] of "))
+	assert.True(t, strings.HasSuffix(err.Error(), ErrDuplicateElement.Error()))
+	assert.Equal(t, ErrDuplicateElement, errors.Unwrap(err))
+}
@@ -0,0 +1,53 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+)
+
+// walks accounts of wallet derived by DefaultDerivationPath starting from index 0
+// and stops when gapLimit consecutive accounts are not known by AccountService.GetAccountsInfo
+// returns all accounts up to the last used one
+func (w *Wallet) DiscoverAccounts(ctx context.Context, service *AccountService, gapLimit int) ([]*Account, error) {
+	if gapLimit <= 0 {
+		return nil, ErrInvalidDiscoveryGapLimit
+	}
+
+	accounts := make([]*Account, 0)
+	lastUsed := -1
+
+	for len(accounts)-lastUsed <= gapLimit {
+		batch := make([]*Address, gapLimit)
+		for i := range batch {
+			acc, err := w.Account(uint32(len(accounts)))
+			if err != nil {
+				return nil, err
+			}
+
+			accounts = append(accounts, acc)
+			batch[i] = acc.PublicAccount.Address
+		}
+
+		infos, err := service.GetAccountsInfo(ctx, batch...)
+		if err != nil && !errors.Is(err, ErrResourceNotFound) {
+			return nil, err
+		}
+
+		used := make(map[string]bool, len(infos))
+		for _, info := range infos {
+			used[info.Address.Address] = true
+		}
+
+		for i := len(accounts) - len(batch); i < len(accounts); i++ {
+			if used[accounts[i].PublicAccount.Address.Address] {
+				lastUsed = i
+			}
+		}
+	}
+
+	return accounts[:lastUsed+1], nil
+}
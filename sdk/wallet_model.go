@@ -0,0 +1,149 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// index from which hardened derivation starts
+	HardenedKeyStart uint32 = 0x80000000
+	// template of derivation path of accounts in wallet, the only parameter is index of account
+	DefaultDerivationPath = "m/44'/43'/%d'/0'/0'"
+	// default number of unused accounts after which discovery stops
+	DefaultDiscoveryGapLimit = 20
+)
+
+const (
+	minSeedLength = 16
+	maxSeedLength = 64
+	slip10Curve   = "ed25519 seed"
+)
+
+// returns new BIP39 mnemonic with passed entropy size in bits
+// entropy size should be multiple of 32 between 128 and 256
+func NewMnemonic(bitSize int) (string, error) {
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// returns true if passed mnemonic consists of words of BIP39 english wordlist and has valid checksum
+func IsMnemonicValid(mnemonic string) bool {
+	return bip39.IsMnemonicValid(mnemonic)
+}
+
+// Wallet is a hierarchical deterministic wallet which derives ed25519 accounts by SLIP-10
+type Wallet struct {
+	seed           []byte
+	networkType    NetworkType
+	generationHash *Hash
+}
+
+// returns Wallet from BIP39 mnemonic and passphrase for passed NetworkType and generationHash
+func NewWalletFromMnemonic(mnemonic string, passphrase string, networkType NetworkType, generationHash *Hash) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return NewWalletFromSeed(seed, networkType, generationHash)
+}
+
+// returns Wallet from raw seed for passed NetworkType and generationHash
+func NewWalletFromSeed(seed []byte, networkType NetworkType, generationHash *Hash) (*Wallet, error) {
+	if len(seed) < minSeedLength || len(seed) > maxSeedLength {
+		return nil, ErrInvalidSeedLength
+	}
+
+	s := make([]byte, len(seed))
+	copy(s, seed)
+
+	return &Wallet{s, networkType, generationHash}, nil
+}
+
+// returns Account with passed index derived by DefaultDerivationPath
+func (w *Wallet) Account(index uint32) (*Account, error) {
+	return w.DeriveAccount(fmt.Sprintf(DefaultDerivationPath, index))
+}
+
+// returns Account derived by passed path, e.g. "m/44'/43'/0'/0'/0'"
+// every segment of path should be hardened
+func (w *Wallet) DeriveAccount(path string) (*Account, error) {
+	key, _, err := deriveSlip10Key(w.seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountFromPrivateKey(hex.EncodeToString(key), w.networkType, w.generationHash)
+}
+
+// returns indexes of passed derivation path with hardened offset
+func parseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, ErrInvalidDerivationPath
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, s := range segments[1:] {
+		if len(s) < 2 {
+			return nil, ErrInvalidDerivationPath
+		}
+
+		if !strings.HasSuffix(s, "'") && !strings.HasSuffix(s, "H") {
+			return nil, ErrNonHardenedDerivation
+		}
+
+		i, err := strconv.ParseUint(s[:len(s)-1], 10, 32)
+		if err != nil || uint32(i) >= HardenedKeyStart {
+			return nil, ErrInvalidDerivationPath
+		}
+
+		indexes = append(indexes, uint32(i)+HardenedKeyStart)
+	}
+
+	return indexes, nil
+}
+
+// derives private key and chain code from seed by passed path according to SLIP-10 for ed25519 curve
+func deriveSlip10Key(seed []byte, path string) ([]byte, []byte, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, chainCode := hmacSha512Split([]byte(slip10Curve), seed)
+
+	for _, i := range indexes {
+		data := make([]byte, 1+len(key)+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[1+len(key):], i)
+
+		key, chainCode = hmacSha512Split(chainCode, data)
+	}
+
+	return key, chainCode, nil
+}
+
+func hmacSha512Split(key []byte, data []byte) ([]byte, []byte) {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	sum := h.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,166 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+// test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+var bip39TestVectors = []struct {
+	mnemonic string
+	seed     string
+}{
+	{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		testMnemonic,
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+}
+
+// test vectors from https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+var slip10TestVectors = []struct {
+	seed      string
+	path      string
+	chainCode string
+	key       string
+}{
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"m",
+		"90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+		"2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"m/0H",
+		"8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+		"68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"m/0H/1H",
+		"a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+		"b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"m/0H/1H/2H",
+		"2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c",
+		"92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"m/0H/1H/2H/2H",
+		"8f6d87f93d750e0efccda017d662a1b31a266e4a6f5993b15f5c1f07f74dd5cc",
+		"30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"m/0H/1H/2H/2H/1000000000H",
+		"68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230",
+		"8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+	},
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, size := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := NewMnemonic(size)
+		assert.Nilf(t, err, "NewMnemonic returned error: %s", err)
+
+		assert.Len(t, strings.Fields(mnemonic), size/32*3)
+		assert.True(t, IsMnemonicValid(mnemonic))
+	}
+
+	_, err := NewMnemonic(100)
+	assert.NotNil(t, err)
+}
+
+func TestIsMnemonicValid(t *testing.T) {
+	assert.True(t, IsMnemonicValid(testMnemonic))
+	assert.False(t, IsMnemonicValid("legal winner thank year wave sausage worth useful legal winner thank yellow yellow"))
+	assert.False(t, IsMnemonicValid("legal winner thank year wave sausage worth useful legal winner thank"))
+	assert.False(t, IsMnemonicValid("legal winner thank year wave sausage worth useful legal winner thank proximax"))
+}
+
+func TestNewWalletFromMnemonic_Bip39Vectors(t *testing.T) {
+	for _, v := range bip39TestVectors {
+		w, err := NewWalletFromMnemonic(v.mnemonic, "TREZOR", MijinTest, GenerationHash)
+		assert.Nilf(t, err, "NewWalletFromMnemonic returned error: %s", err)
+
+		assert.Equal(t, v.seed, hex.EncodeToString(w.seed))
+	}
+
+	_, err := NewWalletFromMnemonic("legal winner thank year", "", MijinTest, GenerationHash)
+	assert.Equal(t, ErrInvalidMnemonic, err)
+}
+
+func TestDeriveSlip10Key_Vectors(t *testing.T) {
+	for _, v := range slip10TestVectors {
+		seed, err := hex.DecodeString(v.seed)
+		assert.Nil(t, err)
+
+		key, chainCode, err := deriveSlip10Key(seed, v.path)
+		assert.Nilf(t, err, "deriveSlip10Key returned error: %s", err)
+
+		assert.Equal(t, v.key, hex.EncodeToString(key), v.path)
+		assert.Equal(t, v.chainCode, hex.EncodeToString(chainCode), v.path)
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	indexes, err := parseDerivationPath("m/44'/43'/1H")
+	assert.Nilf(t, err, "parseDerivationPath returned error: %s", err)
+	assert.Equal(t, []uint32{HardenedKeyStart + 44, HardenedKeyStart + 43, HardenedKeyStart + 1}, indexes)
+
+	_, err = parseDerivationPath("m/44'/43/1'")
+	assert.Equal(t, ErrNonHardenedDerivation, err)
+
+	for _, path := range []string{"", "44'/43'", "m/", "m/a'", "m/2147483648'"} {
+		_, err = parseDerivationPath(path)
+		assert.Equal(t, ErrInvalidDerivationPath, err, path)
+	}
+}
+
+func TestWallet_Account(t *testing.T) {
+	w, err := NewWalletFromMnemonic(testMnemonic, "", MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewWalletFromMnemonic returned error: %s", err)
+
+	acc0, err := w.Account(0)
+	assert.Nilf(t, err, "Wallet.Account returned error: %s", err)
+
+	acc1, err := w.Account(1)
+	assert.Nilf(t, err, "Wallet.Account returned error: %s", err)
+
+	assert.NotEqual(t, acc0.PublicAccount.PublicKey, acc1.PublicAccount.PublicKey)
+	assert.Equal(t, MijinTest, acc0.PublicAccount.Address.Type)
+
+	derived, err := w.DeriveAccount("m/44'/43'/0'/0'/0'")
+	assert.Nilf(t, err, "Wallet.DeriveAccount returned error: %s", err)
+	assert.Equal(t, acc0.KeyPair.PrivateKey.String(), derived.KeyPair.PrivateKey.String())
+
+	key, _, err := deriveSlip10Key(w.seed, "m/44'/43'/0'/0'/0'")
+	assert.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(key), strings.ToLower(acc0.KeyPair.PrivateKey.String()))
+
+	same, err := NewWalletFromMnemonic(testMnemonic, "", MijinTest, GenerationHash)
+	assert.Nilf(t, err, "NewWalletFromMnemonic returned error: %s", err)
+
+	acc, err := same.Account(1)
+	assert.Nilf(t, err, "Wallet.Account returned error: %s", err)
+	assert.Equal(t, acc1.PublicAccount, acc.PublicAccount)
+
+	_, err = NewWalletFromSeed([]byte{1, 2, 3}, MijinTest, GenerationHash)
+	assert.Equal(t, ErrInvalidSeedLength, err)
+}
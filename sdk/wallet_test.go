@@ -0,0 +1,93 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const walletAccountInfoJsonTemplate = `{
+   "meta":{},
+   "account":{
+      "address":"%s",
+      "addressHeight":[1, 0],
+      "publicKey":"%s",
+      "publicKeyHeight":[1, 0],
+      "accountType": 0,
+      "mosaics":[]
+   }
+}`
+
+func TestWallet_DiscoverAccounts(t *testing.T) {
+	w, err := NewWalletFromMnemonic(testMnemonic, "", PublicTest, GenerationHash)
+	assert.Nilf(t, err, "NewWalletFromMnemonic returned error: %s", err)
+
+	used, err := w.Account(2)
+	assert.Nilf(t, err, "Wallet.Account returned error: %s", err)
+
+	rawAddress, err := base32.StdEncoding.DecodeString(used.PublicAccount.Address.Address)
+	assert.Nil(t, err)
+
+	sdkMock := newSdkMockWithRouter(&mock.Router{
+		Path:     accountsRoute,
+		RespBody: "[" + fmt.Sprintf(walletAccountInfoJsonTemplate, hex.EncodeToString(rawAddress), used.PublicAccount.PublicKey) + "]",
+	})
+	defer sdkMock.Close()
+
+	client := sdkMock.getPublicTestClientUnsafe()
+
+	accounts, err := w.DiscoverAccounts(context.Background(), client.Account, 3)
+	assert.Nilf(t, err, "Wallet.DiscoverAccounts returned error: %s", err)
+
+	assert.Len(t, accounts, 3)
+	for i, acc := range accounts {
+		expected, err := w.Account(uint32(i))
+		assert.Nilf(t, err, "Wallet.Account returned error: %s", err)
+		assert.Equal(t, expected.PublicAccount, acc.PublicAccount)
+	}
+
+	_, err = w.DiscoverAccounts(context.Background(), client.Account, 0)
+	assert.Equal(t, ErrInvalidDiscoveryGapLimit, err)
+}
+
+func TestWallet_DiscoverAccounts_Unused(t *testing.T) {
+	w, err := NewWalletFromMnemonic(testMnemonic, "", PublicTest, GenerationHash)
+	assert.Nilf(t, err, "NewWalletFromMnemonic returned error: %s", err)
+
+	sdkMock := newSdkMockWithRouter(&mock.Router{
+		Path:     accountsRoute,
+		RespBody: "[]",
+	})
+	defer sdkMock.Close()
+
+	accounts, err := w.DiscoverAccounts(context.Background(), sdkMock.getPublicTestClientUnsafe().Account, DefaultDiscoveryGapLimit)
+	assert.Nilf(t, err, "Wallet.DiscoverAccounts returned error: %s", err)
+	assert.Empty(t, accounts)
+}
+
+func TestWallet_DiscoverAccounts_NotFound(t *testing.T) {
+	w, err := NewWalletFromMnemonic(testMnemonic, "", PublicTest, GenerationHash)
+	assert.Nilf(t, err, "NewWalletFromMnemonic returned error: %s", err)
+
+	// node answers with 404 when none of requested accounts is known
+	sdkMock := newSdkMockWithRouter(&mock.Router{
+		Path:         accountsRoute,
+		RespHttpCode: http.StatusNotFound,
+		RespBody:     `{"code": "ResourceNotFound", "message": "no resource exists"}`,
+	})
+	defer sdkMock.Close()
+
+	accounts, err := w.DiscoverAccounts(context.Background(), sdkMock.getPublicTestClientUnsafe().Account, DefaultDiscoveryGapLimit)
+	assert.Nilf(t, err, "Wallet.DiscoverAccounts returned error: %s", err)
+	assert.Empty(t, accounts)
+}
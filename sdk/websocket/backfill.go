@@ -0,0 +1,407 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	hdlrs "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/handlers"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+const (
+	// number of the latest blocks which are backfilled after reconnection if WithBackfillMaxBlocks is not passed
+	DefaultBackfillMaxBlocks = 1000
+
+	// number of blocks requested at once while missed blocks are backfilled
+	backfillBlocksLimit sdk.Amount = 100
+)
+
+// backfill keeps position of the last blocks and confirmed transactions delivered by websocket,
+// so events missed while websocket is reconnecting are fetched through REST
+type backfill struct {
+	sync.Mutex
+
+	lastBlock sdk.Height
+	// positions of confirmed transactions by address
+	confirmed map[string]*confirmedPosition
+	// hashes of events delivered by the last backfill, websocket doesn't deliver them again
+	delivered map[sdk.Hash]struct{}
+}
+
+// transactions below height and transactions with hashes at height are already delivered
+type confirmedPosition struct {
+	height sdk.Height
+	hashes map[sdk.Hash]struct{}
+}
+
+func (p *confirmedPosition) contains(info *sdk.TransactionInfo) bool {
+	if info.Height != p.height {
+		return info.Height < p.height
+	}
+
+	if info.TransactionHash == nil {
+		return false
+	}
+
+	_, ok := p.hashes[*info.TransactionHash]
+	return ok
+}
+
+func (p *confirmedPosition) add(info *sdk.TransactionInfo) {
+	if info.Height < p.height {
+		return
+	}
+
+	if info.Height > p.height {
+		p.height = info.Height
+		p.hashes = make(map[sdk.Hash]struct{})
+	}
+
+	if info.TransactionHash != nil {
+		p.hashes[*info.TransactionHash] = struct{}{}
+	}
+}
+
+// returns false if block is already delivered by backfill
+func (b *backfill) observeBlock(block *sdk.BlockInfo) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if block.BlockHash != nil {
+		if _, ok := b.delivered[*block.BlockHash]; ok {
+			return false
+		}
+	}
+
+	if block.Height > b.lastBlock {
+		b.lastBlock = block.Height
+	}
+
+	return true
+}
+
+// returns false if transaction is already delivered by backfill
+func (b *backfill) observeConfirmed(address *sdk.Address, tx sdk.Transaction) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	info := &tx.GetAbstractTransaction().TransactionInfo
+	if info.TransactionHash != nil {
+		if _, ok := b.delivered[*info.TransactionHash]; ok {
+			return false
+		}
+	}
+
+	if b.confirmed == nil {
+		b.confirmed = make(map[string]*confirmedPosition)
+	}
+
+	position, ok := b.confirmed[address.Address]
+	if !ok {
+		position = &confirmedPosition{height: info.Height, hashes: make(map[sdk.Hash]struct{})}
+		b.confirmed[address.Address] = position
+	}
+
+	position.add(info)
+
+	return true
+}
+
+// starts tracking of confirmed transactions of address from the block after the last delivered one.
+// Transactions of address can't be backfilled until block or confirmed transaction of address is delivered
+func (b *backfill) watch(address *sdk.Address) {
+	b.Lock()
+	defer b.Unlock()
+
+	if _, ok := b.confirmed[address.Address]; ok || b.lastBlock == 0 {
+		return
+	}
+
+	if b.confirmed == nil {
+		b.confirmed = make(map[string]*confirmedPosition)
+	}
+
+	b.confirmed[address.Address] = &confirmedPosition{height: b.lastBlock + 1, hashes: make(map[sdk.Hash]struct{})}
+}
+
+func (b *backfill) forget(address *sdk.Address) {
+	b.Lock()
+	defer b.Unlock()
+
+	delete(b.confirmed, address.Address)
+}
+
+func (b *backfill) lastBlockHeight() sdk.Height {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.lastBlock
+}
+
+// returns copy of position of address, nil if address isn't tracked
+func (b *backfill) position(address *sdk.Address) *confirmedPosition {
+	b.Lock()
+	defer b.Unlock()
+
+	position, ok := b.confirmed[address.Address]
+	if !ok {
+		return nil
+	}
+
+	hashes := make(map[sdk.Hash]struct{}, len(position.hashes))
+	for hash := range position.hashes {
+		hashes[hash] = struct{}{}
+	}
+
+	return &confirmedPosition{height: position.height, hashes: hashes}
+}
+
+func (b *backfill) resetDelivered() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.delivered = make(map[sdk.Hash]struct{})
+}
+
+func (b *backfill) markDelivered(hash *sdk.Hash) {
+	if hash == nil {
+		return
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.delivered[*hash] = struct{}{}
+}
+
+// blockTopicHandler delivers blocks from websocket to block subscribers, blocks which are delivered by backfill are skipped
+type blockTopicHandler struct {
+	backfill    *backfill
+	subscribers subscribers.Block
+}
+
+func (h *blockTopicHandler) Handle(_ *sdk.Address, resp []byte) bool {
+	b, err := sdk.MapBlock(resp)
+	if err != nil {
+		panic(errors.Wrap(err, "message mapping"))
+	}
+
+	if !h.backfill.observeBlock(b) {
+		return h.subscribers.HasHandlers()
+	}
+
+	return deliverBlock(h.subscribers, b)
+}
+
+// confirmedAddedTopicHandler delivers confirmed transactions from websocket to subscribers,
+// transactions which are delivered by backfill are skipped
+type confirmedAddedTopicHandler struct {
+	backfill    *backfill
+	mapper      sdk.ConfirmedAddedMapper
+	subscribers subscribers.ConfirmedAdded
+}
+
+func (h *confirmedAddedTopicHandler) Handle(address *sdk.Address, resp []byte) bool {
+	tx, err := h.mapper.MapConfirmedAdded(resp)
+	if err != nil {
+		panic(errors.Wrap(err, "message mapper error"))
+	}
+
+	if !h.backfill.observeConfirmed(address, tx) {
+		return h.subscribers.HasHandlers(address)
+	}
+
+	return deliverConfirmed(h.subscribers, address, tx)
+}
+
+// calls handlers of subscribers with passed block, returns false if there are no handlers left
+func deliverBlock(s subscribers.Block, b *sdk.BlockInfo) bool {
+	mapper := sdk.BlockMapperFn(func([]byte) (*sdk.BlockInfo, error) {
+		return b, nil
+	})
+
+	return hdlrs.NewBlockHandler(mapper, s).Handle(nil, nil)
+}
+
+// calls handlers of address with passed transaction, returns false if there are no handlers left
+func deliverConfirmed(s subscribers.ConfirmedAdded, address *sdk.Address, tx sdk.Transaction) bool {
+	mapper := sdk.ConfirmedAddedMapperFn(func([]byte) (sdk.Transaction, error) {
+		return tx, nil
+	})
+
+	return hdlrs.NewConfirmedAddedHandler(mapper, s).Handle(address, nil)
+}
+
+// delivers blocks and confirmed transactions which are missed while websocket was disconnected.
+// It is called after handlers are subscribed again and before messages of new connection are read,
+// so backfilled events go before live ones. Only events of backfillMaxBlocks latest blocks are delivered,
+// so long disconnection doesn't hold live messages for long
+func (c *CatapultWebsocketClientImpl) backfillMissed(ctx context.Context) error {
+	if c.rest == nil {
+		return nil
+	}
+
+	c.backfill.resetDelivered()
+
+	blocks := c.blockSubscriber != nil && c.blockSubscriber.HasHandlers() && c.backfill.lastBlockHeight() != 0
+
+	var addresses []string
+	if c.confirmedAddedSubscribers != nil {
+		addresses = c.confirmedAddedSubscribers.GetAddresses()
+	}
+
+	if !blocks && len(addresses) == 0 {
+		return nil
+	}
+
+	height, err := c.rest.Blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting blockchain height")
+	}
+
+	from := sdk.Height(1)
+	if c.backfillMaxBlocks > 0 && height > c.backfillMaxBlocks {
+		from = height - c.backfillMaxBlocks + 1
+	}
+
+	if blocks {
+		if err := c.backfillBlocks(ctx, from, height); err != nil {
+			return errors.Wrap(err, "backfilling blocks")
+		}
+	}
+
+	for _, value := range addresses {
+		address, err := sdk.NewAddressFromRaw(value)
+		if err != nil {
+			return err
+		}
+
+		if err := c.backfillConfirmed(ctx, address, from); err != nil {
+			return errors.Wrapf(err, "backfilling confirmed transactions of %s", value)
+		}
+	}
+
+	return nil
+}
+
+// delivers blocks after the last delivered one up to passed height, blocks before from are skipped
+func (c *CatapultWebsocketClientImpl) backfillBlocks(ctx context.Context, from, height sdk.Height) error {
+	next := c.backfill.lastBlockHeight() + 1
+	if next < from {
+		next = from
+	}
+
+	for next <= height {
+		blocks, err := c.rest.Blockchain.GetBlocksByHeightWithLimit(ctx, next, backfillBlocksLimit)
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].Height < blocks[j].Height
+		})
+
+		start := next
+		for _, b := range blocks {
+			if b.Height < next || b.Height > height {
+				continue
+			}
+
+			b.Backfilled = true
+			c.backfill.observeBlock(b)
+			c.backfill.markDelivered(b.BlockHash)
+
+			if !deliverBlock(c.blockSubscriber, b) {
+				return c.publishUnsubscribe(pathBlock)
+			}
+
+			next = b.Height + 1
+		}
+
+		// node doesn't return blocks it reported, the rest comes from websocket
+		if next == start {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// transactions of account are found by its public key, so transactions of account without public key can't be backfilled.
+// Transactions of blocks before from are skipped
+func (c *CatapultWebsocketClientImpl) backfillConfirmed(ctx context.Context, address *sdk.Address, from sdk.Height) error {
+	position := c.backfill.position(address)
+	if position == nil {
+		return nil
+	}
+
+	info, err := c.rest.Account.GetAccountInfo(ctx, address)
+	// account is not known by node yet, so it has no confirmed transactions
+	if stderrors.Is(err, sdk.ErrResourceNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if info.PublicKeyHeight == 0 {
+		return nil
+	}
+
+	account, err := sdk.NewAccountFromPublicKey(info.PublicKey, address.Type)
+	if err != nil {
+		return err
+	}
+
+	// transactions are requested from the newest ones until delivered transactions are reached
+	var missed []sdk.Transaction
+	it := c.rest.Account.TransactionsIterator(account, &sdk.AccountTransactionsOption{Ordering: sdk.TRANSACTION_ORDER_DESC})
+	for it.Next(ctx) {
+		tx := it.Transaction()
+		txInfo := &tx.GetAbstractTransaction().TransactionInfo
+		if txInfo.Height < position.height || txInfo.Height < from {
+			break
+		}
+
+		if !position.contains(txInfo) {
+			missed = append(missed, tx)
+		}
+	}
+
+	if it.Err() != nil {
+		return it.Err()
+	}
+
+	sort.SliceStable(missed, func(i, j int) bool {
+		a, b := missed[i].GetAbstractTransaction(), missed[j].GetAbstractTransaction()
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+
+		return a.Index < b.Index
+	})
+
+	for _, tx := range missed {
+		txInfo := &tx.GetAbstractTransaction().TransactionInfo
+		txInfo.Backfilled = true
+		c.backfill.observeConfirmed(address, tx)
+		c.backfill.markDelivered(txInfo.TransactionHash)
+
+		if !deliverConfirmed(c.confirmedAddedSubscribers, address, tx) {
+			return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathConfirmedAdded, address.Address)))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/sdktest"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+func TestCatapultWebsocketClientImpl_Backfill(t *testing.T) {
+	node, err := sdktest.NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+	defer node.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+	cfg.WsReconnectionTimeout = 300 * time.Millisecond
+
+	client := sdk.NewClient(nil, cfg)
+	nemesis := node.Nemesis()
+
+	wsc, err := NewClient(ctx, cfg)
+	assert.Nilf(t, err, "NewClient returned error: %s", err)
+	defer wsc.Close()
+
+	go wsc.Listen()
+
+	blocks := make(chan *sdk.BlockInfo, 100)
+	_, err = wsc.AddBlockHandlers(func(b *sdk.BlockInfo) bool {
+		blocks <- b
+		return false
+	})
+	assert.Nilf(t, err, "AddBlockHandlers returned error: %s", err)
+
+	confirmed := make(chan sdk.Transaction, 100)
+	_, err = wsc.AddConfirmedAddedHandlers(nemesis.Address, func(tx sdk.Transaction) bool {
+		confirmed <- tx
+		return false
+	})
+	assert.Nilf(t, err, "AddConfirmedAddedHandlers returned error: %s", err)
+
+	transfer := func(message string) {
+		tx, err := client.NewTransferTransaction(sdk.NewDeadline(time.Hour), nemesis.Address, []*sdk.Mosaic{sdk.Xpx(1)}, sdk.NewPlainMessage(message))
+		assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+		stx, err := nemesis.Sign(tx)
+		assert.Nilf(t, err, "Sign returned error: %s", err)
+
+		_, err = client.Transaction.Announce(ctx, stx)
+		assert.Nilf(t, err, "Announce returned error: %s", err)
+	}
+
+	var received []*sdk.BlockInfo
+
+	// generates blocks until block with passed backfilled flag is received from websocket
+	receiveBlock := func(backfilled bool) {
+		for i := 0; i < 50; i++ {
+			node.GenerateBlock()
+
+			timeout := time.After(100 * time.Millisecond)
+			for {
+				select {
+				case b := <-blocks:
+					received = append(received, b)
+					if b.Backfilled == backfilled {
+						return
+					}
+
+					continue
+				case <-timeout:
+				}
+
+				break
+			}
+		}
+
+		t.Fatal("block is not received")
+	}
+
+	// websocket subscribes asynchronously, so the first blocks can be missed
+	receiveBlock(false)
+	received = received[len(received)-1:]
+	first := received[0].Height
+
+	transfer("live")
+	receiveBlock(false)
+
+	// new connection fails once, so blocks generated meanwhile are missed by websocket
+	node.Fail(sdktest.Failure{Path: "/ws", Times: 1})
+	node.DropWebsockets()
+
+	transfer("missed")
+	node.GenerateBlock()
+	node.GenerateBlock()
+
+	receiveBlock(false)
+
+	for i, b := range received[1:] {
+		assert.Equal(t, first+sdk.Height(i+1), b.Height, "blocks are not delivered in order without duplicates")
+	}
+
+	// blocks generated while websocket was disconnected
+	assert.True(t, received[2].Backfilled, "missed block is not backfilled")
+	assert.True(t, received[3].Backfilled, "missed block is not backfilled")
+	assert.False(t, received[len(received)-1].Backfilled)
+
+	var messages []string
+	var backfilled []bool
+	for len(confirmed) > 0 {
+		tx := <-confirmed
+		messages = append(messages, string(tx.(*sdk.TransferTransaction).Message.Payload()))
+		backfilled = append(backfilled, tx.GetAbstractTransaction().Backfilled)
+	}
+
+	assert.Equal(t, []string{"live", "missed"}, messages)
+	assert.Equal(t, []bool{false, true}, backfilled)
+}
+
+func TestCatapultWebsocketClientImpl_BackfillMaxBlocks(t *testing.T) {
+	node, err := sdktest.NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+	defer node.Close()
+
+	ctx := context.Background()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	c := &CatapultWebsocketClientImpl{
+		rest:              sdk.NewClient(nil, cfg),
+		backfillMaxBlocks: 2,
+		blockSubscriber:   subscribers.NewBlock(),
+	}
+
+	var heights []sdk.Height
+	_, err = c.blockSubscriber.AddHandlers(func(b *sdk.BlockInfo) bool {
+		heights = append(heights, b.Height)
+		return false
+	})
+	assert.Nilf(t, err, "AddHandlers returned error: %s", err)
+
+	c.backfill.observeBlock(&sdk.BlockInfo{Height: 1})
+
+	var height sdk.Height
+	for i := 0; i < 5; i++ {
+		height = node.GenerateBlock()
+	}
+
+	// only the latest blocks are replayed after long disconnection
+	err = c.backfillMissed(ctx)
+	assert.Nilf(t, err, "backfillMissed returned error: %s", err)
+	assert.Equal(t, []sdk.Height{height - 1, height}, heights)
+}
+
+func TestCatapultWebsocketClientImpl_BackfillUnknownAccount(t *testing.T) {
+	node, err := sdktest.NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+	defer node.Close()
+
+	ctx := context.Background()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	c := &CatapultWebsocketClientImpl{rest: sdk.NewClient(nil, cfg)}
+
+	account, err := sdk.NewAccount(sdk.MijinTest, cfg.GenerationHash)
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	c.backfill.watch(account.Address)
+
+	err = c.backfillConfirmed(ctx, account.Address, 1)
+	assert.Nilf(t, err, "backfillConfirmed returned error: %s", err)
+}
+
+func TestNewClient_RestClient(t *testing.T) {
+	node, err := sdktest.NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+	defer node.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	wsc, err := NewClient(ctx, cfg)
+	assert.Nilf(t, err, "NewClient returned error: %s", err)
+	defer wsc.Close()
+
+	c := wsc.(*CatapultWebsocketClientImpl)
+	assert.NotNil(t, c.rest)
+	assert.Equal(t, sdk.Height(DefaultBackfillMaxBlocks), c.backfillMaxBlocks)
+
+	// backfill is disabled without REST client
+	disabled, err := NewClient(ctx, cfg, WithRestClient(nil), WithBackfillMaxBlocks(10))
+	assert.Nilf(t, err, "NewClient returned error: %s", err)
+	defer disabled.Close()
+
+	c = disabled.(*CatapultWebsocketClientImpl)
+	assert.Nil(t, c.rest)
+	assert.Equal(t, sdk.Height(10), c.backfillMaxBlocks)
+}
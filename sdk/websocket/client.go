@@ -8,9 +8,8 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net"
 	"net/url"
-	"time"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
@@ -51,6 +50,19 @@ type (
 
 		conn *websocket.Conn
 
+		// serializes adding and removing of handlers with subscribe and unsubscribe messages
+		subscriptionMutex sync.Mutex
+
+		// REST client which fetches blocks and confirmed transactions missed while websocket was reconnecting, is set by WithRestClient
+		rest     *sdk.Client
+		backfill backfill
+		// only events of this number of the latest blocks are backfilled, is set by WithBackfillMaxBlocks
+		backfillMaxBlocks sdk.Height
+
+		// receives changes of connection state, is set by WithConnectionHandler
+		onConnectionEvent func(*ConnectionEvent)
+		metrics           metrics
+
 		blockSubscriber               subscribers.Block
 		statusSubscribers             subscribers.Status
 		cosignatureSubscribers        subscribers.Cosignature
@@ -83,27 +95,66 @@ type (
 		Client
 
 		Config() *sdk.Config
-		AddBlockHandlers(handlers ...subscribers.BlockHandler) error
-		AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error
-		AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error
-		AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) error
-		AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error
-		AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) error
-		AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) error
-		AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error
-		AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) error
+		AddBlockHandlers(handlers ...subscribers.BlockHandler) (*Subscription, error)
+		AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) (*Subscription, error)
+		AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) (*Subscription, error)
+		AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) (*Subscription, error)
+		AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) (*Subscription, error)
+		AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) (*Subscription, error)
+		AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) (*Subscription, error)
+		AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) (*Subscription, error)
+		AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) (*Subscription, error)
+		RemoveBlockHandlers() error
+		RemoveConfirmedAddedHandlers(address *sdk.Address) error
+		RemoveUnconfirmedAddedHandlers(address *sdk.Address) error
+		RemoveUnconfirmedRemovedHandlers(address *sdk.Address) error
+		RemovePartialAddedHandlers(address *sdk.Address) error
+		RemovePartialRemovedHandlers(address *sdk.Address) error
+		RemoveStatusHandlers(address *sdk.Address) error
+		RemoveCosignatureHandlers(address *sdk.Address) error
+		RemoveDriveStateHandlers(address *sdk.Address) error
+		// removes all handlers of passed channel and address, address is ignored for BlockChannel
+		Unsubscribe(address *sdk.Address, channel Path) error
+		// channel alternatives of AddXxxHandlers, channels are closed when passed context is done
+		SubscribeBlocks(ctx context.Context, opts ...StreamOption) (<-chan *sdk.BlockInfo, *Stream, error)
+		SubscribeConfirmed(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan sdk.Transaction, *Stream, error)
+		SubscribeUnconfirmedAdded(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan sdk.Transaction, *Stream, error)
+		SubscribeUnconfirmedRemoved(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.UnconfirmedRemoved, *Stream, error)
+		SubscribePartialAdded(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.AggregateTransaction, *Stream, error)
+		SubscribePartialRemoved(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.PartialRemovedInfo, *Stream, error)
+		SubscribeStatus(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.StatusInfo, *Stream, error)
+		SubscribeCosignature(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.SignerInfo, *Stream, error)
+		SubscribeDriveState(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.DriveStateInfo, *Stream, error)
+		// returns numbers of received and dropped messages by topic
+		Metrics() map[Path]TopicMetrics
 	}
 )
 
-func NewClient(ctx context.Context, cfg *sdk.Config) (CatapultClient, error) {
+func NewClient(ctx context.Context, cfg *sdk.Config, opts ...ClientOption) (CatapultClient, error) {
 	ctx, cancelFunc := context.WithCancel(ctx)
 
+	clientCfg := &clientConfig{}
+	for _, opt := range opts {
+		opt(clientCfg)
+	}
+
+	if !clientCfg.restSet {
+		clientCfg.rest = sdk.NewClient(nil, cfg)
+	}
+
+	if clientCfg.backfillMaxBlocks == 0 {
+		clientCfg.backfillMaxBlocks = DefaultBackfillMaxBlocks
+	}
+
 	socketClient := &CatapultWebsocketClientImpl{
 		ctx:        ctx,
 		cancelFunc: cancelFunc,
 
 		config: cfg,
 
+		rest:              clientCfg.rest,
+		backfillMaxBlocks: clientCfg.backfillMaxBlocks,
+
 		blockSubscriber:               subscribers.NewBlock(),
 		statusSubscribers:             subscribers.NewStatus(),
 		cosignatureSubscribers:        subscribers.NewCosignature(),
@@ -121,11 +172,13 @@ func NewClient(ctx context.Context, cfg *sdk.Config) (CatapultClient, error) {
 		connectionCh: make(chan *websocket.Conn),
 
 		connectFn: connect,
+
+		onConnectionEvent: clientCfg.onConnectionEvent,
 	}
 
 	go socketClient.handleSignal()
 
-	if err := socketClient.initNewConnection(); err != nil {
+	if err := socketClient.connectNew(0); err != nil {
 		return socketClient, err
 	}
 
@@ -152,62 +205,132 @@ func (c *CatapultWebsocketClientImpl) Config() *sdk.Config {
 	return c.config
 }
 
-func (c *CatapultWebsocketClientImpl) AddBlockHandlers(handlers ...subscribers.BlockHandler) error {
+func (c *CatapultWebsocketClientImpl) AddBlockHandlers(handlers ...subscribers.BlockHandler) (*Subscription, error) {
 	if len(handlers) == 0 {
-		return nil
+		return nil, nil
 	}
 
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathBlock) {
 		c.topicHandlers.SetTopicHandler(pathBlock, &TopicHandler{
-			Handler: hdlrs.NewBlockHandler(sdk.BlockMapperFn(sdk.MapBlock), c.blockSubscriber),
+			Handler: &blockTopicHandler{backfill: &c.backfill, subscribers: c.blockSubscriber},
 			Topic:   topicFormatFn(formatBlockTopic),
 		})
 	}
 
 	if !c.blockSubscriber.HasHandlers() {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, pathBlock); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	if err := c.blockSubscriber.AddHandlers(handlers...); err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+	refs, err := c.blockSubscriber.AddHandlers(handlers...)
+	if err != nil {
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeBlockHandlers(refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers added by AddBlockHandlers and unsubscribes from block topic
+func (c *CatapultWebsocketClientImpl) RemoveBlockHandlers() error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.blockSubscriber == nil {
+		return nil
+	}
+
+	return c.removeBlockHandlers(c.blockSubscriber.GetHandlers()...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeBlockHandlers(handlers ...*subscribers.BlockHandler) error {
+	if c.blockSubscriber == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.blockSubscriber.RemoveHandlers(handlers...) || c.blockSubscriber.HasHandlers() {
 		return nil
 	}
 
+	return c.publishUnsubscribe(pathBlock)
+}
+
+func (c *CatapultWebsocketClientImpl) AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathConfirmedAdded) {
 		c.topicHandlers.SetTopicHandler(pathConfirmedAdded, &TopicHandler{
-			Handler: hdlrs.NewConfirmedAddedHandler(sdk.NewConfirmedAddedMapper(sdk.MapTransaction, c.config.GenerationHash), c.confirmedAddedSubscribers),
-			Topic:   topicFormatFn(formatPlainTopic),
+			Handler: &confirmedAddedTopicHandler{
+				backfill:    &c.backfill,
+				mapper:      sdk.NewConfirmedAddedMapper(sdk.MapTransaction, c.config.GenerationHash),
+				subscribers: c.confirmedAddedSubscribers,
+			},
+			Topic: topicFormatFn(formatPlainTopic),
 		})
 	}
 
 	if !c.confirmedAddedSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathConfirmedAdded, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
+
+		c.backfill.watch(address)
 	}
 
-	err := c.confirmedAddedSubscribers.AddHandlers(address, handlers...)
+	refs, err := c.confirmedAddedSubscribers.AddHandlers(address, handlers...)
 	if err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeConfirmedAddedHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddConfirmedAddedHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemoveConfirmedAddedHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.confirmedAddedSubscribers == nil {
+		return nil
+	}
+
+	return c.removeConfirmedAddedHandlers(address, c.confirmedAddedSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeConfirmedAddedHandlers(address *sdk.Address, handlers ...*subscribers.ConfirmedAddedHandler) error {
+	if c.confirmedAddedSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.confirmedAddedSubscribers.RemoveHandlers(address, handlers...) || c.confirmedAddedSubscribers.HasHandlers(address) {
 		return nil
 	}
 
+	c.backfill.forget(address)
+
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathConfirmedAdded, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathUnconfirmedAdded) {
 		c.topicHandlers.SetTopicHandler(pathUnconfirmedAdded, &TopicHandler{
 			Handler: hdlrs.NewUnconfirmedAddedHandler(sdk.NewUnconfirmedAddedMapper(sdk.MapTransaction, c.config.GenerationHash), c.unconfirmedAddedSubscribers),
@@ -217,23 +340,52 @@ func (c *CatapultWebsocketClientImpl) AddUnconfirmedAddedHandlers(address *sdk.A
 
 	if !c.unconfirmedAddedSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathUnconfirmedAdded, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	err := c.unconfirmedAddedSubscribers.AddHandlers(address, handlers...)
+	refs, err := c.unconfirmedAddedSubscribers.AddHandlers(address, handlers...)
 	if err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeUnconfirmedAddedHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddUnconfirmedAddedHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemoveUnconfirmedAddedHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.unconfirmedAddedSubscribers == nil {
+		return nil
+	}
+
+	return c.removeUnconfirmedAddedHandlers(address, c.unconfirmedAddedSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeUnconfirmedAddedHandlers(address *sdk.Address, handlers ...*subscribers.UnconfirmedAddedHandler) error {
+	if c.unconfirmedAddedSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.unconfirmedAddedSubscribers.RemoveHandlers(address, handlers...) || c.unconfirmedAddedSubscribers.HasHandlers(address) {
 		return nil
 	}
 
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathUnconfirmedAdded, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathUnconfirmedRemoved) {
 		c.topicHandlers.SetTopicHandler(pathUnconfirmedRemoved, &TopicHandler{
 			Handler: hdlrs.NewUnconfirmedRemovedHandler(sdk.UnconfirmedRemovedMapperFn(sdk.MapUnconfirmedRemoved), c.unconfirmedRemovedSubscribers),
@@ -243,23 +395,52 @@ func (c *CatapultWebsocketClientImpl) AddUnconfirmedRemovedHandlers(address *sdk
 
 	if !c.unconfirmedRemovedSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathUnconfirmedRemoved, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	err := c.unconfirmedRemovedSubscribers.AddHandlers(address, handlers...)
+	refs, err := c.unconfirmedRemovedSubscribers.AddHandlers(address, handlers...)
 	if err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeUnconfirmedRemovedHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddUnconfirmedRemovedHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemoveUnconfirmedRemovedHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.unconfirmedRemovedSubscribers == nil {
 		return nil
 	}
 
+	return c.removeUnconfirmedRemovedHandlers(address, c.unconfirmedRemovedSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...*subscribers.UnconfirmedRemovedHandler) error {
+	if c.unconfirmedRemovedSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.unconfirmedRemovedSubscribers.RemoveHandlers(address, handlers...) || c.unconfirmedRemovedSubscribers.HasHandlers(address) {
+		return nil
+	}
+
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathUnconfirmedRemoved, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathPartialAdded) {
 		c.topicHandlers.SetTopicHandler(pathPartialAdded, &TopicHandler{
 			Handler: hdlrs.NewPartialAddedHandler(sdk.NewPartialAddedMapper(sdk.MapTransaction, c.config.GenerationHash), c.partialAddedSubscribers),
@@ -269,23 +450,52 @@ func (c *CatapultWebsocketClientImpl) AddPartialAddedHandlers(address *sdk.Addre
 
 	if !c.partialAddedSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathPartialAdded, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	err := c.partialAddedSubscribers.AddHandlers(address, handlers...)
+	refs, err := c.partialAddedSubscribers.AddHandlers(address, handlers...)
 	if err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removePartialAddedHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddPartialAddedHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemovePartialAddedHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.partialAddedSubscribers == nil {
+		return nil
+	}
+
+	return c.removePartialAddedHandlers(address, c.partialAddedSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removePartialAddedHandlers(address *sdk.Address, handlers ...*subscribers.PartialAddedHandler) error {
+	if c.partialAddedSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.partialAddedSubscribers.RemoveHandlers(address, handlers...) || c.partialAddedSubscribers.HasHandlers(address) {
 		return nil
 	}
 
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathPartialAdded, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathPartialRemoved) {
 		c.topicHandlers.SetTopicHandler(pathPartialRemoved, &TopicHandler{
 			Handler: hdlrs.NewPartialRemovedHandler(sdk.PartialRemovedMapperFn(sdk.MapPartialRemoved), c.partialRemovedSubscribers),
@@ -295,23 +505,52 @@ func (c *CatapultWebsocketClientImpl) AddPartialRemovedHandlers(address *sdk.Add
 
 	if !c.partialRemovedSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathPartialRemoved, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	err := c.partialRemovedSubscribers.AddHandlers(address, handlers...)
+	refs, err := c.partialRemovedSubscribers.AddHandlers(address, handlers...)
 	if err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removePartialRemovedHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddPartialRemovedHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemovePartialRemovedHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.partialRemovedSubscribers == nil {
 		return nil
 	}
 
+	return c.removePartialRemovedHandlers(address, c.partialRemovedSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removePartialRemovedHandlers(address *sdk.Address, handlers ...*subscribers.PartialRemovedHandler) error {
+	if c.partialRemovedSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.partialRemovedSubscribers.RemoveHandlers(address, handlers...) || c.partialRemovedSubscribers.HasHandlers(address) {
+		return nil
+	}
+
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathPartialRemoved, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathStatus) {
 		c.topicHandlers.SetTopicHandler(pathStatus, &TopicHandler{
 			Handler: hdlrs.NewStatusHandler(sdk.StatusMapperFn(sdk.MapStatus), c.statusSubscribers),
@@ -321,23 +560,52 @@ func (c *CatapultWebsocketClientImpl) AddStatusHandlers(address *sdk.Address, ha
 
 	if !c.statusSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathStatus, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	err := c.statusSubscribers.AddHandlers(address, handlers...)
+	refs, err := c.statusSubscribers.AddHandlers(address, handlers...)
 	if err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeStatusHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddStatusHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemoveStatusHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.statusSubscribers == nil {
 		return nil
 	}
 
+	return c.removeStatusHandlers(address, c.statusSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeStatusHandlers(address *sdk.Address, handlers ...*subscribers.StatusHandler) error {
+	if c.statusSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.statusSubscribers.RemoveHandlers(address, handlers...) || c.statusSubscribers.HasHandlers(address) {
+		return nil
+	}
+
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathStatus, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(pathCosignature) {
 		c.topicHandlers.SetTopicHandler(pathCosignature, &TopicHandler{
 			Handler: hdlrs.NewCosignatureHandler(sdk.CosignatureMapperFn(sdk.MapCosignature), c.cosignatureSubscribers),
@@ -347,22 +615,52 @@ func (c *CatapultWebsocketClientImpl) AddCosignatureHandlers(address *sdk.Addres
 
 	if !c.cosignatureSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathCosignature, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	if err := c.cosignatureSubscribers.AddHandlers(address, handlers...); err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+	refs, err := c.cosignatureSubscribers.AddHandlers(address, handlers...)
+	if err != nil {
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeCosignatureHandlers(address, refs...)
+	}), nil
 }
 
-func (c *CatapultWebsocketClientImpl) AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) error {
-	if len(handlers) == 0 {
+// removes all handlers of passed address added by AddCosignatureHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemoveCosignatureHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.cosignatureSubscribers == nil {
+		return nil
+	}
+
+	return c.removeCosignatureHandlers(address, c.cosignatureSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeCosignatureHandlers(address *sdk.Address, handlers ...*subscribers.CosignatureHandler) error {
+	if c.cosignatureSubscribers == nil || len(handlers) == 0 {
 		return nil
 	}
 
+	if !c.cosignatureSubscribers.RemoveHandlers(address, handlers...) || c.cosignatureSubscribers.HasHandlers(address) {
+		return nil
+	}
+
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", pathCosignature, address.Address)))
+}
+
+func (c *CatapultWebsocketClientImpl) AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) (*Subscription, error) {
+	if len(handlers) == 0 {
+		return nil, nil
+	}
+
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	if !c.topicHandlers.HasHandler(driveState) {
 		c.topicHandlers.SetTopicHandler(driveState, &TopicHandler{
 			Handler: hdlrs.NewDriveStateHandler(sdk.DriveStateMapperFn(sdk.MapDriveState), c.driveStateSubscribers),
@@ -372,18 +670,48 @@ func (c *CatapultWebsocketClientImpl) AddDriveStateHandlers(address *sdk.Address
 
 	if !c.driveStateSubscribers.HasHandlers(address) {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", driveState, address.Address))); err != nil {
-			return errors.Wrap(err, "publishing subscribe message into websocket")
+			return nil, errors.Wrap(err, "publishing subscribe message into websocket")
 		}
 	}
 
-	if err := c.driveStateSubscribers.AddHandlers(address, handlers...); err != nil {
-		return errors.Wrap(err, "adding handlers functions into handlers storage")
+	refs, err := c.driveStateSubscribers.AddHandlers(address, handlers...)
+	if err != nil {
+		return nil, errors.Wrap(err, "adding handlers functions into handlers storage")
 	}
 
-	return nil
+	return c.newSubscription(func() error {
+		return c.removeDriveStateHandlers(address, refs...)
+	}), nil
+}
+
+// removes all handlers of passed address added by AddDriveStateHandlers and unsubscribes from their topic
+func (c *CatapultWebsocketClientImpl) RemoveDriveStateHandlers(address *sdk.Address) error {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
+	if c.driveStateSubscribers == nil {
+		return nil
+	}
+
+	return c.removeDriveStateHandlers(address, c.driveStateSubscribers.GetHandlers(address)...)
+}
+
+func (c *CatapultWebsocketClientImpl) removeDriveStateHandlers(address *sdk.Address, handlers ...*subscribers.DriveStateHandler) error {
+	if c.driveStateSubscribers == nil || len(handlers) == 0 {
+		return nil
+	}
+
+	if !c.driveStateSubscribers.RemoveHandlers(address, handlers...) || c.driveStateSubscribers.HasHandlers(address) {
+		return nil
+	}
+
+	return c.publishUnsubscribe(Path(fmt.Sprintf("%s/%s", driveState, address.Address)))
 }
 
 func (c *CatapultWebsocketClientImpl) handleSignal() {
+	// number of failed connection attempts in a row
+	attempt := 0
+
 	for {
 		select {
 		case conn := <-c.connectionCh:
@@ -394,38 +722,62 @@ func (c *CatapultWebsocketClientImpl) handleSignal() {
 				c.listenCh <- false
 			}()
 
-		case <-c.listenCh:
+		// listen is true when it is triggered by Listen, false on reconnection
+		case listen := <-c.listenCh:
 
 			if c.conn == nil {
-				err := c.initNewConnection()
-				if err != nil {
+				if err := c.connectNew(attempt); err != nil {
+					attempt++
 					fmt.Println("websocket: connection is failed. Try again after wait period")
-					select {
-					case <-time.NewTicker(c.config.WsReconnectionTimeout).C:
-						go func() {
-							c.listenCh <- true
-						}()
-						continue
-					}
+					c.retryListen(listen, attempt)
+					continue
 				}
 			}
 
 			err := c.updateHandlers()
 			if err != nil {
+				attempt++
 				fmt.Println("websocket: update handles is failed. Try again after timeout period")
-				select {
-				case <-time.NewTicker(c.config.WsReconnectionTimeout).C:
-					continue
-				}
+				c.closeConnection(c.conn)
+				c.notify(&ConnectionEvent{State: Disconnected, Err: err, Attempt: attempt})
+				c.retryListen(listen, attempt)
+				continue
+			}
+
+			attempt = 0
+			if !listen {
+				c.notify(&ConnectionEvent{State: Resubscribed, UID: c.UID})
+			}
 
+			if err := c.backfillMissed(c.ctx); err != nil {
+				fmt.Println(fmt.Sprintf("websocket: missed events are not backfilled: %s", err))
 			}
-			fmt.Println(fmt.Sprintf("websocket: connection established: %s", c.config.UsedBaseUrl.String()))
+
+			fmt.Println(fmt.Sprintf("websocket: connection established: %s", c.config.GetUsedBaseUrl().String()))
 			c.startListener()
 		}
 	}
 }
 
+// opens new connection and reports its states, attempt is the number of failed attempts before this one
+func (c *CatapultWebsocketClientImpl) connectNew(attempt int) error {
+	c.notify(&ConnectionEvent{State: Connecting, Attempt: attempt})
+
+	if err := c.initNewConnection(); err != nil {
+		c.notify(&ConnectionEvent{State: Disconnected, Err: err, Attempt: attempt + 1})
+		return err
+	}
+
+	c.notify(&ConnectionEvent{State: Connected, UID: c.UID})
+
+	return nil
+}
+
 func (c *CatapultWebsocketClientImpl) removeHandlers() {
+	// subscriptions can be unsubscribed concurrently with closing of client
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+
 	c.blockSubscriber = nil
 	c.confirmedAddedSubscribers = nil
 	c.unconfirmedAddedSubscribers = nil
@@ -449,22 +801,29 @@ func (c *CatapultWebsocketClientImpl) closeConnection(conn *websocket.Conn) {
 }
 
 func (c *CatapultWebsocketClientImpl) startListener() {
+	conn := c.conn
+
+	stopHeartbeat := c.startHeartbeat(conn)
+	defer stopHeartbeat()
+
 	for {
-		_, resp, e := c.conn.ReadMessage()
+		_, resp, e := conn.ReadMessage()
 		if e != nil {
-			if _, ok := e.(*net.OpError); ok {
+			if c.ctx.Err() != nil {
 				// Stop ReadMessage if user called Close function for websocket client
+				c.notify(&ConnectionEvent{State: Disconnected, Err: c.ctx.Err()})
 				return
 			}
 
-			if _, ok := e.(*websocket.CloseError); ok {
-				go func() {
-					c.reconnectCh <- c.conn
-				}()
-				return
-			}
+			// connection is closed by server, broken or doesn't answer pings
+			c.notify(&ConnectionEvent{State: Disconnected, Err: e})
+			go func() {
+				c.reconnectCh <- conn
+			}()
+			return
 		}
 
+		c.extendReadDeadline(conn)
 		c.messageRouter.RouteMessage(resp)
 	}
 }
@@ -479,7 +838,7 @@ func (c *CatapultWebsocketClientImpl) initNewConnection() error {
 	c.conn = conn
 
 	messagePublisher := newMessagePublisher(c.conn)
-	messageRouter := NewRouter(c.UID, messagePublisher, c.topicHandlers)
+	messageRouter := newRouter(c.UID, messagePublisher, c.topicHandlers, &c.metrics)
 
 	c.messageRouter = messageRouter
 	c.messagePublisher = messagePublisher
@@ -489,7 +848,8 @@ func (c *CatapultWebsocketClientImpl) initNewConnection() error {
 
 func (c *CatapultWebsocketClientImpl) updateHandlers() error {
 
-	if c.topicHandlers.HasHandler(pathBlock) {
+	// topic handler of block stays registered after the last block handler is removed
+	if c.blockSubscriber != nil && c.blockSubscriber.HasHandlers() {
 		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s", pathBlock))); err != nil {
 			return err
 		}
@@ -544,11 +904,13 @@ func connect(cfg *sdk.Config) (*websocket.Conn, string, error) {
 	var conn *websocket.Conn
 	var err error
 
-	conn, _, err = websocket.DefaultDialer.Dial(convertToWsUrl(cfg.UsedBaseUrl).String(), nil)
+	usedBaseUrl := cfg.GetUsedBaseUrl()
+
+	conn, _, err = websocket.DefaultDialer.Dial(convertToWsUrl(usedBaseUrl).String(), nil)
 	if err != nil {
 		for _, u := range cfg.BaseURLs {
 
-			if u == cfg.UsedBaseUrl {
+			if u == usedBaseUrl {
 				continue
 			}
 
@@ -557,7 +919,7 @@ func connect(cfg *sdk.Config) (*websocket.Conn, string, error) {
 				continue
 			}
 
-			cfg.UsedBaseUrl = u
+			cfg.SetUsedBaseUrl(u)
 			break
 		}
 	}
@@ -57,11 +57,11 @@ func TestCatapultWebsocketClientImpl_AddBlockHandlers(t *testing.T) {
 	blockSubscriberError := errors.New("block subscription error")
 	blockSubscriberErrorObj := new(mocks.Block)
 	blockSubscriberErrorObj.On("HasHandlers").Return(true).Once().
-		On("AddHandlers", mock.Anything, mock.Anything).Return(blockSubscriberError)
+		On("AddHandlers", mock.Anything, mock.Anything).Return(nil, blockSubscriberError)
 
 	blockSubscriberSuccessObj := new(mocks.Block)
 	blockSubscriberSuccessObj.On("HasHandlers").Return(true).Once().
-		On("AddHandlers", mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -126,7 +126,7 @@ func TestCatapultWebsocketClientImpl_AddBlockHandlers(t *testing.T) {
 				messagePublisher: tt.fields.messagePublisher,
 			}
 
-			err := c.AddBlockHandlers(tt.args.handlers...)
+			_, err := c.AddBlockHandlers(tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -176,8 +176,8 @@ func TestCatapultWebsocketClientImpl_AddConfirmedAddedHandlers(t *testing.T) {
 	mockSubscribers := new(mocks.ConfirmedAdded)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -247,7 +247,7 @@ func TestCatapultWebsocketClientImpl_AddConfirmedAddedHandlers(t *testing.T) {
 				topicHandlers:             tt.fields.topicHandlers,
 				messagePublisher:          tt.fields.messagePublisher,
 			}
-			err := c.AddConfirmedAddedHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddConfirmedAddedHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -296,8 +296,8 @@ func TestCatapultWebsocketClientImpl_AddUnconfirmedAddedHandlers(t *testing.T) {
 	mockSubscribers := new(mocks.UnconfirmedAdded)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -354,7 +354,7 @@ func TestCatapultWebsocketClientImpl_AddUnconfirmedAddedHandlers(t *testing.T) {
 				topicHandlers:               tt.fields.topicHandlers,
 				messagePublisher:            tt.fields.messagePublisher,
 			}
-			err := c.AddUnconfirmedAddedHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddUnconfirmedAddedHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -404,8 +404,8 @@ func TestCatapultWebsocketClientImpl_AddUnconfirmedRemovedHandlers(t *testing.T)
 	mockSubscribers := new(mocks.UnconfirmedRemoved)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -475,7 +475,7 @@ func TestCatapultWebsocketClientImpl_AddUnconfirmedRemovedHandlers(t *testing.T)
 				messagePublisher:              tt.fields.messagePublisher,
 				unconfirmedRemovedSubscribers: tt.fields.unconfirmedRemovedSubscribers,
 			}
-			err := c.AddUnconfirmedRemovedHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddUnconfirmedRemovedHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -525,8 +525,8 @@ func TestCatapultWebsocketClientImpl_AddPartialAddedHandlers(t *testing.T) {
 	mockSubscribers := new(mocks.PartialAdded)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -597,7 +597,7 @@ func TestCatapultWebsocketClientImpl_AddPartialAddedHandlers(t *testing.T) {
 				topicHandlers:           tt.fields.topicHandlers,
 				messagePublisher:        tt.fields.messagePublisher,
 			}
-			err := c.AddPartialAddedHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddPartialAddedHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -647,8 +647,8 @@ func TestCatapultWebsocketClientImpl_AddPartialRemovedHandlers(t *testing.T) {
 	mockSubscribers := new(mocks.PartialRemoved)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -718,7 +718,7 @@ func TestCatapultWebsocketClientImpl_AddPartialRemovedHandlers(t *testing.T) {
 				topicHandlers:             tt.fields.topicHandlers,
 				messagePublisher:          tt.fields.messagePublisher,
 			}
-			err := c.AddPartialRemovedHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddPartialRemovedHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -768,8 +768,8 @@ func TestCatapultWebsocketClientImpl_AddStatusHandlers(t *testing.T) {
 	mockSubscribers := new(mocks.Status)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -839,7 +839,7 @@ func TestCatapultWebsocketClientImpl_AddStatusHandlers(t *testing.T) {
 				topicHandlers:     tt.fields.topicHandlers,
 				messagePublisher:  tt.fields.messagePublisher,
 			}
-			err := c.AddStatusHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddStatusHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -889,8 +889,8 @@ func TestCatapultWebsocketClientImpl_AddCosignatureHandlers(t *testing.T) {
 	mockSubscribers := new(mocks.Cosignature)
 	mockSubscribers.On("HasHandlers", address).Return(false).Once().
 		On("HasHandlers", address).Return(true).
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(subscribersAddHandlersError).Once().
-		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil)
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, subscribersAddHandlersError).Once().
+		On("AddHandlers", address, mock.Anything, mock.Anything).Return(nil, nil)
 
 	tests := []struct {
 		name    string
@@ -960,7 +960,7 @@ func TestCatapultWebsocketClientImpl_AddCosignatureHandlers(t *testing.T) {
 				topicHandlers:          tt.fields.topicHandlers,
 				messagePublisher:       tt.fields.messagePublisher,
 			}
-			err := c.AddCosignatureHandlers(tt.args.address, tt.args.handlers...)
+			_, err := c.AddCosignatureHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
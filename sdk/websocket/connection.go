@@ -0,0 +1,181 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+type ConnectionState uint8
+
+// ConnectionState enums
+const (
+	// client dials websocket server
+	Connecting ConnectionState = iota + 1
+	// connection is opened, server assigned UID to it
+	Connected
+	// connection is lost or connection attempt is failed
+	Disconnected
+	// topics of handlers are subscribed again on reconnected connection
+	Resubscribed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case Connecting:
+		return "Connecting"
+	case Connected:
+		return "Connected"
+	case Disconnected:
+		return "Disconnected"
+	case Resubscribed:
+		return "Resubscribed"
+	default:
+		return fmt.Sprintf("%d", s)
+	}
+}
+
+// ConnectionEvent reports change of state of websocket connection
+type ConnectionEvent struct {
+	State ConnectionState
+	// UID of connection, is set for Connected and Resubscribed
+	UID string
+	// reason of disconnection, is set for Disconnected
+	Err error
+	// number of failed connection attempts in a row, the next attempt is made after backoff delay
+	Attempt int
+}
+
+func (e *ConnectionEvent) String() string {
+	return fmt.Sprintf(
+		`[State: %s, UID: %s, Err: %v, Attempt: %d]`,
+		e.State,
+		e.UID,
+		e.Err,
+		e.Attempt,
+	)
+}
+
+type clientConfig struct {
+	onConnectionEvent func(*ConnectionEvent)
+	rest              *sdk.Client
+	restSet           bool
+	backfillMaxBlocks sdk.Height
+}
+
+// ClientOption configures websocket client created by NewClient
+type ClientOption func(*clientConfig)
+
+// sets function which receives changes of connection state.
+// Calls are never concurrent, client doesn't read messages until function returns
+func WithConnectionHandler(handler func(*ConnectionEvent)) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.onConnectionEvent = handler
+	}
+}
+
+// sets REST client which backfills blocks and confirmed transactions missed while websocket was reconnecting.
+// nil disables backfill, by default REST client is created from config passed to NewClient
+func WithRestClient(client *sdk.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.rest = client
+		cfg.restSet = true
+	}
+}
+
+// sets maximum number of the latest blocks which are backfilled after reconnection, DefaultBackfillMaxBlocks is used if zero.
+// Events of older blocks are not delivered
+func WithBackfillMaxBlocks(blocks uint64) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.backfillMaxBlocks = sdk.Height(blocks)
+	}
+}
+
+func (c *CatapultWebsocketClientImpl) notify(event *ConnectionEvent) {
+	if c.onConnectionEvent != nil {
+		c.onConnectionEvent(event)
+	}
+}
+
+// returns delay before reconnection after passed number of failed attempts in a row
+func reconnectionDelay(cfg *sdk.Config, attempt int) time.Duration {
+	delay := cfg.WsReconnectionTimeout
+	for i := 1; i < attempt && delay < cfg.WsMaxReconnectionDelay; i++ {
+		delay *= 2
+	}
+
+	if cfg.WsMaxReconnectionDelay > cfg.WsReconnectionTimeout && delay > cfg.WsMaxReconnectionDelay {
+		delay = cfg.WsMaxReconnectionDelay
+	}
+
+	return delay
+}
+
+// triggers listening again after backoff delay of passed attempt, nothing is triggered if client is closed meanwhile
+func (c *CatapultWebsocketClientImpl) retryListen(listen bool, attempt int) {
+	go func() {
+		timer := time.NewTimer(reconnectionDelay(c.config, attempt))
+		defer timer.Stop()
+
+		select {
+		case <-c.ctx.Done():
+		case <-timer.C:
+			c.listenCh <- listen
+		}
+	}()
+}
+
+// pings server while connection is listened, returned function stops pings.
+// Pong handler extends read deadline of connection, so ReadMessage fails when server doesn't answer
+func (c *CatapultWebsocketClientImpl) startHeartbeat(conn *websocket.Conn) func() {
+	interval := c.config.WsPingInterval
+	if interval <= 0 {
+		return func() {}
+	}
+
+	c.extendReadDeadline(conn)
+	conn.SetPongHandler(func(string) error {
+		c.extendReadDeadline(conn)
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// failed ping is detected by deadline of reading
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(interval)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
+
+// connection is alive while anything is received during two ping intervals
+func (c *CatapultWebsocketClientImpl) extendReadDeadline(conn *websocket.Conn) {
+	if c.config.WsPingInterval <= 0 {
+		return
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(2 * c.config.WsPingInterval)); err != nil {
+		fmt.Println(fmt.Sprintf("websocket: setting read deadline error: %s", err))
+	}
+}
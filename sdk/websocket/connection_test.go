@@ -0,0 +1,135 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+func TestReconnectionDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		max     time.Duration
+		delays  []time.Duration
+	}{
+		{
+			name:    "exponential backoff",
+			timeout: time.Second,
+			max:     5 * time.Second,
+			delays:  []time.Duration{time.Second, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:    "fixed timeout",
+			timeout: time.Second,
+			max:     0,
+			delays:  []time.Duration{time.Second, time.Second, time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &sdk.Config{WsReconnectionTimeout: tt.timeout, WsMaxReconnectionDelay: tt.max}
+
+			for attempt, want := range tt.delays {
+				assert.Equal(t, want, reconnectionDelay(cfg, attempt), "attempt %d", attempt)
+			}
+		})
+	}
+}
+
+// server which sends uid to every connection, the first connection doesn't answer pings
+type heartbeatServer struct {
+	upgrader websocket.Upgrader
+
+	mutex       sync.Mutex
+	connections int
+}
+
+func (s *heartbeatServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mutex.Lock()
+	s.connections++
+	uid := fmt.Sprintf("UID%d", s.connections)
+	if s.connections == 1 {
+		conn.SetPingHandler(func(string) error { return nil })
+	}
+	s.mutex.Unlock()
+
+	if err := conn.WriteJSON(&wsConnectionResponse{Uid: uid}); err != nil {
+		return
+	}
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func TestCatapultWebsocketClientImpl_Heartbeat(t *testing.T) {
+	server := httptest.NewServer(&heartbeatServer{})
+	defer server.Close()
+
+	cfg, err := sdk.NewConfigWithReputation([]string{server.URL}, sdk.Mijin, nil, 10*time.Millisecond, nil, sdk.DefaultFeeCalculationStrategy)
+	assert.Nilf(t, err, "NewConfigWithReputation returned error: %s", err)
+	cfg.WsPingInterval = 50 * time.Millisecond
+
+	events := make(chan *ConnectionEvent, 100)
+	wsc, err := NewClient(context.Background(), cfg, WithConnectionHandler(func(e *ConnectionEvent) {
+		events <- e
+	}))
+	assert.Nilf(t, err, "NewClient returned error: %s", err)
+
+	go wsc.Listen()
+
+	next := func() *ConnectionEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("connection event is not received")
+			return nil
+		}
+	}
+
+	assert.Equal(t, &ConnectionEvent{State: Connecting}, next())
+	assert.Equal(t, &ConnectionEvent{State: Connected, UID: "UID1"}, next())
+
+	// the first connection is dead, because server doesn't answer pings
+	disconnected := next()
+	assert.Equal(t, Disconnected, disconnected.State)
+	assert.NotNil(t, disconnected.Err)
+
+	assert.Equal(t, &ConnectionEvent{State: Connecting}, next())
+	assert.Equal(t, &ConnectionEvent{State: Connected, UID: "UID2"}, next())
+	assert.Equal(t, &ConnectionEvent{State: Resubscribed, UID: "UID2"}, next())
+
+	// the second connection stays alive while pings are answered
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected connection event: %s", e)
+	case <-time.After(5 * cfg.WsPingInterval):
+	}
+
+	assert.Nil(t, wsc.Close())
+	assert.Equal(t, &ConnectionEvent{State: Disconnected, Err: context.Canceled}, next())
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// TopicMetrics counts messages of one websocket topic
+type TopicMetrics struct {
+	// number of messages received from server
+	Received uint64
+	// number of messages which are not delivered: messages without topic handler
+	// and messages dropped on overflow of channels returned by SubscribeXxx
+	Dropped uint64
+}
+
+// metrics keeps TopicMetrics by topic, zero value is ready to use
+type metrics struct {
+	sync.Mutex
+	topics map[Path]*TopicMetrics
+}
+
+func (m *metrics) topic(path Path) *TopicMetrics {
+	if m.topics == nil {
+		m.topics = make(map[Path]*TopicMetrics)
+	}
+
+	t, ok := m.topics[path]
+	if !ok {
+		t = &TopicMetrics{}
+		m.topics[path] = t
+	}
+
+	return t
+}
+
+func (m *metrics) received(path Path) {
+	if m == nil {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.topic(path).Received++
+}
+
+func (m *metrics) dropped(path Path) {
+	if m == nil {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.topic(path).Dropped++
+}
+
+// returns copy of metrics
+func (m *metrics) snapshot() map[Path]TopicMetrics {
+	m.Lock()
+	defer m.Unlock()
+
+	topics := make(map[Path]TopicMetrics, len(m.topics))
+	for path, t := range m.topics {
+		topics[path] = *t
+	}
+
+	return topics
+}
+
+// returns metrics of messages by topic, e.g. "block" or "confirmedAdded/<address>".
+// Metrics are kept since the client is created and are not reset on reconnection
+func (c *CatapultWebsocketClientImpl) Metrics() map[Path]TopicMetrics {
+	return c.metrics.snapshot()
+}
+
+// returns topic of message, it is the same topic which client subscribes to
+func formatMessageTopic(info *sdk.WsMessageInfo) Path {
+	if info.Address == nil {
+		return Path(info.ChannelName)
+	}
+
+	return Path(fmt.Sprintf("%s/%s", info.ChannelName, info.Address.Address))
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// handler which sends every routed message into channel
+type chanHandler chan []byte
+
+func (h chanHandler) Handle(_ *sdk.Address, resp []byte) bool {
+	h <- resp
+	return true
+}
+
+func TestMessageRouter_Metrics(t *testing.T) {
+	c := &CatapultWebsocketClientImpl{
+		topicHandlers: &topicHandlers{h: make(topicHandlersMap)},
+	}
+
+	routed := make(chanHandler, 10)
+	c.topicHandlers.SetTopicHandler(pathBlock, &TopicHandler{
+		Handler: routed,
+		Topic:   topicFormatFn(formatBlockTopic),
+	})
+
+	address, err := sdk.NewAddressFromRaw("SCGUWZBYFHS5AVRJBZQHVYBLOPDHMQTMGHMMHSGM")
+	assert.Nilf(t, err, "NewAddressFromRaw returned error: %s", err)
+
+	router := newRouter(c.UID, nil, c.topicHandlers, &c.metrics)
+
+	router.RouteMessage([]byte(`{"meta": {"channelName": "block"}}`))
+	router.RouteMessage([]byte(`{"meta": {"channelName": "block"}}`))
+	// there is no handler of status topic, address of message is hex of raw address
+	router.RouteMessage([]byte(`{"meta": {"channelName": "status", "address": "908D4B643829E5D056290E607AE02B73C676426C31D8C3C8CC"}}`))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-routed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("block is not routed")
+		}
+	}
+
+	// status message is counted after blocks are routed
+	deadline := time.Now().Add(5 * time.Second)
+	for len(c.Metrics()) != 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, map[Path]TopicMetrics{
+		pathBlock:                         {Received: 2},
+		Path("status/" + address.Address): {Received: 1, Dropped: 1},
+	}, c.Metrics())
+}
@@ -11,11 +11,17 @@ import (
 )
 
 func NewRouter(uid string, publisher MessagePublisher, topicHandlers TopicHandlersStorage) Router {
+	return newRouter(uid, publisher, topicHandlers, nil)
+}
+
+// returns router which counts messages in passed metrics, metrics are not counted if it is nil
+func newRouter(uid string, publisher MessagePublisher, topicHandlers TopicHandlersStorage, metrics *metrics) Router {
 	router := messageRouter{
 		uid:               uid,
 		topicHandlers:     topicHandlers,
 		messageInfoMapper: messageInfoMapperFn(MapMessageInfo),
 		messagePublisher:  publisher,
+		metrics:           metrics,
 		dataCh:            make(chan []byte, 1024),
 	}
 
@@ -35,6 +41,7 @@ type messageRouter struct {
 	messagePublisher  MessagePublisher
 	messageInfoMapper MessageInfoMapper
 	topicHandlers     TopicHandlersStorage
+	metrics           *metrics
 	dataCh            chan []byte
 }
 
@@ -45,8 +52,12 @@ func (r *messageRouter) run() {
 			panic(errors.Wrap(err, "getting message info"))
 		}
 
+		topic := formatMessageTopic(messageInfo)
+		r.metrics.received(topic)
+
 		handler := r.topicHandlers.GetHandler(Path(messageInfo.ChannelName))
 		if handler == nil {
+			r.metrics.dropped(topic)
 			fmt.Println("getting topic handler from topic handlers storage")
 			continue
 		}
@@ -0,0 +1,379 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// OverflowPolicy defines what happens with message when buffer of channel returned by SubscribeXxx is full
+type OverflowPolicy int
+
+const (
+	// message waits until it is received, delivery of all other websocket messages waits meanwhile
+	OverflowBlock OverflowPolicy = iota
+	// the oldest message in buffer is dropped to free space for the new one
+	OverflowDropOldest
+	// channel is closed and Stream.Err returns ErrStreamOverflow
+	OverflowError
+)
+
+// buffer size of channels returned by SubscribeXxx when it is not set by WithBufferSize
+const DefaultStreamBufferSize = 64
+
+var (
+	ErrStreamOverflow = errors.New("buffer of subscription channel is overflowed")
+)
+
+type streamConfig struct {
+	bufferSize int
+	overflow   OverflowPolicy
+}
+
+// StreamOption configures channel returned by SubscribeXxx
+type StreamOption func(*streamConfig)
+
+// sets buffer size of channel, values less than 1 are replaced with DefaultStreamBufferSize
+func WithBufferSize(size int) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.bufferSize = size
+	}
+}
+
+// sets what happens when buffer of channel is full, OverflowBlock by default
+func WithOverflowPolicy(policy OverflowPolicy) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.overflow = policy
+	}
+}
+
+// Stream controls channel returned by SubscribeXxx.
+// Channel is closed when context passed to SubscribeXxx is done, Stream is closed, websocket client is closed
+// or buffer of channel is overflowed with OverflowError policy
+type Stream struct {
+	// guards sending into channel and its closing
+	sendMutex sync.Mutex
+	// guards err separately, so Err doesn't wait for blocked send
+	errMutex sync.RWMutex
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	ch       reflect.Value
+	overflow OverflowPolicy
+	closed   bool
+	err      error
+
+	sub       *Subscription
+	unsubErr  error
+	closingCh chan struct{}
+
+	metrics *metrics
+	topic   Path
+}
+
+// closes channel and removes its handler, returns error of unsubscribing from websocket topic
+func (s *Stream) Close() error {
+	s.cancel()
+	<-s.closingCh
+
+	return s.unsubErr
+}
+
+// returns reason why channel is closed, nil while channel is open.
+// ErrStreamOverflow is returned after overflow with OverflowError policy, error of context otherwise
+func (s *Stream) Err() error {
+	s.errMutex.RLock()
+	defer s.errMutex.RUnlock()
+
+	return s.err
+}
+
+func newStream(ctx context.Context, ch interface{}, cfg *streamConfig) *Stream {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Stream{
+		ctx:       ctx,
+		cancel:    cancel,
+		ch:        reflect.ValueOf(ch),
+		overflow:  cfg.overflow,
+		closingCh: make(chan struct{}),
+	}
+}
+
+// sends message into channel according to overflow policy.
+// It always keeps handler, because the handler is removed with subscription when stream is closed
+func (s *Stream) push(message interface{}) bool {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	value := reflect.ValueOf(message)
+	if !value.IsValid() {
+		value = reflect.Zero(s.ch.Type().Elem())
+	}
+
+	if s.ch.TrySend(value) {
+		return false
+	}
+
+	switch s.overflow {
+	case OverflowDropOldest:
+		// there is the only sender, so buffer has space after receive even if reader took message meanwhile
+		if _, ok := s.ch.TryRecv(); ok {
+			s.metrics.dropped(s.topic)
+		}
+
+		s.ch.TrySend(value)
+	case OverflowError:
+		s.metrics.dropped(s.topic)
+		s.closeChannel(ErrStreamOverflow)
+		s.cancel()
+	default:
+		reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: s.ch, Send: value},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.ctx.Done())},
+		})
+	}
+
+	return false
+}
+
+func (s *Stream) closeChannel(err error) {
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	s.ch.Close()
+
+	s.errMutex.Lock()
+	s.err = err
+	s.errMutex.Unlock()
+}
+
+// waits until stream or websocket client is done, then closes channel and removes its handler
+func (s *Stream) run(clientCtx context.Context) {
+	var clientDone <-chan struct{}
+	if clientCtx != nil {
+		clientDone = clientCtx.Done()
+	}
+
+	select {
+	case <-s.ctx.Done():
+	case <-clientDone:
+		s.cancel()
+	}
+
+	s.sendMutex.Lock()
+	s.closeChannel(s.ctx.Err())
+	s.sendMutex.Unlock()
+
+	s.unsubErr = s.sub.Unsubscribe()
+	close(s.closingCh)
+}
+
+// creates stream of channel ch which is filled by handler added with passed add function,
+// messages dropped on overflow are counted in metrics of passed topic
+func (c *CatapultWebsocketClientImpl) subscribeStream(ctx context.Context, topic Path, ch interface{}, cfg *streamConfig, add func(s *Stream) (*Subscription, error)) (*Stream, error) {
+	s := newStream(ctx, ch, cfg)
+	s.metrics = &c.metrics
+	s.topic = topic
+
+	sub, err := add(s)
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+
+	s.sub = sub
+	go s.run(c.ctx)
+
+	return s, nil
+}
+
+// returns topic of passed address, e.g. "confirmedAdded/<address>"
+func addressTopic(path Path, address *sdk.Address) Path {
+	return Path(fmt.Sprintf("%s/%s", path, address.Address))
+}
+
+func newStreamConfig(opts []StreamOption) *streamConfig {
+	cfg := &streamConfig{
+		bufferSize: DefaultStreamBufferSize,
+		overflow:   OverflowBlock,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.bufferSize < 1 {
+		cfg.bufferSize = DefaultStreamBufferSize
+	}
+
+	return cfg
+}
+
+// returns channel of new blocks
+func (c *CatapultWebsocketClientImpl) SubscribeBlocks(ctx context.Context, opts ...StreamOption) (<-chan *sdk.BlockInfo, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.BlockInfo, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, pathBlock, ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddBlockHandlers(func(info *sdk.BlockInfo) bool {
+			return s.push(info)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of transactions of passed address which are added into blocks
+func (c *CatapultWebsocketClientImpl) SubscribeConfirmed(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan sdk.Transaction, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan sdk.Transaction, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathConfirmedAdded, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddConfirmedAddedHandlers(address, func(tx sdk.Transaction) bool {
+			return s.push(tx)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of transactions of passed address which are added into unconfirmed cache
+func (c *CatapultWebsocketClientImpl) SubscribeUnconfirmedAdded(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan sdk.Transaction, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan sdk.Transaction, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathUnconfirmedAdded, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddUnconfirmedAddedHandlers(address, func(tx sdk.Transaction) bool {
+			return s.push(tx)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of hashes of transactions of passed address which are removed from unconfirmed cache
+func (c *CatapultWebsocketClientImpl) SubscribeUnconfirmedRemoved(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.UnconfirmedRemoved, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.UnconfirmedRemoved, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathUnconfirmedRemoved, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddUnconfirmedRemovedHandlers(address, func(info *sdk.UnconfirmedRemoved) bool {
+			return s.push(info)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of aggregate bonded transactions of passed address which wait for cosignatures
+func (c *CatapultWebsocketClientImpl) SubscribePartialAdded(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.AggregateTransaction, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.AggregateTransaction, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathPartialAdded, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddPartialAddedHandlers(address, func(tx *sdk.AggregateTransaction) bool {
+			return s.push(tx)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of aggregate bonded transactions of passed address which are removed from partial cache
+func (c *CatapultWebsocketClientImpl) SubscribePartialRemoved(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.PartialRemovedInfo, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.PartialRemovedInfo, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathPartialRemoved, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddPartialRemovedHandlers(address, func(info *sdk.PartialRemovedInfo) bool {
+			return s.push(info)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of errors of transactions of passed address
+func (c *CatapultWebsocketClientImpl) SubscribeStatus(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.StatusInfo, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.StatusInfo, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathStatus, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddStatusHandlers(address, func(info *sdk.StatusInfo) bool {
+			return s.push(info)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of cosignatures added to aggregate bonded transactions of passed address
+func (c *CatapultWebsocketClientImpl) SubscribeCosignature(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.SignerInfo, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.SignerInfo, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(pathCosignature, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddCosignatureHandlers(address, func(info *sdk.SignerInfo) bool {
+			return s.push(info)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
+
+// returns channel of state changes of drive with passed address
+func (c *CatapultWebsocketClientImpl) SubscribeDriveState(ctx context.Context, address *sdk.Address, opts ...StreamOption) (<-chan *sdk.DriveStateInfo, *Stream, error) {
+	cfg := newStreamConfig(opts)
+	ch := make(chan *sdk.DriveStateInfo, cfg.bufferSize)
+
+	s, err := c.subscribeStream(ctx, addressTopic(driveState, address), ch, cfg, func(s *Stream) (*Subscription, error) {
+		return c.AddDriveStateHandlers(address, func(info *sdk.DriveStateInfo) bool {
+			return s.push(info)
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, s, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+func newStreamTestClient(publisher *MockMessagePublisher) *CatapultWebsocketClientImpl {
+	return &CatapultWebsocketClientImpl{
+		UID:              "123456",
+		blockSubscriber:  subscribers.NewBlock(),
+		topicHandlers:    &topicHandlers{h: make(topicHandlersMap)},
+		messagePublisher: publisher,
+	}
+}
+
+// calls block handlers as block handler of router does
+func publishBlocks(c *CatapultWebsocketClientImpl, heights ...sdk.Height) {
+	for _, height := range heights {
+		for _, h := range c.blockSubscriber.GetHandlers() {
+			(*h)(&sdk.BlockInfo{Height: height})
+		}
+	}
+}
+
+func receiveHeights(ch <-chan *sdk.BlockInfo) []sdk.Height {
+	var heights []sdk.Height
+	for b := range ch {
+		heights = append(heights, b.Height)
+	}
+
+	return heights
+}
+
+func TestCatapultWebsocketClientImpl_SubscribeBlocks(t *testing.T) {
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", "123456", pathBlock).Return(nil).Once()
+	c := newStreamTestClient(publisher)
+
+	// handlers and channels are served by the same subscription
+	_, err := c.AddBlockHandlers(func(*sdk.BlockInfo) bool { return false })
+	assert.Nilf(t, err, "AddBlockHandlers returned error: %s", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, stream, err := c.SubscribeBlocks(ctx, WithBufferSize(2), WithOverflowPolicy(OverflowDropOldest))
+	assert.Nilf(t, err, "SubscribeBlocks returned error: %s", err)
+	assert.Len(t, c.blockSubscriber.GetHandlers(), 2)
+
+	publishBlocks(c, 1, 2, 3)
+	assert.Nil(t, stream.Err())
+
+	assert.Equal(t, map[Path]TopicMetrics{pathBlock: {Dropped: 1}}, c.Metrics())
+
+	cancel()
+	assert.Equal(t, []sdk.Height{2, 3}, receiveHeights(ch))
+	assert.Equal(t, context.Canceled, stream.Err())
+
+	assert.Nil(t, stream.Close())
+	assert.Len(t, c.blockSubscriber.GetHandlers(), 1)
+	publisher.AssertExpectations(t)
+}
+
+func TestCatapultWebsocketClientImpl_SubscribeBlocks_OverflowError(t *testing.T) {
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", "123456", pathBlock).Return(nil).Once()
+	publisher.On("PublishUnsubscribeMessage", "123456", pathBlock).Return(nil).Once()
+	c := newStreamTestClient(publisher)
+
+	ch, stream, err := c.SubscribeBlocks(context.Background(), WithBufferSize(1), WithOverflowPolicy(OverflowError))
+	assert.Nilf(t, err, "SubscribeBlocks returned error: %s", err)
+
+	publishBlocks(c, 1, 2)
+	assert.Equal(t, []sdk.Height{1}, receiveHeights(ch))
+	assert.Equal(t, ErrStreamOverflow, stream.Err())
+
+	// handler is removed with server subscription after overflow
+	assert.Nil(t, stream.Close())
+	assert.False(t, c.blockSubscriber.HasHandlers())
+	publisher.AssertExpectations(t)
+}
+
+func TestCatapultWebsocketClientImpl_SubscribeBlocks_OverflowBlock(t *testing.T) {
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", "123456", pathBlock).Return(nil).Once()
+	publisher.On("PublishUnsubscribeMessage", "123456", pathBlock).Return(nil).Once()
+	c := newStreamTestClient(publisher)
+
+	ch, stream, err := c.SubscribeBlocks(context.Background(), WithBufferSize(1))
+	assert.Nilf(t, err, "SubscribeBlocks returned error: %s", err)
+
+	published := make(chan struct{})
+	go func() {
+		publishBlocks(c, 1, 2, 3)
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("publishing is not blocked by full channel")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	for _, height := range []sdk.Height{1, 2, 3} {
+		assert.Equal(t, height, (<-ch).Height)
+	}
+
+	<-published
+
+	// blocked message is dropped when stream is closed
+	go publishBlocks(c, 4, 5)
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, context.Canceled, stream.Err())
+	publisher.AssertExpectations(t)
+}
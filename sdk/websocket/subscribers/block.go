@@ -9,7 +9,7 @@ type (
 	BlockHandler func(*sdk.BlockInfo) bool
 
 	Block interface {
-		AddHandlers(handlers ...BlockHandler) error
+		AddHandlers(handlers ...BlockHandler) ([]*BlockHandler, error)
 		RemoveHandlers(handlers ...*BlockHandler) bool
 		HasHandlers() bool
 		GetHandlers() []*BlockHandler
@@ -44,6 +44,10 @@ func (s *blockSubscriberImpl) addSubscription(b *blockSubscription) {
 	for i := 0; i < len(b.handlers); i++ {
 		s.handlers = append(s.handlers, b.handlers[i])
 	}
+
+	if b.resultCh != nil {
+		b.resultCh <- true
+	}
 }
 
 func (s *blockSubscriberImpl) removeSubscription(b *blockSubscription) {
@@ -51,19 +55,29 @@ func (s *blockSubscriberImpl) removeSubscription(b *blockSubscription) {
 	defer s.Unlock()
 	if s.handlers == nil || len(b.handlers) == 0 {
 		b.resultCh <- true
+		return
 	}
 
-	itemCount := len(s.handlers)
-	for _, removeHandler := range s.handlers {
-		for index, currentHandlers := range b.handlers {
-			if removeHandler == currentHandlers {
-				s.handlers = append(s.handlers[:index],
-					s.handlers[index+1:]...)
+	// handlers are copied, so slice returned by GetHandlers isn't changed
+	remaining := make([]*BlockHandler, 0, len(s.handlers))
+	for _, currentHandler := range s.handlers {
+		removed := false
+		for _, removeHandler := range b.handlers {
+			if removeHandler == currentHandler {
+				removed = true
+				break
 			}
 		}
+
+		if !removed {
+			remaining = append(remaining, currentHandler)
+		}
 	}
 
-	b.resultCh <- itemCount != len(s.handlers)
+	itemCount := len(s.handlers)
+	s.handlers = remaining
+
+	b.resultCh <- itemCount != len(remaining)
 }
 
 func (s *blockSubscriberImpl) handleNewSubscription() {
@@ -76,21 +90,28 @@ func (s *blockSubscriberImpl) handleNewSubscription() {
 		}
 	}
 }
-func (s *blockSubscriberImpl) AddHandlers(handlers ...BlockHandler) error {
+
+// returns references of added handlers which are passed to RemoveHandlers
+func (s *blockSubscriberImpl) AddHandlers(handlers ...BlockHandler) ([]*BlockHandler, error) {
 
 	if s.handlers == nil || len(handlers) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	refHandlers := make([]*BlockHandler, len(handlers))
-	for i, h := range handlers {
+	for i := range handlers {
+		h := handlers[i]
 		refHandlers[i] = &h
 	}
+	// handlers are stored before AddHandlers returns, so HasHandlers reports them
+	resCh := make(chan bool)
 	s.newSubscriberCh <- &blockSubscription{
 		handlers: refHandlers,
+		resultCh: resCh,
 	}
+	<-resCh
 
-	return nil
+	return refHandlers, nil
 }
 
 func (s *blockSubscriberImpl) RemoveHandlers(handlers ...*BlockHandler) bool {
@@ -57,7 +57,7 @@ func Test_blockSubscriberImpl_AddHandlers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			go tt.s.handleNewSubscription()
-			err := tt.s.AddHandlers(tt.args.handlers...)
+			_, err := tt.s.AddHandlers(tt.args.handlers...)
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, len(tt.args.handlers), len(tt.s.handlers))
 		})
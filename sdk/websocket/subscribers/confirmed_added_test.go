@@ -88,7 +88,7 @@ func Test_confirmedAddedImpl_AddHandlers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			go tt.e.handleNewSubscription()
-			err := tt.e.AddHandlers(tt.args.address, tt.args.handlers...)
+			_, err := tt.e.AddHandlers(tt.args.address, tt.args.handlers...)
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
@@ -10,7 +10,7 @@ type (
 	DriveStateHandler func(*sdk.DriveStateInfo) bool
 
 	DriveState interface {
-		AddHandlers(address *sdk.Address, handlers ...DriveStateHandler) error
+		AddHandlers(address *sdk.Address, handlers ...DriveStateHandler) ([]*DriveStateHandler, error)
 		RemoveHandlers(address *sdk.Address, handlers ...*DriveStateHandler) bool
 		HasHandlers(address *sdk.Address) bool
 		GetHandlers(address *sdk.Address) []*DriveStateHandler
@@ -49,26 +49,41 @@ func (e *driveStateImpl) addSubscription(s *driveStateSubscription) {
 	for i := 0; i < len(s.handlers); i++ {
 		e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address], s.handlers[i])
 	}
+
+	if s.resultCh != nil {
+		s.resultCh <- true
+	}
 }
 
 func (e *driveStateImpl) removeSubscription(s *driveStateSubscription) {
 	e.Lock()
 	defer e.Unlock()
-	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
-		s.resultCh <- false
-	}
 
-	itemCount := len(e.subscribers[s.address.Address])
-	for _, removeHandler := range s.handlers {
-		for index, currentHandlers := range e.subscribers[s.address.Address] {
-			if removeHandler == currentHandlers {
-				e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address][:index],
-					e.subscribers[s.address.Address][index+1:]...)
+	// handlers are copied, so slice returned by GetHandlers isn't changed
+	current := e.subscribers[s.address.Address]
+	remaining := make([]*DriveStateHandler, 0, len(current))
+	for _, currentHandler := range current {
+		removed := false
+		for _, removeHandler := range s.handlers {
+			if removeHandler == currentHandler {
+				removed = true
+				break
 			}
 		}
+
+		if !removed {
+			remaining = append(remaining, currentHandler)
+		}
 	}
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	// addresses without handlers are not subscribed again after reconnection
+	if len(remaining) == 0 {
+		delete(e.subscribers, s.address.Address)
+	} else {
+		e.subscribers[s.address.Address] = remaining
+	}
+
+	s.resultCh <- len(current) != len(remaining)
 }
 
 func (e *driveStateImpl) handleNewSubscription() {
@@ -82,22 +97,28 @@ func (e *driveStateImpl) handleNewSubscription() {
 	}
 }
 
-func (e *driveStateImpl) AddHandlers(address *sdk.Address, handlers ...DriveStateHandler) error {
+// returns references of added handlers which are passed to RemoveHandlers
+func (e *driveStateImpl) AddHandlers(address *sdk.Address, handlers ...DriveStateHandler) ([]*DriveStateHandler, error) {
 
 	if len(handlers) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	refHandlers := make([]*DriveStateHandler, len(handlers))
-	for i, h := range handlers {
+	for i := range handlers {
+		h := handlers[i]
 		refHandlers[i] = &h
 	}
 
+	// handlers are stored before AddHandlers returns, so HasHandlers reports them
+	resCh := make(chan bool)
 	e.newSubscriberCh <- &driveStateSubscription{
 		address:  address,
 		handlers: refHandlers,
+		resultCh: resCh,
 	}
-	return nil
+	<-resCh
+	return refHandlers, nil
 }
 
 func (e *driveStateImpl) RemoveHandlers(address *sdk.Address, handlers ...*DriveStateHandler) bool {
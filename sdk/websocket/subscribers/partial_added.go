@@ -7,7 +7,7 @@ import (
 
 type (
 	PartialAdded interface {
-		AddHandlers(address *sdk.Address, handlers ...PartialAddedHandler) error
+		AddHandlers(address *sdk.Address, handlers ...PartialAddedHandler) ([]*PartialAddedHandler, error)
 		RemoveHandlers(address *sdk.Address, handlers ...*PartialAddedHandler) bool
 		HasHandlers(address *sdk.Address) bool
 		GetHandlers(address *sdk.Address) []*PartialAddedHandler
@@ -59,44 +59,65 @@ func (e *partialAddedImpl) addSubscription(s *partialAddedSubscription) {
 	for i := 0; i < len(s.handlers); i++ {
 		e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address], s.handlers[i])
 	}
+
+	if s.resultCh != nil {
+		s.resultCh <- true
+	}
 }
 
 func (e *partialAddedImpl) removeSubscription(s *partialAddedSubscription) {
 	e.Lock()
 	defer e.Unlock()
-	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
-		s.resultCh <- false
-	}
 
-	itemCount := len(e.subscribers[s.address.Address])
-	for _, removeHandler := range s.handlers {
-		for index, currentHandlers := range e.subscribers[s.address.Address] {
-			if removeHandler == currentHandlers {
-				e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address][:index],
-					e.subscribers[s.address.Address][index+1:]...)
+	// handlers are copied, so slice returned by GetHandlers isn't changed
+	current := e.subscribers[s.address.Address]
+	remaining := make([]*PartialAddedHandler, 0, len(current))
+	for _, currentHandler := range current {
+		removed := false
+		for _, removeHandler := range s.handlers {
+			if removeHandler == currentHandler {
+				removed = true
+				break
 			}
 		}
+
+		if !removed {
+			remaining = append(remaining, currentHandler)
+		}
 	}
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	// addresses without handlers are not subscribed again after reconnection
+	if len(remaining) == 0 {
+		delete(e.subscribers, s.address.Address)
+	} else {
+		e.subscribers[s.address.Address] = remaining
+	}
+
+	s.resultCh <- len(current) != len(remaining)
 }
 
-func (e *partialAddedImpl) AddHandlers(address *sdk.Address, handlers ...PartialAddedHandler) error {
+// returns references of added handlers which are passed to RemoveHandlers
+func (e *partialAddedImpl) AddHandlers(address *sdk.Address, handlers ...PartialAddedHandler) ([]*PartialAddedHandler, error) {
 
 	if len(handlers) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	refHandlers := make([]*PartialAddedHandler, len(handlers))
-	for i, h := range handlers {
+	for i := range handlers {
+		h := handlers[i]
 		refHandlers[i] = &h
 	}
 
+	// handlers are stored before AddHandlers returns, so HasHandlers reports them
+	resCh := make(chan bool)
 	e.newSubscriberCh <- &partialAddedSubscription{
 		address:  address,
 		handlers: refHandlers,
+		resultCh: resCh,
 	}
-	return nil
+	<-resCh
+	return refHandlers, nil
 }
 
 func (e *partialAddedImpl) RemoveHandlers(address *sdk.Address, handlers ...*PartialAddedHandler) bool {
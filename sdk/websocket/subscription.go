@@ -0,0 +1,96 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// channels which are passed to Unsubscribe
+const (
+	BlockChannel              = pathBlock
+	ConfirmedAddedChannel     = pathConfirmedAdded
+	UnconfirmedAddedChannel   = pathUnconfirmedAdded
+	UnconfirmedRemovedChannel = pathUnconfirmedRemoved
+	StatusChannel             = pathStatus
+	PartialAddedChannel       = pathPartialAdded
+	PartialRemovedChannel     = pathPartialRemoved
+	CosignatureChannel        = pathCosignature
+	DriveStateChannel         = driveState
+)
+
+var (
+	ErrUnknownChannel = errors.New("unknown websocket channel")
+)
+
+// Subscription is a handle of handlers added by one call of AddXxxHandlers.
+// Nil Subscription is returned when no handlers are passed, it is safe to Unsubscribe it
+type Subscription struct {
+	once   sync.Once
+	remove func() error
+}
+
+// removes handlers of subscription, server is unsubscribed from topic when the last handler of topic is removed.
+// Subsequent calls do nothing
+func (s *Subscription) Unsubscribe() error {
+	if s == nil {
+		return nil
+	}
+
+	var err error
+	s.once.Do(func() {
+		err = s.remove()
+	})
+
+	return err
+}
+
+func (c *CatapultWebsocketClientImpl) newSubscription(remove func() error) *Subscription {
+	return &Subscription{
+		remove: func() error {
+			c.subscriptionMutex.Lock()
+			defer c.subscriptionMutex.Unlock()
+
+			return remove()
+		},
+	}
+}
+
+func (c *CatapultWebsocketClientImpl) Unsubscribe(address *sdk.Address, channel Path) error {
+	switch channel {
+	case BlockChannel:
+		return c.RemoveBlockHandlers()
+	case ConfirmedAddedChannel:
+		return c.RemoveConfirmedAddedHandlers(address)
+	case UnconfirmedAddedChannel:
+		return c.RemoveUnconfirmedAddedHandlers(address)
+	case UnconfirmedRemovedChannel:
+		return c.RemoveUnconfirmedRemovedHandlers(address)
+	case StatusChannel:
+		return c.RemoveStatusHandlers(address)
+	case PartialAddedChannel:
+		return c.RemovePartialAddedHandlers(address)
+	case PartialRemovedChannel:
+		return c.RemovePartialRemovedHandlers(address)
+	case CosignatureChannel:
+		return c.RemoveCosignatureHandlers(address)
+	case DriveStateChannel:
+		return c.RemoveDriveStateHandlers(address)
+	default:
+		return ErrUnknownChannel
+	}
+}
+
+func (c *CatapultWebsocketClientImpl) publishUnsubscribe(path Path) error {
+	if err := c.messagePublisher.PublishUnsubscribeMessage(c.UID, path); err != nil {
+		return errors.Wrap(err, "publishing unsubscribe message into websocket")
+	}
+
+	return nil
+}
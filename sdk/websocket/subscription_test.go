@@ -0,0 +1,115 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+func TestSubscription_Unsubscribe(t *testing.T) {
+	uid := "123456"
+
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", uid, pathBlock).Return(nil).Once()
+	publisher.On("PublishUnsubscribeMessage", uid, pathBlock).Return(nil).Once()
+
+	c := &CatapultWebsocketClientImpl{
+		UID:              uid,
+		blockSubscriber:  subscribers.NewBlock(),
+		topicHandlers:    &topicHandlers{h: make(topicHandlersMap)},
+		messagePublisher: publisher,
+	}
+
+	handler := func(*sdk.BlockInfo) bool { return false }
+
+	first, err := c.AddBlockHandlers(handler, handler)
+	assert.Nilf(t, err, "AddBlockHandlers returned error: %s", err)
+
+	second, err := c.AddBlockHandlers(handler)
+	assert.Nilf(t, err, "AddBlockHandlers returned error: %s", err)
+	assert.Len(t, c.blockSubscriber.GetHandlers(), 3)
+
+	// server is unsubscribed only after the last handler is removed
+	assert.Nil(t, first.Unsubscribe())
+	assert.Len(t, c.blockSubscriber.GetHandlers(), 1)
+	publisher.AssertNotCalled(t, "PublishUnsubscribeMessage", uid, pathBlock)
+
+	assert.Nil(t, first.Unsubscribe())
+	assert.Len(t, c.blockSubscriber.GetHandlers(), 1)
+
+	assert.Nil(t, second.Unsubscribe())
+	assert.False(t, c.blockSubscriber.HasHandlers())
+	publisher.AssertExpectations(t)
+
+	var empty *Subscription
+	assert.Nil(t, empty.Unsubscribe())
+}
+
+func TestCatapultWebsocketClientImpl_UpdateHandlers_Unsubscribed(t *testing.T) {
+	uid := "123456"
+
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", uid, pathBlock).Return(nil).Once()
+	publisher.On("PublishUnsubscribeMessage", uid, pathBlock).Return(nil).Once()
+
+	c := &CatapultWebsocketClientImpl{
+		UID:                           uid,
+		blockSubscriber:               subscribers.NewBlock(),
+		confirmedAddedSubscribers:     subscribers.NewConfirmedAdded(),
+		cosignatureSubscribers:        subscribers.NewCosignature(),
+		partialAddedSubscribers:       subscribers.NewPartialAdded(),
+		partialRemovedSubscribers:     subscribers.NewPartialRemoved(),
+		statusSubscribers:             subscribers.NewStatus(),
+		unconfirmedAddedSubscribers:   subscribers.NewUnconfirmedAdded(),
+		unconfirmedRemovedSubscribers: subscribers.NewUnconfirmedRemoved(),
+		topicHandlers:                 &topicHandlers{h: make(topicHandlersMap)},
+		messagePublisher:              publisher,
+	}
+
+	sub, err := c.AddBlockHandlers(func(*sdk.BlockInfo) bool { return false })
+	assert.Nilf(t, err, "AddBlockHandlers returned error: %s", err)
+	assert.Nil(t, sub.Unsubscribe())
+
+	// reconnected client doesn't subscribe to block topic without block handlers
+	assert.Nil(t, c.updateHandlers())
+	publisher.AssertExpectations(t)
+}
+
+func TestCatapultWebsocketClientImpl_Unsubscribe(t *testing.T) {
+	uid := "123456"
+	address := &sdk.Address{Address: "test-address"}
+	path := Path("status/" + address.Address)
+
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", uid, path).Return(nil).Once()
+	publisher.On("PublishUnsubscribeMessage", uid, path).Return(nil).Once()
+
+	c := &CatapultWebsocketClientImpl{
+		UID:               uid,
+		statusSubscribers: subscribers.NewStatus(),
+		topicHandlers:     &topicHandlers{h: make(topicHandlersMap)},
+		messagePublisher:  publisher,
+	}
+
+	handler := func(*sdk.StatusInfo) bool { return false }
+
+	sub, err := c.AddStatusHandlers(address, handler, handler)
+	assert.Nilf(t, err, "AddStatusHandlers returned error: %s", err)
+
+	assert.Nil(t, c.Unsubscribe(address, StatusChannel))
+	assert.False(t, c.statusSubscribers.HasHandlers(address))
+	assert.Empty(t, c.statusSubscribers.GetAddresses())
+
+	// handlers are already removed, so server isn't unsubscribed again
+	assert.Nil(t, sub.Unsubscribe())
+	publisher.AssertExpectations(t)
+
+	assert.Equal(t, ErrUnknownChannel, c.Unsubscribe(address, Path("unknown")))
+}
@@ -0,0 +1,276 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+// Package workflow combines REST and websocket clients into higher level flows over transactions.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+const (
+	// interval of polling transaction status when websocket is not available
+	DefaultPollInterval = time.Second * 5
+
+	// while websocket subscriptions are alive REST status is polled this times rarer, just as a safety net
+	socketPollFactor = 6
+
+	confirmedGroup   = "confirmed"
+	unconfirmedGroup = "unconfirmed"
+	failedGroup      = "failed"
+)
+
+type AnnounceStatus uint8
+
+// AnnounceStatus enums
+const (
+	Confirmed AnnounceStatus = iota + 1
+	Rejected
+	Expired
+)
+
+func (s AnnounceStatus) String() string {
+	switch s {
+	case Confirmed:
+		return "Confirmed"
+	case Rejected:
+		return "Rejected"
+	case Expired:
+		return "Expired"
+	default:
+		return fmt.Sprintf("%d", s)
+	}
+}
+
+// AnnounceResult is a final state of announced transaction
+type AnnounceResult struct {
+	Status AnnounceStatus
+	Hash   *sdk.Hash
+	// height of block which includes transaction, is set only for Confirmed
+	Height sdk.Height
+	// status of rejection returned by node, is set only for Rejected
+	Error string
+	// is true if transaction was added to unconfirmed transactions of node before final state
+	SeenUnconfirmed bool
+}
+
+func (r *AnnounceResult) String() string {
+	return fmt.Sprintf(
+		`[Status: %s, Hash: %s, Height: %s, Error: %s, SeenUnconfirmed: %t]`,
+		r.Status,
+		r.Hash,
+		r.Height,
+		r.Error,
+		r.SeenUnconfirmed,
+	)
+}
+
+// Announcer announces transactions and tracks them up to the final state
+type Announcer struct {
+	client       *sdk.Client
+	ws           websocket.CatapultClient
+	pollInterval time.Duration
+}
+
+// returns Announcer which announces transactions through passed client and tracks them through passed websocket client.
+// ws can be nil, then status of transactions is polled through REST with passed pollInterval
+func NewAnnouncer(client *sdk.Client, ws websocket.CatapultClient, pollInterval time.Duration) *Announcer {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &Announcer{
+		client:       client,
+		ws:           ws,
+		pollInterval: pollInterval,
+	}
+}
+
+// announces passed SignedTransaction and waits until it is confirmed, rejected or expired past its Deadline
+func (a *Announcer) AnnounceAndWait(ctx context.Context, stx *sdk.SignedTransaction) (*AnnounceResult, error) {
+	return a.announceAndWait(ctx, stx, a.client.Transaction.Announce)
+}
+
+// announces passed aggregate bonded SignedTransaction and waits until it is confirmed, rejected or expired past its Deadline
+// aggregate bonded transaction is confirmed only after all required cosignatures are received
+func (a *Announcer) AnnounceAggregateBondedAndWait(ctx context.Context, stx *sdk.SignedTransaction) (*AnnounceResult, error) {
+	return a.announceAndWait(ctx, stx, a.client.Transaction.AnnounceAggregateBonded)
+}
+
+type announceFunc func(ctx context.Context, stx *sdk.SignedTransaction) (string, error)
+
+func (a *Announcer) announceAndWait(ctx context.Context, stx *sdk.SignedTransaction, announce announceFunc) (*AnnounceResult, error) {
+	tx, err := sdk.ParseSignedTransaction(stx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := &waiter{
+		hash:     stx.Hash,
+		resultCh: make(chan *AnnounceResult, 1),
+	}
+
+	interval := a.pollInterval
+	if a.subscribe(ctx, tx.GetAbstractTransaction().Signer.Address, w) {
+		interval *= socketPollFactor
+	}
+
+	if _, err := announce(ctx, stx); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	expiry := time.NewTimer(time.Until(tx.GetAbstractTransaction().Deadline.Time))
+	defer expiry.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case res := <-w.resultCh:
+			return w.finish(res), nil
+		case <-ticker.C:
+			if res := a.poll(ctx, w); res != nil {
+				return w.finish(res), nil
+			}
+		case <-expiry.C:
+			// transaction can be confirmed right before deadline, so check it for the last time
+			if res := a.poll(ctx, w); res != nil {
+				return w.finish(res), nil
+			}
+
+			return w.finish(&AnnounceResult{Status: Expired}), nil
+		}
+	}
+}
+
+// subscribes waiter to websocket channels of signer
+// returns false if websocket is not available
+func (a *Announcer) subscribe(ctx context.Context, signer *sdk.Address, w *waiter) bool {
+	if a.ws == nil {
+		return false
+	}
+
+	// handlers are removed when waiting is finished
+	statusSub, err := a.ws.AddStatusHandlers(signer, func(info *sdk.StatusInfo) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		if !w.matches(info.Hash) {
+			return false
+		}
+
+		w.report(&AnnounceResult{Status: Rejected, Error: info.Status})
+		return true
+	})
+	if err != nil {
+		return false
+	}
+	unsubscribeOnDone(ctx, statusSub)
+
+	unconfirmedSub, err := a.ws.AddUnconfirmedAddedHandlers(signer, func(tx sdk.Transaction) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		if !w.matches(tx.GetAbstractTransaction().TransactionHash) {
+			return false
+		}
+
+		w.markUnconfirmed()
+		return true
+	})
+	if err != nil {
+		return false
+	}
+	unsubscribeOnDone(ctx, unconfirmedSub)
+
+	confirmedSub, err := a.ws.AddConfirmedAddedHandlers(signer, func(tx sdk.Transaction) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		abs := tx.GetAbstractTransaction()
+		if !w.matches(abs.TransactionHash) {
+			return false
+		}
+
+		w.report(&AnnounceResult{Status: Confirmed, Height: abs.Height})
+		return true
+	})
+	if err != nil {
+		return false
+	}
+	unsubscribeOnDone(ctx, confirmedSub)
+
+	return true
+}
+
+// removes handlers of passed subscription when ctx is done
+func unsubscribeOnDone(ctx context.Context, sub *websocket.Subscription) {
+	go func() {
+		<-ctx.Done()
+		sub.Unsubscribe()
+	}()
+}
+
+// returns final result if REST reports it, otherwise nil
+func (a *Announcer) poll(ctx context.Context, w *waiter) *AnnounceResult {
+	status, err := a.client.Transaction.GetTransactionStatus(ctx, w.hash.String())
+	if err != nil {
+		// node doesn't know about transaction yet or is not available, next poll will try again
+		return nil
+	}
+
+	switch status.Group {
+	case confirmedGroup:
+		return &AnnounceResult{Status: Confirmed, Height: status.Height}
+	case failedGroup:
+		return &AnnounceResult{Status: Rejected, Error: status.Status}
+	case unconfirmedGroup:
+		w.markUnconfirmed()
+	}
+
+	return nil
+}
+
+type waiter struct {
+	hash        *sdk.Hash
+	resultCh    chan *AnnounceResult
+	unconfirmed int32
+}
+
+func (w *waiter) matches(hash *sdk.Hash) bool {
+	return hash != nil && *hash == *w.hash
+}
+
+// passes result to waiting loop, only the first reported result is taken
+func (w *waiter) report(res *AnnounceResult) {
+	select {
+	case w.resultCh <- res:
+	default:
+	}
+}
+
+func (w *waiter) markUnconfirmed() {
+	atomic.StoreInt32(&w.unconfirmed, 1)
+}
+
+func (w *waiter) finish(res *AnnounceResult) *AnnounceResult {
+	res.Hash = w.hash
+	res.SeenUnconfirmed = res.Status == Confirmed || atomic.LoadInt32(&w.unconfirmed) == 1
+	return res
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+func TestAnnouncer_AnnounceAndWait_ConfirmedByPolling(t *testing.T) {
+	node := newFakeNode()
+	defer node.close()
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	stx := signedTransfer(t, acc, time.Hour)
+
+	var announced int32
+	node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+		dto := make(map[string]interface{})
+		readJson(t, r, &dto)
+		assert.Equal(t, stx.Payload, dto["payload"])
+
+		atomic.StoreInt32(&announced, 1)
+		writeJson(w, http.StatusAccepted, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+
+	var polls int32
+	node.handle(http.MethodGet, fmt.Sprintf("/transaction/%s/status", stx.Hash), func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			writeJson(w, http.StatusNotFound, `{"code": "ResourceNotFound", "message": "no resource exists"}`)
+		case 2:
+			writeJson(w, http.StatusOK, statusJson("unconfirmed", "Success", stx.Hash, 0))
+		default:
+			writeJson(w, http.StatusOK, statusJson("confirmed", "Success", stx.Hash, 42))
+		}
+	})
+
+	announcer := NewAnnouncer(node.client(t), nil, time.Millisecond*10)
+
+	res, err := announcer.AnnounceAndWait(context.Background(), stx)
+	assert.Nilf(t, err, "Announcer.AnnounceAndWait returned error: %s", err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&announced))
+	assert.Equal(t, Confirmed, res.Status)
+	assert.Equal(t, stx.Hash, res.Hash)
+	assert.Equal(t, sdk.Height(42), res.Height)
+	assert.True(t, res.SeenUnconfirmed)
+}
+
+func TestAnnouncer_AnnounceAndWait_RejectedByPolling(t *testing.T) {
+	node := newFakeNode()
+	defer node.close()
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	stx := signedTransfer(t, acc, time.Hour)
+
+	node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusAccepted, `{"message": "ok"}`)
+	})
+	node.handle(http.MethodGet, fmt.Sprintf("/transaction/%s/status", stx.Hash), func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusOK, statusJson("failed", "Failure_Core_Insufficient_Balance", stx.Hash, 0))
+	})
+
+	res, err := NewAnnouncer(node.client(t), nil, time.Millisecond*10).AnnounceAndWait(context.Background(), stx)
+	assert.Nilf(t, err, "Announcer.AnnounceAndWait returned error: %s", err)
+
+	assert.Equal(t, Rejected, res.Status)
+	assert.Equal(t, "Failure_Core_Insufficient_Balance", res.Error)
+	assert.False(t, res.SeenUnconfirmed)
+}
+
+func TestAnnouncer_AnnounceAndWait_Expired(t *testing.T) {
+	node := newFakeNode()
+	defer node.close()
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	stx := signedTransfer(t, acc, time.Millisecond*300)
+
+	node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusAccepted, `{"message": "ok"}`)
+	})
+	node.handle(http.MethodGet, fmt.Sprintf("/transaction/%s/status", stx.Hash), func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusOK, statusJson("unconfirmed", "Success", stx.Hash, 0))
+	})
+
+	res, err := NewAnnouncer(node.client(t), nil, time.Millisecond*50).AnnounceAndWait(context.Background(), stx)
+	assert.Nilf(t, err, "Announcer.AnnounceAndWait returned error: %s", err)
+
+	assert.Equal(t, Expired, res.Status)
+	assert.True(t, res.SeenUnconfirmed)
+}
+
+func TestAnnouncer_AnnounceAndWait_AnnounceError(t *testing.T) {
+	node := newFakeNode()
+	defer node.close()
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusBadRequest, `{"code": "InvalidContent", "message": "bad payload"}`)
+	})
+
+	_, err = NewAnnouncer(node.client(t), nil, time.Millisecond*10).AnnounceAndWait(context.Background(), signedTransfer(t, acc, time.Hour))
+	assert.NotNil(t, err)
+}
+
+func TestAnnouncer_AnnounceAndWait_Websocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	for _, tt := range []struct {
+		name     string
+		messages func(stx *sdk.SignedTransaction) []string
+		expected func(t *testing.T, res *AnnounceResult)
+	}{
+		{
+			name: "confirmed",
+			messages: func(stx *sdk.SignedTransaction) []string {
+				return []string{
+					transactionMessage("unconfirmedAdded", acc.Address, stx.Hash, 0),
+					transactionMessage("confirmedAdded", acc.Address, stx.Hash, 7),
+				}
+			},
+			expected: func(t *testing.T, res *AnnounceResult) {
+				assert.Equal(t, Confirmed, res.Status)
+				assert.Equal(t, sdk.Height(7), res.Height)
+			},
+		},
+		{
+			name: "rejected",
+			messages: func(stx *sdk.SignedTransaction) []string {
+				return []string{
+					statusMessage(acc.Address, "Failure_Core_Past_Deadline", stx.Hash),
+				}
+			},
+			expected: func(t *testing.T, res *AnnounceResult) {
+				assert.Equal(t, Rejected, res.Status)
+				assert.Equal(t, "Failure_Core_Past_Deadline", res.Error)
+				assert.False(t, res.SeenUnconfirmed)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			node := newFakeNode()
+			defer node.close()
+
+			client := node.client(t)
+			wsc := node.websocket(ctx, t, client)
+			defer wsc.Close()
+
+			stx := signedTransfer(t, acc, time.Hour)
+
+			node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+				writeJson(w, http.StatusAccepted, `{"message": "ok"}`)
+
+				go func() {
+					// give subscribers time to register handlers
+					time.Sleep(time.Millisecond * 100)
+
+					for _, m := range tt.messages(stx) {
+						node.push(m)
+					}
+				}()
+			})
+
+			// polling would never return final state, so result can come only from websocket
+			res, err := NewAnnouncer(client, wsc, time.Hour).AnnounceAndWait(ctx, stx)
+			assert.Nilf(t, err, "Announcer.AnnounceAndWait returned error: %s", err)
+
+			node.waitSubscription(t, "status/"+acc.Address.Address)
+			assert.Equal(t, stx.Hash, res.Hash)
+			tt.expected(t, res)
+		})
+	}
+}
+
+func TestAnnouncer_AnnounceAndWait_ContextCanceled(t *testing.T) {
+	node := newFakeNode()
+	defer node.close()
+
+	acc, err := sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusAccepted, `{"message": "ok"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	_, err = NewAnnouncer(node.client(t), nil, time.Millisecond*10).AnnounceAndWait(ctx, signedTransfer(t, acc, time.Hour))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
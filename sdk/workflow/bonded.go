@@ -0,0 +1,497 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+const (
+	// duration in blocks of funds lock which is used if BondedConfig doesn't set it
+	DefaultLockDuration = sdk.Duration(100)
+
+	// expected time between blocks, it is used to estimate when funds lock expires
+	DefaultBlockTime = time.Second * 15
+
+	DefaultBondedRetries    = 3
+	DefaultBondedRetryDelay = time.Second * 5
+
+	lockDeadline = time.Hour
+)
+
+var (
+	ErrNotAggregateBonded = errors.New("transaction should be aggregate bonded")
+	ErrNilSigner          = errors.New("signer should not be nil")
+	ErrLockExpired        = errors.New("funds lock transaction expired before confirmation")
+)
+
+type BondedStage uint8
+
+// BondedStage enums
+const (
+	LockAnnounced BondedStage = iota + 1
+	LockConfirmed
+	BondedAnnounced
+	PartialAdded
+	CosignatureAdded
+	BondedConfirmed
+	Retrying
+)
+
+func (s BondedStage) String() string {
+	switch s {
+	case LockAnnounced:
+		return "LockAnnounced"
+	case LockConfirmed:
+		return "LockConfirmed"
+	case BondedAnnounced:
+		return "BondedAnnounced"
+	case PartialAdded:
+		return "PartialAdded"
+	case CosignatureAdded:
+		return "CosignatureAdded"
+	case BondedConfirmed:
+		return "BondedConfirmed"
+	case Retrying:
+		return "Retrying"
+	default:
+		return fmt.Sprintf("%d", s)
+	}
+}
+
+// BondedEvent reports progress of BondedOrchestrator
+type BondedEvent struct {
+	Stage BondedStage
+	// hash of funds lock for lock stages and of aggregate bonded transaction for others
+	Hash *sdk.Hash
+	// account which cosigned transaction, is set only for CosignatureAdded
+	Cosigner *sdk.PublicAccount
+	// cosigners which didn't sign transaction yet
+	Missing []*sdk.PublicAccount
+	// number of the failed attempt and its error, are set only for Retrying
+	Attempt int
+	Error   error
+}
+
+func (e *BondedEvent) String() string {
+	return fmt.Sprintf(
+		`[Stage: %s, Hash: %s, Cosigner: %s, Missing: %s, Attempt: %d, Error: %v]`,
+		e.Stage,
+		e.Hash,
+		e.Cosigner,
+		e.Missing,
+		e.Attempt,
+		e.Error,
+	)
+}
+
+// BondedResult is a final state of aggregate bonded transaction and its funds lock
+type BondedResult struct {
+	Lock *AnnounceResult
+	// is nil if funds lock was not confirmed
+	Bonded *AnnounceResult
+	// cosigners which didn't sign transaction, is empty if transaction is confirmed
+	Missing []*sdk.PublicAccount
+}
+
+func (r *BondedResult) String() string {
+	return fmt.Sprintf(
+		`[Lock: %s, Bonded: %s, Missing: %s]`,
+		r.Lock,
+		r.Bonded,
+		r.Missing,
+	)
+}
+
+type BondedConfig struct {
+	// mosaic locked for aggregate bonded transaction, 10 xpx by default
+	LockMosaic   *sdk.Mosaic
+	LockDuration sdk.Duration
+	BlockTime    time.Duration
+	// how many times announcing is repeated after temporary failure or expiration of funds lock
+	Retries    int
+	RetryDelay time.Duration
+	// is called on every stage of workflow, calls are never concurrent
+	OnEvent func(*BondedEvent)
+}
+
+// returns BondedConfig with default values
+func DefaultBondedConfig() *BondedConfig {
+	return &BondedConfig{
+		LockMosaic:   sdk.XpxRelative(10),
+		LockDuration: DefaultLockDuration,
+		BlockTime:    DefaultBlockTime,
+		Retries:      DefaultBondedRetries,
+		RetryDelay:   DefaultBondedRetryDelay,
+	}
+}
+
+// BondedOrchestrator locks funds for aggregate bonded transaction, announces it and collects cosignatures
+type BondedOrchestrator struct {
+	client    *sdk.Client
+	ws        websocket.CatapultClient
+	announcer *Announcer
+	config    *BondedConfig
+}
+
+// returns BondedOrchestrator which works through passed clients, ws can be nil.
+// if passed config is nil, DefaultBondedConfig is used
+func NewBondedOrchestrator(client *sdk.Client, ws websocket.CatapultClient, pollInterval time.Duration, config *BondedConfig) *BondedOrchestrator {
+	if config == nil {
+		config = DefaultBondedConfig()
+	}
+
+	return &BondedOrchestrator{
+		client:    client,
+		ws:        ws,
+		announcer: NewAnnouncer(client, ws, pollInterval),
+		config:    config,
+	}
+}
+
+// signs passed aggregate bonded transaction by signer and cosigners, locks funds for it,
+// announces it and waits until it is confirmed, rejected or funds lock expires
+func (o *BondedOrchestrator) Run(ctx context.Context, tx *sdk.AggregateTransaction, signer sdk.Signer, cosigners ...sdk.Signer) (*BondedResult, error) {
+	if tx == nil || tx.Type != sdk.AggregateBonded {
+		return nil, ErrNotAggregateBonded
+	}
+
+	if signer == nil {
+		return nil, ErrNilSigner
+	}
+
+	stx, err := sdk.SignTransactionWithCosignatures(signer, tx, cosigners, o.client.GenerationHash())
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := o.requiredCosigners(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := []*sdk.PublicAccount{signer.SignerAccount()}
+	for _, c := range cosigners {
+		signers = append(signers, c.SignerAccount())
+	}
+
+	s := newBondedState(stx.Hash, required, signers, o.config.OnEvent)
+
+	res := &BondedResult{}
+
+	res.Lock, err = o.lock(ctx, s, stx, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Lock.Status != Confirmed {
+		res.Missing = s.missing()
+		return res, nil
+	}
+
+	s.emit(&BondedEvent{Stage: LockConfirmed, Hash: res.Lock.Hash})
+
+	// aggregate bonded transaction can't be confirmed after funds lock expires
+	expiry := time.Now().Add(time.Duration(o.config.LockDuration) * o.config.BlockTime)
+	bondedCtx, cancel := context.WithDeadline(ctx, expiry)
+	defer cancel()
+
+	o.watchCosignatures(bondedCtx, s, signer.SignerAccount())
+
+	res.Bonded, err = o.announceWithRetry(bondedCtx, s, stx, o.client.Transaction.AnnounceAggregateBonded, BondedAnnounced)
+	if err != nil {
+		if ctx.Err() != nil || bondedCtx.Err() != context.DeadlineExceeded {
+			return nil, err
+		}
+
+		res.Bonded = &AnnounceResult{Status: Expired, Hash: stx.Hash}
+	}
+
+	if res.Bonded.Status == Confirmed {
+		s.emit(&BondedEvent{Stage: BondedConfirmed, Hash: stx.Hash})
+		return res, nil
+	}
+
+	o.refreshCosignatures(ctx, s, signer.SignerAccount())
+	res.Missing = s.missing()
+
+	return res, nil
+}
+
+// announces funds lock for passed aggregate bonded transaction and waits for its final state,
+// expired lock is replaced with a new one
+func (o *BondedOrchestrator) lock(ctx context.Context, s *bondedState, stx *sdk.SignedTransaction, signer sdk.Signer) (*AnnounceResult, error) {
+	for attempt := 1; ; attempt++ {
+		lockTx, err := o.client.NewLockFundsTransaction(sdk.NewDeadline(lockDeadline), o.config.LockMosaic, o.config.LockDuration, stx)
+		if err != nil {
+			return nil, err
+		}
+
+		lockStx, err := sdk.SignTransaction(signer, lockTx, o.client.GenerationHash())
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := o.announceWithRetry(ctx, s, lockStx, o.client.Transaction.Announce, LockAnnounced)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.Status != Expired || attempt > o.config.Retries {
+			return res, nil
+		}
+
+		s.emit(&BondedEvent{Stage: Retrying, Hash: lockStx.Hash, Attempt: attempt, Error: ErrLockExpired})
+	}
+}
+
+// announces passed transaction and waits for its final state, temporary failures are retried.
+// transaction is announced again only if node doesn't know about it yet
+func (o *BondedOrchestrator) announceWithRetry(ctx context.Context, s *bondedState, stx *sdk.SignedTransaction, announce announceFunc, stage BondedStage) (*AnnounceResult, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := o.announcer.announceAndWait(ctx, stx, func(ctx context.Context, stx *sdk.SignedTransaction) (string, error) {
+			if attempt > 1 {
+				if _, err := o.client.Transaction.GetTransactionStatus(ctx, stx.Hash.String()); err == nil {
+					return "", nil
+				}
+			}
+
+			msg, err := announce(ctx, stx)
+			if err == nil {
+				s.emit(&BondedEvent{Stage: stage, Hash: stx.Hash})
+			}
+
+			return msg, err
+		})
+		if err == nil || ctx.Err() != nil || attempt > o.config.Retries || !isTemporary(err) {
+			return res, err
+		}
+
+		s.emit(&BondedEvent{Stage: Retrying, Hash: stx.Hash, Attempt: attempt, Error: err})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(o.config.RetryDelay):
+		}
+	}
+}
+
+// tracks cosignatures through websocket or, if it is not available, through REST
+func (o *BondedOrchestrator) watchCosignatures(ctx context.Context, s *bondedState, signer *sdk.PublicAccount) {
+	if o.ws != nil {
+		partialSub, err := o.ws.AddPartialAddedHandlers(signer.Address, func(tx *sdk.AggregateTransaction) bool {
+			if ctx.Err() != nil {
+				return true
+			}
+
+			if !s.matches(tx.TransactionHash) {
+				return false
+			}
+
+			s.emit(&BondedEvent{Stage: PartialAdded, Hash: s.hash, Missing: s.missing()})
+			return true
+		})
+
+		if err == nil {
+			unsubscribeOnDone(ctx, partialSub)
+
+			var cosignatureSub *websocket.Subscription
+			cosignatureSub, err = o.ws.AddCosignatureHandlers(signer.Address, func(info *sdk.SignerInfo) bool {
+				if ctx.Err() != nil {
+					return true
+				}
+
+				if !s.matches(info.ParentHash) {
+					return false
+				}
+
+				cosigner, err := sdk.NewAccountFromPublicKey(info.Signer, o.client.NetworkType())
+				if err == nil {
+					s.cosigned(cosigner)
+				}
+
+				return false
+			})
+
+			if err == nil {
+				unsubscribeOnDone(ctx, cosignatureSub)
+			}
+		}
+
+		if err == nil {
+			return
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(o.announcer.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				o.refreshCosignatures(ctx, s, signer)
+			}
+		}
+	}()
+}
+
+// takes cosignatures of transaction from partial transactions of signer
+func (o *BondedOrchestrator) refreshCosignatures(ctx context.Context, s *bondedState, signer *sdk.PublicAccount) {
+	txs, err := o.client.Account.AggregateBondedTransactions(ctx, signer, nil)
+	if err != nil {
+		return
+	}
+
+	for _, tx := range txs {
+		if !s.matches(tx.TransactionHash) {
+			continue
+		}
+
+		for _, c := range tx.Cosignatures {
+			s.cosigned(c.Signer)
+		}
+	}
+}
+
+// returns accounts which should sign passed aggregate transaction,
+// inner transactions of multisig accounts require signatures of their cosignatories down to the last level
+func (o *BondedOrchestrator) requiredCosigners(ctx context.Context, tx *sdk.AggregateTransaction) ([]*sdk.PublicAccount, error) {
+	required := make([]*sdk.PublicAccount, 0)
+	seen := make(map[string]bool)
+
+	add := func(acc *sdk.PublicAccount) {
+		key := strings.ToUpper(acc.PublicKey)
+		if !seen[key] {
+			seen[key] = true
+			required = append(required, acc)
+		}
+	}
+
+	for _, inner := range tx.InnerTransactions {
+		signer := inner.GetAbstractTransaction().Signer
+		if signer == nil {
+			continue
+		}
+
+		graph, err := o.client.Account.GetMultisigAccountGraphInfo(ctx, signer.Address)
+		if errors.Is(err, sdk.ErrResourceNotFound) {
+			add(signer)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		infos := make(map[string]*sdk.MultisigAccountInfo)
+		for _, level := range graph.MultisigAccounts {
+			for _, info := range level {
+				infos[strings.ToUpper(info.Account.PublicKey)] = info
+			}
+		}
+
+		expandCosigners(signer, infos, add)
+	}
+
+	return required, nil
+}
+
+func expandCosigners(acc *sdk.PublicAccount, infos map[string]*sdk.MultisigAccountInfo, add func(*sdk.PublicAccount)) {
+	info, ok := infos[strings.ToUpper(acc.PublicKey)]
+	if !ok || len(info.Cosignatories) == 0 {
+		add(acc)
+		return
+	}
+
+	for _, c := range info.Cosignatories {
+		expandCosigners(c, infos, add)
+	}
+}
+
+// returns true if error can disappear on the next attempt
+func isTemporary(err error) bool {
+	if _, ok := err.(*url.Error); ok {
+		return true
+	}
+
+	return errors.Is(err, sdk.ErrServerError)
+}
+
+type bondedState struct {
+	sync.Mutex
+	hash     *sdk.Hash
+	required []*sdk.PublicAccount
+	signed   map[string]bool
+	onEvent  func(*BondedEvent)
+}
+
+func newBondedState(hash *sdk.Hash, required []*sdk.PublicAccount, signers []*sdk.PublicAccount, onEvent func(*BondedEvent)) *bondedState {
+	s := &bondedState{
+		hash:     hash,
+		required: required,
+		signed:   make(map[string]bool),
+		onEvent:  onEvent,
+	}
+
+	for _, acc := range signers {
+		s.signed[strings.ToUpper(acc.PublicKey)] = true
+	}
+
+	return s
+}
+
+func (s *bondedState) matches(hash *sdk.Hash) bool {
+	return hash != nil && *hash == *s.hash
+}
+
+// marks passed account as cosigner, only the first cosignature of every account is reported
+func (s *bondedState) cosigned(acc *sdk.PublicAccount) {
+	s.Lock()
+	key := strings.ToUpper(acc.PublicKey)
+	known := s.signed[key]
+	s.signed[key] = true
+	s.Unlock()
+
+	if !known {
+		s.emit(&BondedEvent{Stage: CosignatureAdded, Hash: s.hash, Cosigner: acc, Missing: s.missing()})
+	}
+}
+
+func (s *bondedState) missing() []*sdk.PublicAccount {
+	s.Lock()
+	defer s.Unlock()
+
+	missing := make([]*sdk.PublicAccount, 0)
+	for _, acc := range s.required {
+		if !s.signed[strings.ToUpper(acc.PublicKey)] {
+			missing = append(missing, acc)
+		}
+	}
+
+	return missing
+}
+
+func (s *bondedState) emit(event *BondedEvent) {
+	if s.onEvent == nil {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.onEvent(event)
+}
@@ -0,0 +1,255 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const multisigGraphJsonTpl = `[{
+	"level": 0,
+	"multisigEntries": [{
+		"multisig": {
+			"account": "%s",
+			"minApproval": 2,
+			"minRemoval": 1,
+			"cosignatories": [%s],
+			"multisigAccounts": []
+		}
+	}]
+}]`
+
+type bondedFixture struct {
+	node     *fakeNode
+	signer   *sdk.Account
+	cosigner *sdk.Account
+	third    *sdk.Account
+	multisig *sdk.Account
+	tx       *sdk.AggregateTransaction
+
+	sync.Mutex
+	events []*BondedEvent
+}
+
+// returns aggregate bonded transaction of multisig account with cosignatories signer, cosigner and third
+func newBondedFixture(t *testing.T) *bondedFixture {
+	f := &bondedFixture{node: newFakeNode()}
+
+	var err error
+	f.signer, err = sdk.NewAccountFromPrivateKey(testPrivateKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+	f.cosigner, err = sdk.NewAccountFromPrivateKey(testCosignerKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+	f.third, err = sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+	f.multisig, err = sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccount returned error: %s", err)
+
+	transfer, err := sdk.NewTransferTransaction(
+		sdk.NewDeadline(time.Hour),
+		sdk.NewAddress(testRecipient, sdk.MijinTest),
+		[]*sdk.Mosaic{sdk.Xpx(10)},
+		sdk.NewPlainMessage("test"),
+		sdk.MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+	transfer.Signer = f.multisig.PublicAccount
+
+	f.tx, err = sdk.NewBondedAggregateTransaction(sdk.NewDeadline(time.Hour), []sdk.Transaction{transfer}, sdk.MijinTest)
+	assert.Nilf(t, err, "NewBondedAggregateTransaction returned error: %s", err)
+
+	f.node.handle(http.MethodGet, fmt.Sprintf("/account/%s/multisig/graph", f.multisig.Address.Address), func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusOK, fmt.Sprintf(
+			multisigGraphJsonTpl,
+			f.multisig.PublicAccount.PublicKey,
+			fmt.Sprintf(`"%s", "%s", "%s"`, f.signer.PublicAccount.PublicKey, f.cosigner.PublicAccount.PublicKey, f.third.PublicAccount.PublicKey),
+		))
+	})
+
+	return f
+}
+
+func (f *bondedFixture) config(duration sdk.Duration, blockTime time.Duration) *BondedConfig {
+	return &BondedConfig{
+		LockMosaic:   sdk.XpxRelative(10),
+		LockDuration: duration,
+		BlockTime:    blockTime,
+		Retries:      2,
+		RetryDelay:   time.Millisecond * 10,
+		OnEvent: func(event *BondedEvent) {
+			f.Lock()
+			defer f.Unlock()
+
+			f.events = append(f.events, event)
+		},
+	}
+}
+
+func (f *bondedFixture) stages() []BondedStage {
+	f.Lock()
+	defer f.Unlock()
+
+	stages := make([]BondedStage, len(f.events))
+	for i, e := range f.events {
+		stages[i] = e.Stage
+	}
+
+	return stages
+}
+
+func TestBondedOrchestrator_Run(t *testing.T) {
+	f := newBondedFixture(t)
+	defer f.node.close()
+
+	f.node.accept(t, "/transaction", confirmedGroup, nil)
+	f.node.accept(t, "/transaction/partial", confirmedGroup, nil)
+
+	o := NewBondedOrchestrator(f.node.client(t), nil, time.Millisecond*10, f.config(DefaultLockDuration, DefaultBlockTime))
+
+	res, err := o.Run(context.Background(), f.tx, f.signer, f.cosigner, f.third)
+	assert.Nilf(t, err, "BondedOrchestrator.Run returned error: %s", err)
+
+	assert.Equal(t, Confirmed, res.Lock.Status)
+	assert.Equal(t, Confirmed, res.Bonded.Status)
+	assert.Equal(t, sdk.Height(42), res.Bonded.Height)
+	assert.Empty(t, res.Missing)
+	assert.Equal(t, []BondedStage{LockAnnounced, LockConfirmed, BondedAnnounced, BondedConfirmed}, f.stages())
+}
+
+func TestBondedOrchestrator_Run_NotMultisigSigner(t *testing.T) {
+	f := newBondedFixture(t)
+	defer f.node.close()
+
+	transfer, err := sdk.NewTransferTransaction(
+		sdk.NewDeadline(time.Hour),
+		sdk.NewAddress(testRecipient, sdk.MijinTest),
+		[]*sdk.Mosaic{sdk.Xpx(10)},
+		sdk.NewPlainMessage("test"),
+		sdk.MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+	transfer.Signer = f.signer.PublicAccount
+
+	tx, err := sdk.NewBondedAggregateTransaction(sdk.NewDeadline(time.Hour), []sdk.Transaction{transfer}, sdk.MijinTest)
+	assert.Nilf(t, err, "NewBondedAggregateTransaction returned error: %s", err)
+
+	// node doesn't know multisig graph of plain account
+	f.node.handle(http.MethodGet, fmt.Sprintf("/account/%s/multisig/graph", f.signer.Address.Address), func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusNotFound, `{"code": "ResourceNotFound", "message": "no resource exists"}`)
+	})
+
+	f.node.accept(t, "/transaction", confirmedGroup, nil)
+	f.node.accept(t, "/transaction/partial", confirmedGroup, nil)
+
+	o := NewBondedOrchestrator(f.node.client(t), nil, time.Millisecond*10, f.config(DefaultLockDuration, DefaultBlockTime))
+
+	res, err := o.Run(context.Background(), tx, f.signer)
+	assert.Nilf(t, err, "BondedOrchestrator.Run returned error: %s", err)
+
+	assert.Equal(t, Confirmed, res.Bonded.Status)
+	assert.Empty(t, res.Missing)
+}
+
+func TestBondedOrchestrator_Run_LockExpiredWithMissingCosigners(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newBondedFixture(t)
+	defer f.node.close()
+
+	client := f.node.client(t)
+	wsc := f.node.websocket(ctx, t, client)
+	defer wsc.Close()
+
+	partial := make(chan string, 1)
+	f.node.accept(t, "/transaction", confirmedGroup, nil)
+	f.node.accept(t, "/transaction/partial", "partial", partial)
+
+	go func() {
+		hash, err := sdk.StringToHash(<-partial)
+		assert.Nilf(t, err, "StringToHash returned error: %s", err)
+
+		// give subscribers time to register handlers
+		time.Sleep(time.Millisecond * 100)
+		f.node.push(cosignatureMessage(f.signer.Address, f.cosigner.PublicAccount, hash))
+	}()
+
+	o := NewBondedOrchestrator(client, wsc, time.Millisecond*10, f.config(1, time.Millisecond*500))
+
+	res, err := o.Run(ctx, f.tx, f.signer)
+	assert.Nilf(t, err, "BondedOrchestrator.Run returned error: %s", err)
+
+	assert.Equal(t, Confirmed, res.Lock.Status)
+	assert.Equal(t, Expired, res.Bonded.Status)
+	assert.Equal(t, []*sdk.PublicAccount{f.third.PublicAccount}, res.Missing)
+
+	f.Lock()
+	defer f.Unlock()
+
+	var cosigned *BondedEvent
+	for _, e := range f.events {
+		if e.Stage == CosignatureAdded {
+			cosigned = e
+		}
+	}
+
+	if assert.NotNil(t, cosigned) {
+		assert.Equal(t, f.cosigner.PublicAccount.PublicKey, cosigned.Cosigner.PublicKey)
+		assert.Equal(t, []*sdk.PublicAccount{f.third.PublicAccount}, cosigned.Missing)
+	}
+}
+
+func TestBondedOrchestrator_Run_Retry(t *testing.T) {
+	f := newBondedFixture(t)
+	defer f.node.close()
+
+	f.node.accept(t, "/transaction/partial", confirmedGroup, nil)
+
+	var attempts int32
+	f.node.handle(http.MethodPut, "/transaction", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			writeJson(w, http.StatusServiceUnavailable, `{"code": "Unavailable", "message": "try later"}`)
+			return
+		}
+
+		f.node.accept(t, "/transaction", confirmedGroup, nil)
+		f.node.serve(w, r)
+	})
+
+	o := NewBondedOrchestrator(f.node.client(t), nil, time.Millisecond*10, f.config(DefaultLockDuration, DefaultBlockTime))
+
+	res, err := o.Run(context.Background(), f.tx, f.signer, f.cosigner)
+	assert.Nilf(t, err, "BondedOrchestrator.Run returned error: %s", err)
+
+	assert.Equal(t, Confirmed, res.Bonded.Status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, []BondedStage{Retrying, LockAnnounced, LockConfirmed, BondedAnnounced, BondedConfirmed}, f.stages())
+}
+
+func TestBondedOrchestrator_Run_NotBonded(t *testing.T) {
+	f := newBondedFixture(t)
+	defer f.node.close()
+
+	complete, err := sdk.NewCompleteAggregateTransaction(sdk.NewDeadline(time.Hour), f.tx.InnerTransactions, sdk.MijinTest)
+	assert.Nilf(t, err, "NewCompleteAggregateTransaction returned error: %s", err)
+
+	o := NewBondedOrchestrator(f.node.client(t), nil, time.Millisecond*10, nil)
+
+	_, err = o.Run(context.Background(), complete, f.signer)
+	assert.Equal(t, ErrNotAggregateBonded, err)
+
+	_, err = o.Run(context.Background(), f.tx, nil)
+	assert.Equal(t, ErrNilSigner, err)
+}
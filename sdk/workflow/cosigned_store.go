@@ -0,0 +1,153 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// CosignedStore keeps hashes of transactions which were cosigned by CosignerAgent
+type CosignedStore interface {
+	Has(hash *sdk.Hash) (bool, error)
+	Add(hash *sdk.Hash) error
+	Remove(hash *sdk.Hash) error
+}
+
+// FileCosignedStore is a CosignedStore which keeps hashes in file, one hash per line.
+// Every added hash is synced to disk before Add returns
+type FileCosignedStore struct {
+	sync.Mutex
+	path   string
+	hashes map[string]bool
+}
+
+// returns FileCosignedStore backed by file at passed path, file is created if it doesn't exist
+func OpenFileCosignedStore(path string) (*FileCosignedStore, error) {
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := &FileCosignedStore{
+		path:   path,
+		hashes: make(map[string]bool),
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		hash, err := sdk.StringToHash(line)
+		if err != nil {
+			return nil, err
+		}
+
+		s.hashes[hash.String()] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileCosignedStore) Has(hash *sdk.Hash) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.hashes[hash.String()], nil
+}
+
+func (s *FileCosignedStore) Add(hash *sdk.Hash) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.hashes[hash.String()] {
+		return nil
+	}
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(hash.String() + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	s.hashes[hash.String()] = true
+
+	return nil
+}
+
+// rewrites file without passed hash, file is replaced atomically
+func (s *FileCosignedStore) Remove(hash *sdk.Hash) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.hashes[hash.String()] {
+		return nil
+	}
+
+	var b strings.Builder
+	for h := range s.hashes {
+		if h != hash.String() {
+			b.WriteString(h + "\n")
+		}
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	delete(s.hashes, hash.String())
+
+	return nil
+}
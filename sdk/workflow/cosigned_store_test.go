@@ -0,0 +1,64 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+func newTestCosignedStore(t *testing.T) (*FileCosignedStore, func()) {
+	dir, err := ioutil.TempDir("", "cosigned")
+	assert.Nilf(t, err, "ioutil.TempDir returned error: %s", err)
+
+	store, err := OpenFileCosignedStore(filepath.Join(dir, "cosigned"))
+	assert.Nilf(t, err, "OpenFileCosignedStore returned error: %s", err)
+
+	return store, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFileCosignedStore(t *testing.T) {
+	store, closeFn := newTestCosignedStore(t)
+	defer closeFn()
+
+	first, err := sdk.StringToHash(testPartialHash)
+	assert.Nilf(t, err, "StringToHash returned error: %s", err)
+	second, err := sdk.StringToHash("81E5E7AE49998802DABC816EC10158D3A7879702FF29084C2C992CD1289877A7")
+	assert.Nilf(t, err, "StringToHash returned error: %s", err)
+
+	assert.Nil(t, store.Add(first))
+	assert.Nil(t, store.Add(first))
+	assert.Nil(t, store.Add(second))
+
+	reopened, err := OpenFileCosignedStore(store.path)
+	assert.Nilf(t, err, "OpenFileCosignedStore returned error: %s", err)
+
+	for _, h := range []*sdk.Hash{first, second} {
+		has, err := reopened.Has(h)
+		assert.Nilf(t, err, "FileCosignedStore.Has returned error: %s", err)
+		assert.True(t, has)
+	}
+
+	assert.Nil(t, reopened.Remove(first))
+
+	reopened, err = OpenFileCosignedStore(store.path)
+	assert.Nilf(t, err, "OpenFileCosignedStore returned error: %s", err)
+
+	has, err := reopened.Has(first)
+	assert.Nilf(t, err, "FileCosignedStore.Has returned error: %s", err)
+	assert.False(t, has)
+
+	has, err = reopened.Has(second)
+	assert.Nilf(t, err, "FileCosignedStore.Has returned error: %s", err)
+	assert.True(t, has)
+}
@@ -0,0 +1,350 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+var (
+	ErrNilCosignedStore    = errors.New("cosigned store should not be nil")
+	ErrTypeNotAllowed      = errors.New("type of inner transaction is not allowed")
+	ErrAmountExceeded      = errors.New("amount of mosaic exceeds allowed maximum")
+	ErrRecipientNotAllowed = errors.New("recipient is not allowed")
+)
+
+// Policy approves aggregate transaction for cosigning by returning nil, returned error is a reason of rejection
+type Policy func(tx *sdk.AggregateTransaction) error
+
+// returns Policy which allows only inner transactions of passed types
+func AllowTypes(types ...sdk.EntityType) Policy {
+	return func(tx *sdk.AggregateTransaction) error {
+		for _, inner := range tx.InnerTransactions {
+			if !containsType(types, inner.GetAbstractTransaction().Type) {
+				return ErrTypeNotAllowed
+			}
+		}
+
+		return nil
+	}
+}
+
+// returns Policy which limits total amount of every mosaic transferred by inner transactions.
+// transfers of mosaics not listed in limits are rejected.
+// other inner transactions could move mosaics too, so they are rejected with ErrTypeNotAllowed
+func MaxMosaicAmounts(limits ...*sdk.Mosaic) Policy {
+	return func(tx *sdk.AggregateTransaction) error {
+		totals := make(map[string]sdk.Amount)
+
+		for _, inner := range tx.InnerTransactions {
+			transfer, ok := inner.(*sdk.TransferTransaction)
+			if !ok {
+				return ErrTypeNotAllowed
+			}
+
+			for _, m := range transfer.Mosaics {
+				key := assetKey(m.AssetId)
+				totals[key] += m.Amount
+
+				limit := findMosaic(limits, key)
+				if limit == nil || totals[key] > limit.Amount {
+					return ErrAmountExceeded
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// returns Policy which allows inner transfers only to passed recipients.
+// other inner transactions could send mosaics elsewhere, so they are rejected with ErrTypeNotAllowed
+func AllowRecipients(recipients ...*sdk.Address) Policy {
+	return func(tx *sdk.AggregateTransaction) error {
+		for _, inner := range tx.InnerTransactions {
+			transfer, ok := inner.(*sdk.TransferTransaction)
+			if !ok {
+				return ErrTypeNotAllowed
+			}
+
+			if !containsAddress(recipients, transfer.Recipient) {
+				return ErrRecipientNotAllowed
+			}
+		}
+
+		return nil
+	}
+}
+
+// CosignDecision reports what CosignerAgent did with aggregate bonded transaction
+type CosignDecision struct {
+	Hash     *sdk.Hash
+	Cosigned bool
+	// reason of rejection by policy or error of cosigning
+	Error error
+}
+
+func (d *CosignDecision) String() string {
+	return fmt.Sprintf(
+		`[Hash: %s, Cosigned: %t, Error: %v]`,
+		d.Hash,
+		d.Cosigned,
+		d.Error,
+	)
+}
+
+type CosignerConfig struct {
+	// every policy should approve transaction before it is cosigned
+	Policies []Policy
+	// interval of polling partial transactions when websocket is not available
+	PollInterval time.Duration
+	// is called for every aggregate bonded transaction which was cosigned, rejected or failed
+	OnDecision func(*CosignDecision)
+}
+
+// CosignerAgent cosigns aggregate bonded transactions waiting for signature of cosigner.
+// Every cosigned transaction is recorded in CosignedStore before announcing, so it is never signed twice
+type CosignerAgent struct {
+	client *sdk.Client
+	ws     websocket.CatapultClient
+	signer sdk.Signer
+	store  CosignedStore
+	config *CosignerConfig
+	// policies are deterministic, so rejected transactions are not checked again
+	rejected map[sdk.Hash]bool
+}
+
+// returns CosignerAgent which cosigns transactions with passed signer, ws can be nil
+func NewCosignerAgent(client *sdk.Client, ws websocket.CatapultClient, signer sdk.Signer, store CosignedStore, config *CosignerConfig) (*CosignerAgent, error) {
+	if signer == nil {
+		return nil, ErrNilSigner
+	}
+
+	if store == nil {
+		return nil, ErrNilCosignedStore
+	}
+
+	if config == nil {
+		config = &CosignerConfig{}
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	return &CosignerAgent{
+		client:   client,
+		ws:       ws,
+		signer:   signer,
+		store:    store,
+		config:   config,
+		rejected: make(map[sdk.Hash]bool),
+	}, nil
+}
+
+// listens for aggregate bonded transactions of cosigner and cosigns approved ones until context is done.
+// transactions which were added before start are taken from REST
+func (a *CosignerAgent) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	txCh := make(chan *sdk.AggregateTransaction, 16)
+
+	interval := a.config.PollInterval
+	if a.subscribe(ctx, txCh) {
+		interval *= socketPollFactor
+	}
+
+	if err := a.poll(ctx); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case tx := <-txCh:
+			a.process(ctx, tx)
+		case <-ticker.C:
+			// node can be temporary unavailable, next poll will try again
+			a.poll(ctx)
+		}
+	}
+}
+
+// passes aggregate bonded transactions of cosigner from websocket to txCh
+// returns false if websocket is not available
+func (a *CosignerAgent) subscribe(ctx context.Context, txCh chan<- *sdk.AggregateTransaction) bool {
+	if a.ws == nil {
+		return false
+	}
+
+	sub, err := a.ws.AddPartialAddedHandlers(a.signer.SignerAccount().Address, func(tx *sdk.AggregateTransaction) bool {
+		select {
+		case <-ctx.Done():
+			return true
+		case txCh <- tx:
+			return false
+		}
+	})
+	if err != nil {
+		return false
+	}
+
+	unsubscribeOnDone(ctx, sub)
+
+	return true
+}
+
+func (a *CosignerAgent) poll(ctx context.Context) error {
+	txs, err := a.client.Account.AggregateBondedTransactions(ctx, a.signer.SignerAccount(), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		a.process(ctx, tx)
+	}
+
+	return nil
+}
+
+// cosigns passed transaction if it waits for signature of cosigner and every policy approves it
+func (a *CosignerAgent) process(ctx context.Context, tx *sdk.AggregateTransaction) {
+	hash := tx.TransactionHash
+	if hash == nil || a.rejected[*hash] || a.signedBy(tx) {
+		return
+	}
+
+	cosigned, err := a.store.Has(hash)
+	if err != nil {
+		a.decide(&CosignDecision{Hash: hash, Error: err})
+		return
+	}
+
+	if cosigned {
+		return
+	}
+
+	for _, policy := range a.config.Policies {
+		if err := policy(tx); err != nil {
+			a.rejected[*hash] = true
+			a.decide(&CosignDecision{Hash: hash, Error: err})
+			return
+		}
+	}
+
+	a.decide(&CosignDecision{Hash: hash, Cosigned: true, Error: a.cosign(ctx, tx)})
+}
+
+func (a *CosignerAgent) cosign(ctx context.Context, tx *sdk.AggregateTransaction) error {
+	cosignatureTx, err := sdk.NewCosignatureTransaction(tx)
+	if err != nil {
+		return err
+	}
+
+	signed, err := sdk.SignCosignatureTransaction(a.signer, cosignatureTx)
+	if err != nil {
+		return err
+	}
+
+	// record goes first, crash after announcing should never lead to the second signature
+	if err := a.store.Add(tx.TransactionHash); err != nil {
+		return err
+	}
+
+	if _, err := a.client.Transaction.AnnounceAggregateBondedCosignature(ctx, signed); err != nil {
+		// node refused cosignature, so transaction can be cosigned again.
+		// after other errors (timeouts, server errors) cosignature could reach node, so record is kept
+		if errors.Is(err, sdk.ErrArgumentNotValid) || errors.Is(err, sdk.ErrConflict) {
+			if rmErr := a.store.Remove(tx.TransactionHash); rmErr != nil {
+				return rmErr
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// returns true if transaction already contains signature of cosigner
+func (a *CosignerAgent) signedBy(tx *sdk.AggregateTransaction) bool {
+	publicKey := a.signer.SignerAccount().PublicKey
+
+	if tx.Signer != nil && strings.EqualFold(tx.Signer.PublicKey, publicKey) {
+		return true
+	}
+
+	for _, c := range tx.Cosignatures {
+		if c.Signer != nil && strings.EqualFold(c.Signer.PublicKey, publicKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *CosignerAgent) decide(decision *CosignDecision) {
+	if decision.Error != nil {
+		decision.Cosigned = false
+	}
+
+	if a.config.OnDecision != nil {
+		a.config.OnDecision(decision)
+	}
+}
+
+func containsType(types []sdk.EntityType, t sdk.EntityType) bool {
+	for _, allowed := range types {
+		if allowed == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAddress(addresses []*sdk.Address, address *sdk.Address) bool {
+	if address == nil {
+		return false
+	}
+
+	for _, allowed := range addresses {
+		if allowed != nil && allowed.Address == address.Address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func assetKey(id sdk.AssetId) string {
+	if id == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d:%d", id.Type(), id.Id())
+}
+
+func findMosaic(mosaics []*sdk.Mosaic, key string) *sdk.Mosaic {
+	for _, m := range mosaics {
+		if m != nil && assetKey(m.AssetId) == key {
+			return m
+		}
+	}
+
+	return nil
+}
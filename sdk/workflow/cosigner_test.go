@@ -0,0 +1,391 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const (
+	testOtherRecipient = "SAONSOGFZZHNEIBRYXHDTDTBR2YSAXKTITRFHG2Y"
+	testPartialHash    = "671653C94E2254F2A23EFEDB15D67C38332AED1FBD24B063C0A8E675582B6A96"
+	testPartialTxJson  = `{
+	"meta": {
+		"channelName": "partialAdded",
+		"address": "%s",
+		"hash": "` + testPartialHash + `",
+		"height": [0, 0],
+		"id": "5A0069D83F17CF0001777E55",
+		"index": 0,
+		"merkleComponentHash": "81E5E7AE49998802DABC816EC10158D3A7879702FF29084C2C992CD1289877A7"
+	},
+	"transaction": {
+		"cosignatures": [%s],
+		"deadline": [3266625578, 11],
+		"maxFee": [1, 0],
+		"signature": "939673209A13FF82397578D22CC96EB8516A6760C894D9B7535E3A1E068007B9255CFA9A914C97142A7AE18533E381C846B69D2AE0D60D1DC8A55AD120E2B606",
+		"signer": "7681ED5023141D9CDCF184E5A7B60B7D466739918ED5DA30F7E71EA7B86EFF2D",
+		"transactions": [{
+			"meta": {
+				"aggregateHash": "` + testPartialHash + `",
+				"aggregateId": "5A0069D83F17CF0001777E55",
+				"height": [0, 0],
+				"id": "5A0069D83F17CF0001777E56",
+				"index": 0
+			},
+			"transaction": {
+				"message": {"payload": "746573742D6D657373616765", "type": 0},
+				"mosaics": [{"amount": [100, 0], "id": [298950589, 1817567325]}],
+				"recipient": "9050B9837EFAB4BBE8A4B9BB32D812F9885C00D8FC1650E142",
+				"signer": "B4F12E7C9F6946091E2CB8B6D3A12B50D17CCBBF646386EA27CE2946A7423DCF",
+				"type": 16724,
+				"version": 36867
+			}
+		}],
+		"type": 16961,
+		"version": 36867
+	}
+}`
+)
+
+type cosignerFixture struct {
+	node      *fakeNode
+	cosigner  *sdk.Account
+	store     *FileCosignedStore
+	decisions chan *CosignDecision
+	announced int32
+	closeFn   func()
+}
+
+func newCosignerFixture(t *testing.T, partial string) *cosignerFixture {
+	cosigner, err := sdk.NewAccountFromPrivateKey(testCosignerKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	store, closeFn := newTestCosignedStore(t)
+
+	f := &cosignerFixture{
+		node:      newFakeNode(),
+		cosigner:  cosigner,
+		store:     store,
+		decisions: make(chan *CosignDecision, 100),
+		closeFn:   closeFn,
+	}
+
+	f.node.handle(http.MethodGet, fmt.Sprintf("/account/%s/transactions/partial", cosigner.PublicAccount.PublicKey), func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusOK, partial)
+	})
+
+	f.node.handle(http.MethodPut, "/transaction/cosignature", func(w http.ResponseWriter, r *http.Request) {
+		dto := make(map[string]string)
+		readJson(t, r, &dto)
+
+		assert.True(t, strings.EqualFold(testPartialHash, dto["parentHash"]))
+		assert.True(t, strings.EqualFold(cosigner.PublicAccount.PublicKey, dto["signer"]))
+
+		atomic.AddInt32(&f.announced, 1)
+		writeJson(w, http.StatusAccepted, `{"message": "ok"}`)
+	})
+
+	return f
+}
+
+func (f *cosignerFixture) close() {
+	f.node.close()
+	f.closeFn()
+}
+
+func (f *cosignerFixture) config(policies ...Policy) *CosignerConfig {
+	return &CosignerConfig{
+		Policies:     policies,
+		PollInterval: time.Millisecond * 10,
+		OnDecision: func(decision *CosignDecision) {
+			f.decisions <- decision
+		},
+	}
+}
+
+func (f *cosignerFixture) run(t *testing.T, config *CosignerConfig) (context.CancelFunc, chan error) {
+	agent, err := NewCosignerAgent(f.node.client(t), nil, f.cosigner, f.store, config)
+	assert.Nilf(t, err, "NewCosignerAgent returned error: %s", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- agent.Run(ctx)
+	}()
+
+	return cancel, done
+}
+
+func (f *cosignerFixture) waitDecision(t *testing.T) *CosignDecision {
+	select {
+	case d := <-f.decisions:
+		return d
+	case <-time.After(time.Second * 5):
+		t.Fatal("no decision of cosigner agent")
+		return nil
+	}
+}
+
+func partialTxs(address *sdk.Address, cosignatures string) string {
+	return "[" + fmt.Sprintf(testPartialTxJson, rawAddress(address), cosignatures) + "]"
+}
+
+func TestCosignerAgent_Run(t *testing.T) {
+	recipient, err := sdk.NewAddressFromBase32("9050B9837EFAB4BBE8A4B9BB32D812F9885C00D8FC1650E142")
+	assert.Nilf(t, err, "NewAddressFromBase32 returned error: %s", err)
+
+	f := newCosignerFixture(t, partialTxs(recipient, ""))
+	defer f.close()
+
+	cancel, done := f.run(t, f.config(
+		AllowTypes(sdk.Transfer),
+		AllowRecipients(recipient),
+	))
+
+	d := f.waitDecision(t)
+	assert.True(t, d.Cosigned)
+	assert.Nil(t, d.Error)
+	assert.True(t, strings.EqualFold(testPartialHash, d.Hash.String()))
+
+	// following polls should not cosign the same transaction again
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&f.announced))
+	assert.Len(t, f.decisions, 0)
+
+	cosigned, err := f.store.Has(d.Hash)
+	assert.Nilf(t, err, "FileCosignedStore.Has returned error: %s", err)
+	assert.True(t, cosigned)
+
+	// restarted agent should remember cosigned transaction
+	reopened, err := OpenFileCosignedStore(f.store.path)
+	assert.Nilf(t, err, "OpenFileCosignedStore returned error: %s", err)
+	f.store = reopened
+
+	cancel, done = f.run(t, f.config())
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	<-done
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&f.announced))
+}
+
+func TestCosignerAgent_Run_Rejected(t *testing.T) {
+	recipient, err := sdk.NewAddressFromBase32("9050B9837EFAB4BBE8A4B9BB32D812F9885C00D8FC1650E142")
+	assert.Nilf(t, err, "NewAddressFromBase32 returned error: %s", err)
+
+	f := newCosignerFixture(t, partialTxs(recipient, ""))
+	defer f.close()
+
+	cancel, done := f.run(t, f.config(AllowRecipients(sdk.NewAddress(testOtherRecipient, sdk.MijinTest))))
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	d := f.waitDecision(t)
+	assert.False(t, d.Cosigned)
+	assert.Equal(t, ErrRecipientNotAllowed, d.Error)
+
+	// rejected transaction is not checked again
+	time.Sleep(time.Millisecond * 100)
+	assert.Len(t, f.decisions, 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&f.announced))
+}
+
+func TestCosignerAgent_Run_AlreadyCosigned(t *testing.T) {
+	recipient, err := sdk.NewAddressFromBase32("9050B9837EFAB4BBE8A4B9BB32D812F9885C00D8FC1650E142")
+	assert.Nilf(t, err, "NewAddressFromBase32 returned error: %s", err)
+
+	cosigner, err := sdk.NewAccountFromPrivateKey(testCosignerKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	f := newCosignerFixture(t, partialTxs(recipient, fmt.Sprintf(
+		`{"signature": "%s", "signer": "%s"}`,
+		bytes.Repeat([]byte("AB"), 64),
+		cosigner.PublicAccount.PublicKey,
+	)))
+	defer f.close()
+
+	cancel, done := f.run(t, f.config())
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	<-done
+
+	assert.Len(t, f.decisions, 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&f.announced))
+}
+
+func TestCosignerAgent_Run_AnnounceRefused(t *testing.T) {
+	recipient, err := sdk.NewAddressFromBase32("9050B9837EFAB4BBE8A4B9BB32D812F9885C00D8FC1650E142")
+	assert.Nilf(t, err, "NewAddressFromBase32 returned error: %s", err)
+
+	f := newCosignerFixture(t, partialTxs(recipient, ""))
+	defer f.close()
+
+	var refused int32
+	f.node.handle(http.MethodPut, "/transaction/cosignature", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refused, 1)
+		writeJson(w, http.StatusConflict, `{"code": "InvalidArgument", "message": "not a cosigner"}`)
+	})
+
+	cancel, done := f.run(t, f.config())
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	d := f.waitDecision(t)
+	assert.False(t, d.Cosigned)
+	assert.NotNil(t, d.Error)
+
+	cosigned, err := f.store.Has(d.Hash)
+	assert.Nilf(t, err, "FileCosignedStore.Has returned error: %s", err)
+	assert.False(t, cosigned)
+
+	// refused cosignature is tried again on the next poll
+	d = f.waitDecision(t)
+	assert.False(t, d.Cosigned)
+	assert.True(t, atomic.LoadInt32(&refused) >= 2)
+}
+
+func TestCosignerAgent_Run_AnnounceFailed(t *testing.T) {
+	recipient, err := sdk.NewAddressFromBase32("9050B9837EFAB4BBE8A4B9BB32D812F9885C00D8FC1650E142")
+	assert.Nilf(t, err, "NewAddressFromBase32 returned error: %s", err)
+
+	f := newCosignerFixture(t, partialTxs(recipient, ""))
+	defer f.close()
+
+	f.node.handle(http.MethodPut, "/transaction/cosignature", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusInternalServerError, `{"code": "Internal", "message": "internal error"}`)
+	})
+
+	cancel, done := f.run(t, f.config())
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	d := f.waitDecision(t)
+	assert.False(t, d.Cosigned)
+	assert.True(t, errors.Is(d.Error, sdk.ErrServerError))
+
+	// cosignature could be accepted by node, so it is never announced again
+	cosigned, err := f.store.Has(d.Hash)
+	assert.Nilf(t, err, "FileCosignedStore.Has returned error: %s", err)
+	assert.True(t, cosigned)
+
+	time.Sleep(time.Millisecond * 100)
+	assert.Len(t, f.decisions, 0)
+}
+
+func TestCosignerAgent_Run_Websocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newCosignerFixture(t, "[]")
+	defer f.close()
+
+	client := f.node.client(t)
+	wsc := f.node.websocket(ctx, t, client)
+	defer wsc.Close()
+
+	config := f.config()
+	config.PollInterval = time.Hour
+
+	agent, err := NewCosignerAgent(client, wsc, f.cosigner, f.store, config)
+	assert.Nilf(t, err, "NewCosignerAgent returned error: %s", err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- agent.Run(ctx)
+	}()
+
+	f.node.waitSubscription(t, "partialAdded/"+f.cosigner.PublicAccount.Address.Address)
+
+	// give subscriber time to register handler
+	time.Sleep(time.Millisecond * 100)
+	f.node.push(fmt.Sprintf(testPartialTxJson, rawAddress(f.cosigner.PublicAccount.Address), ""))
+
+	d := f.waitDecision(t)
+	assert.True(t, d.Cosigned)
+	assert.Nil(t, d.Error)
+
+	cancel()
+	<-done
+	assert.Equal(t, int32(1), atomic.LoadInt32(&f.announced))
+}
+
+func TestNewCosignerAgent_Invalid(t *testing.T) {
+	_, err := NewCosignerAgent(nil, nil, nil, nil, nil)
+	assert.Equal(t, ErrNilSigner, err)
+
+	cosigner, err := sdk.NewAccountFromPrivateKey(testCosignerKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	_, err = NewCosignerAgent(nil, nil, cosigner, nil, nil)
+	assert.Equal(t, ErrNilCosignedStore, err)
+}
+
+func TestPolicies(t *testing.T) {
+	recipient := sdk.NewAddress(testRecipient, sdk.MijinTest)
+
+	transfer, err := sdk.NewTransferTransaction(
+		sdk.NewDeadline(time.Hour),
+		recipient,
+		[]*sdk.Mosaic{sdk.Xpx(10), sdk.Xpx(5)},
+		sdk.NewPlainMessage("test"),
+		sdk.MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	tx, err := sdk.NewBondedAggregateTransaction(sdk.NewDeadline(time.Hour), []sdk.Transaction{transfer}, sdk.MijinTest)
+	assert.Nilf(t, err, "NewBondedAggregateTransaction returned error: %s", err)
+
+	assert.Nil(t, AllowTypes(sdk.Transfer)(tx))
+	assert.Equal(t, ErrTypeNotAllowed, AllowTypes(sdk.MosaicDefinition)(tx))
+
+	assert.Nil(t, MaxMosaicAmounts(sdk.Xpx(15))(tx))
+	assert.Equal(t, ErrAmountExceeded, MaxMosaicAmounts(sdk.Xpx(14))(tx))
+	assert.Equal(t, ErrAmountExceeded, MaxMosaicAmounts(sdk.Storage(100))(tx))
+
+	assert.Nil(t, AllowRecipients(recipient)(tx))
+	assert.Equal(t, ErrRecipientNotAllowed, AllowRecipients(sdk.NewAddress(testOtherRecipient, sdk.MijinTest))(tx))
+
+	// inner transactions which are not transfers can't be checked by mosaic policies
+	owner, err := sdk.NewAccountFromPrivateKey(testCosignerKey, sdk.MijinTest, nil)
+	assert.Nilf(t, err, "NewAccountFromPrivateKey returned error: %s", err)
+
+	definition, err := sdk.NewMosaicDefinitionTransaction(
+		sdk.NewDeadline(time.Hour),
+		1,
+		owner.PublicAccount.PublicKey,
+		sdk.NewMosaicProperties(true, true, 0, sdk.Duration(100)),
+		sdk.MijinTest,
+	)
+	assert.Nilf(t, err, "NewMosaicDefinitionTransaction returned error: %s", err)
+
+	tx, err = sdk.NewBondedAggregateTransaction(sdk.NewDeadline(time.Hour), []sdk.Transaction{transfer, definition}, sdk.MijinTest)
+	assert.Nilf(t, err, "NewBondedAggregateTransaction returned error: %s", err)
+
+	assert.Equal(t, ErrTypeNotAllowed, MaxMosaicAmounts(sdk.Xpx(15))(tx))
+	assert.Equal(t, ErrTypeNotAllowed, AllowRecipients(recipient)(tx))
+}
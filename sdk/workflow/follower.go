@@ -0,0 +1,384 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+const (
+	// number of recent headers which ChainFollower keeps to find common ancestor of forks
+	DefaultFollowerWindow = 100
+
+	// number of blocks requested at once while ChainFollower catches up the chain
+	followerBatchSize sdk.Amount = 100
+)
+
+var (
+	ErrDiscontinuousChain = errors.New("node returned blocks which don't continue followed chain")
+)
+
+type ChainEventType uint8
+
+// ChainEventType enums
+const (
+	// block continues followed chain
+	BlockAdded ChainEventType = iota + 1
+	// block has FinalityDepth blocks above it
+	BlockFinalized
+	// blocks are abandoned, because node switched to another fork
+	RolledBack
+)
+
+func (t ChainEventType) String() string {
+	switch t {
+	case BlockAdded:
+		return "BlockAdded"
+	case BlockFinalized:
+		return "BlockFinalized"
+	case RolledBack:
+		return "RolledBack"
+	default:
+		return fmt.Sprintf("%d", t)
+	}
+}
+
+// ChainEvent reports change of chain followed by ChainFollower
+type ChainEvent struct {
+	Type ChainEventType
+	// added or finalized block, is nil for RolledBack
+	Block *sdk.BlockInfo
+	// heights of abandoned blocks from the highest one, are set only for RolledBack
+	Heights []sdk.Height
+}
+
+func (e *ChainEvent) String() string {
+	return fmt.Sprintf(
+		`[Type: %s, Block: %s, Heights: %v]`,
+		e.Type,
+		e.Block,
+		e.Heights,
+	)
+}
+
+type FollowerConfig struct {
+	// height of the first followed block, the current chain height is used if it is zero
+	StartHeight sdk.Height
+	// number of recent headers kept to detect forks, DefaultFollowerWindow is used if it is zero.
+	// Window is extended to keep blocks which are not finalized. Fork deeper than window rolls back all kept blocks
+	Window int
+	// number of blocks which should be added above block before it is finalized, blocks are finalized at once if it is zero.
+	// Finalized block is rolled back only by fork which is deeper than FinalityDepth
+	FinalityDepth int
+	// interval of polling chain height when websocket is not available
+	PollInterval time.Duration
+	// is called for every change of followed chain, calls are never concurrent
+	OnEvent func(*ChainEvent)
+	// is called when follower fails to fetch blocks, follower tries again on the next block or poll
+	OnError func(error)
+}
+
+// ChainFollower follows blocks of node, checks that every block continues the previous one
+// and reports blocks which are abandoned on forks
+type ChainFollower struct {
+	client *sdk.Client
+	ws     websocket.CatapultClient
+	config FollowerConfig
+	// recent headers in ascending order of height without gaps
+	headers []*sdk.BlockInfo
+	// height of the first block which is followed when there are no headers
+	next sdk.Height
+	// height of the last finalized block
+	finalized sdk.Height
+}
+
+// returns ChainFollower which takes blocks through passed clients, ws can be nil
+func NewChainFollower(client *sdk.Client, ws websocket.CatapultClient, config *FollowerConfig) *ChainFollower {
+	f := &ChainFollower{
+		client: client,
+		ws:     ws,
+	}
+
+	if config != nil {
+		f.config = *config
+	}
+
+	if f.config.Window <= 0 {
+		f.config.Window = DefaultFollowerWindow
+	}
+
+	if f.config.FinalityDepth < 0 {
+		f.config.FinalityDepth = 0
+	}
+
+	if f.config.Window <= f.config.FinalityDepth {
+		f.config.Window = f.config.FinalityDepth + 1
+	}
+
+	if f.config.PollInterval <= 0 {
+		f.config.PollInterval = DefaultPollInterval
+	}
+
+	f.next = f.config.StartHeight
+
+	return f
+}
+
+// follows the chain until passed context is done.
+// New blocks are taken from websocket if ws is available, chain height is polled through REST as a safety net
+func (f *ChainFollower) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscription goes first, so blocks produced during catching up are not missed.
+	// Blocks dropped on overflow are fetched by Sync
+	var blocks <-chan *sdk.BlockInfo
+	interval := f.config.PollInterval
+	if f.ws != nil {
+		ch, _, err := f.ws.SubscribeBlocks(ctx, websocket.WithOverflowPolicy(websocket.OverflowDropOldest))
+		if err == nil {
+			blocks = ch
+			interval *= socketPollFactor
+		}
+	}
+
+	f.report(f.Sync(ctx))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case b, ok := <-blocks:
+			if !ok {
+				// websocket is closed, chain is followed by polling
+				blocks = nil
+				continue
+			}
+
+			f.report(f.follow(ctx, b))
+		case <-ticker.C:
+			f.report(f.Sync(ctx))
+		}
+	}
+}
+
+// follows the chain up to the current chain height, blocks of abandoned fork are rolled back before blocks of new chain are added.
+// It shouldn't be called concurrently with Run
+func (f *ChainFollower) Sync(ctx context.Context) error {
+	for {
+		chainHeight, err := f.client.Blockchain.GetBlockchainHeight(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(f.headers) == 0 {
+			if f.next == 0 {
+				f.next = chainHeight
+			}
+
+			if f.next > chainHeight {
+				return nil
+			}
+
+			b, err := f.client.Blockchain.GetBlockByHeight(ctx, f.next)
+			if err != nil {
+				return err
+			}
+
+			f.add(b)
+		}
+
+		// node which is behind followed chain can't confirm or deny its blocks
+		if f.tip().Height > chainHeight {
+			return nil
+		}
+
+		if err := f.reconcile(ctx); err != nil {
+			return err
+		}
+
+		if len(f.headers) == 0 {
+			continue
+		}
+
+		tip := f.tip()
+		if tip.Height >= chainHeight {
+			return nil
+		}
+
+		blocks, err := f.client.Blockchain.GetBlocksByHeightWithLimit(ctx, tip.Height+1, followerBatchSize)
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].Height < blocks[j].Height
+		})
+
+		for _, b := range blocks {
+			if b.Height <= f.tip().Height {
+				continue
+			}
+
+			// chain changed since reconcile, so it is reconciled again
+			if !f.continues(b) {
+				break
+			}
+
+			f.add(b)
+		}
+
+		// node returned blocks which don't continue its own chain, it should be consistent on the next call
+		if f.tip() == tip {
+			return ErrDiscontinuousChain
+		}
+	}
+}
+
+// rolls back headers which are not in the chain of node
+func (f *ChainFollower) reconcile(ctx context.Context) error {
+	i := len(f.headers) - 1
+	for ; i >= 0; i-- {
+		b, err := f.client.Blockchain.GetBlockByHeight(ctx, f.headers[i].Height)
+		if err != nil {
+			return err
+		}
+
+		if sameBlock(b, f.headers[i]) {
+			break
+		}
+	}
+
+	if i == len(f.headers)-1 {
+		return nil
+	}
+
+	// common ancestor is not in window, the chain is followed again from the oldest kept height
+	if i < 0 {
+		f.next = f.headers[0].Height
+		f.rollback(f.headers[0].Height - 1)
+		return nil
+	}
+
+	f.rollback(f.headers[i].Height)
+
+	return nil
+}
+
+// adds block received from websocket, Sync is used if block doesn't continue any kept header
+func (f *ChainFollower) follow(ctx context.Context, b *sdk.BlockInfo) error {
+	if len(f.headers) == 0 {
+		return f.Sync(ctx)
+	}
+
+	if f.continues(b) {
+		f.add(b)
+		return nil
+	}
+
+	if h := f.header(b.Height); h != nil && sameBlock(h, b) {
+		return nil
+	}
+
+	// fork inside window is detected without REST
+	if parent := f.header(b.Height - 1); parent != nil && b.PreviousBlockHash != nil && *b.PreviousBlockHash == *parent.BlockHash {
+		f.rollback(parent.Height)
+		f.add(b)
+		return nil
+	}
+
+	return f.Sync(ctx)
+}
+
+func (f *ChainFollower) tip() *sdk.BlockInfo {
+	return f.headers[len(f.headers)-1]
+}
+
+// returns kept header at passed height, nil if it is not kept
+func (f *ChainFollower) header(height sdk.Height) *sdk.BlockInfo {
+	if len(f.headers) == 0 || height < f.headers[0].Height || height > f.tip().Height {
+		return nil
+	}
+
+	return f.headers[height-f.headers[0].Height]
+}
+
+// returns true if passed block is the next block after tip
+func (f *ChainFollower) continues(b *sdk.BlockInfo) bool {
+	tip := f.tip()
+	return b.Height == tip.Height+1 && b.PreviousBlockHash != nil && tip.BlockHash != nil && *b.PreviousBlockHash == *tip.BlockHash
+}
+
+func (f *ChainFollower) add(b *sdk.BlockInfo) {
+	if len(f.headers) == 0 && f.finalized < b.Height-1 {
+		f.finalized = b.Height - 1
+	}
+
+	f.headers = append(f.headers, b)
+	f.emit(&ChainEvent{Type: BlockAdded, Block: b})
+
+	for _, h := range f.headers {
+		if h.Height <= f.finalized {
+			continue
+		}
+
+		if h.Height+sdk.Height(f.config.FinalityDepth) > b.Height {
+			break
+		}
+
+		f.finalized = h.Height
+		f.emit(&ChainEvent{Type: BlockFinalized, Block: h})
+	}
+
+	// window is longer than finality depth, so only finalized headers are removed
+	if len(f.headers) > f.config.Window {
+		f.headers = f.headers[len(f.headers)-f.config.Window:]
+	}
+}
+
+// removes headers above passed height
+func (f *ChainFollower) rollback(height sdk.Height) {
+	var heights []sdk.Height
+	for len(f.headers) > 0 && f.tip().Height > height {
+		heights = append(heights, f.tip().Height)
+		f.headers = f.headers[:len(f.headers)-1]
+	}
+
+	if len(heights) == 0 {
+		return
+	}
+
+	if f.finalized > height {
+		f.finalized = height
+	}
+
+	f.emit(&ChainEvent{Type: RolledBack, Heights: heights})
+}
+
+func (f *ChainFollower) emit(event *ChainEvent) {
+	if f.config.OnEvent != nil {
+		f.config.OnEvent(event)
+	}
+}
+
+func (f *ChainFollower) report(err error) {
+	if err != nil && f.config.OnError != nil && !errors.Is(err, context.Canceled) {
+		f.config.OnError(err)
+	}
+}
+
+func sameBlock(a, b *sdk.BlockInfo) bool {
+	return a.BlockHash != nil && b.BlockHash != nil && *a.BlockHash == *b.BlockHash
+}
@@ -0,0 +1,182 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/sdktest"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+func newFollowerTestNode(t *testing.T) (*sdktest.Node, *sdk.Client) {
+	node, err := sdktest.NewNode(nil)
+	assert.Nilf(t, err, "NewNode returned error: %s", err)
+
+	cfg, err := node.Config(context.Background())
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	return node, sdk.NewClient(nil, cfg)
+}
+
+// short form of ChainEvent, e.g. "added 3", "finalized 2" or "rolled back [4 3]"
+func describeEvent(e *ChainEvent) string {
+	switch e.Type {
+	case BlockAdded:
+		return "added " + e.Block.Height.String()
+	case BlockFinalized:
+		return "finalized " + e.Block.Height.String()
+	default:
+		heights := ""
+		for i, h := range e.Heights {
+			if i > 0 {
+				heights += " "
+			}
+
+			heights += h.String()
+		}
+
+		return "rolled back [" + heights + "]"
+	}
+}
+
+func TestChainFollower_Sync(t *testing.T) {
+	node, client := newFollowerTestNode(t)
+	defer node.Close()
+
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		node.GenerateBlock()
+	}
+
+	var events []string
+	f := NewChainFollower(client, nil, &FollowerConfig{
+		StartHeight:   1,
+		Window:        3,
+		FinalityDepth: 2,
+		OnEvent:       func(e *ChainEvent) { events = append(events, describeEvent(e)) },
+	})
+
+	err := f.Sync(ctx)
+	assert.Nilf(t, err, "Sync returned error: %s", err)
+	assert.Equal(t, []string{
+		"added 1", "added 2", "added 3", "finalized 1", "added 4", "finalized 2",
+	}, events)
+
+	// blocks 3 and 4 are replaced by another fork
+	events = nil
+	node.Fork(2)
+	for i := 0; i < 3; i++ {
+		node.GenerateBlock()
+	}
+
+	err = f.Sync(ctx)
+	assert.Nilf(t, err, "Sync returned error: %s", err)
+	assert.Equal(t, []string{
+		"rolled back [4 3]", "added 3", "added 4", "added 5", "finalized 3",
+	}, events)
+
+	// fork deeper than window rolls back all kept blocks
+	events = nil
+	node.Fork(1)
+	for i := 0; i < 5; i++ {
+		node.GenerateBlock()
+	}
+
+	err = f.Sync(ctx)
+	assert.Nilf(t, err, "Sync returned error: %s", err)
+	assert.Equal(t, []string{
+		"rolled back [5 4 3]", "added 3", "added 4", "added 5", "finalized 3", "added 6", "finalized 4",
+	}, events)
+}
+
+func TestChainFollower_Run(t *testing.T) {
+	node, client := newFollowerTestNode(t)
+	defer node.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg, err := node.Config(ctx)
+	assert.Nilf(t, err, "Config returned error: %s", err)
+
+	wsc, err := websocket.NewClient(ctx, cfg)
+	assert.Nilf(t, err, "websocket.NewClient returned error: %s", err)
+	defer wsc.Close()
+
+	go wsc.Listen()
+
+	// finalization is checked by TestChainFollower_Sync
+	events := make(chan *ChainEvent, 100)
+	f := NewChainFollower(client, wsc, &FollowerConfig{
+		// only websocket can deliver new blocks during test
+		PollInterval: time.Hour,
+		OnEvent: func(e *ChainEvent) {
+			if e.Type != BlockFinalized {
+				events <- e
+			}
+		},
+	})
+
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- f.Run(runCtx)
+	}()
+
+	next := func() *ChainEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-ctx.Done():
+			t.Fatal("event is not received")
+			return nil
+		}
+	}
+
+	assert.Equal(t, "added 1", describeEvent(next()))
+
+	// returns true when block at passed height is added
+	added := func(height sdk.Height) bool {
+		timeout := time.After(100 * time.Millisecond)
+		for {
+			select {
+			case e := <-events:
+				if e.Type == BlockAdded && e.Block.Height == height {
+					return true
+				}
+			case <-timeout:
+				return false
+			}
+		}
+	}
+
+	// websocket may subscribe after the first blocks, missed blocks are synced when the next block arrives
+	var last sdk.Height
+	for i := 0; i < 50 && last == 0; i++ {
+		if height := node.GenerateBlock(); added(height) {
+			last = height
+		}
+	}
+
+	assert.NotZero(t, last, "blocks are not followed from websocket")
+
+	// block of another fork is delivered by websocket right after its parent
+	node.Fork(last - 1)
+	node.GenerateBlock()
+
+	assert.Equal(t, &ChainEvent{Type: RolledBack, Heights: []sdk.Height{last}}, next())
+
+	assert.Equal(t, "added "+last.String(), describeEvent(next()))
+
+	stop()
+	assert.Equal(t, context.Canceled, <-done)
+}
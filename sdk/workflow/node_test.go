@@ -0,0 +1,284 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
+)
+
+const (
+	testPrivateKey     = "2a2b1f5d366a5dd5dc56c3c757cf4fe6c66e2787087692cf329d7a49a594658b"
+	testCosignerKey    = "b8afae6f4ad13a1b8aad047b488e0738a437c7389d4ff30c359ac068910c1d59"
+	testRecipient      = "SBILTA367K2LX2FEXG5TFWAS7GEFYAGY7QLFBYKC"
+	testWebsocketUid   = "test-uid"
+	testGenerationHash = "86258172F90639811F2ABD055747D1E11B55A64B68AED2CEA9A34FBD6C0BE790"
+	testTransactionTpl = `{
+	"meta": {
+		"channelName": "%s",
+		"address": "%s",
+		"height": [%d, 0],
+		"hash": "%s",
+		"merkleComponentHash": "%s",
+		"index": 0,
+		"id": "5B686E97F0C0EA00017B9437"
+	},
+	"transaction": {
+		"signature": "ADF80CBC864B65A8D94205E9EC6640FA4AE0E3011B27F8A93D93761E454A9853BF0AB1ECB3DF62E1D2D267D3F1913FAB0E2225CE5EA3937790B78FFA1288870C",
+		"signer": "27F6BEF9A7F75E33AE2EB2EBA10EF1D6BEA4D30EBD5E39AF8EE06E96E11AE2A9",
+		"version": -1879048189,
+		"type": 16724,
+		"maxFee": [1, 0],
+		"deadline": [1094650402, 17],
+		"recipient": "90534434E016CAA132AB5EAC70C0AF7DF043B990C789A93EB1",
+		"message": {"type": 0, "payload": ""},
+		"mosaics": [{"id": [3646934825, 3576016193], "amount": [10000000, 0]}]
+	}
+}`
+)
+
+// fakeNode serves REST routes used by workflows and a websocket endpoint, every request is passed to handlers set by test
+type fakeNode struct {
+	sync.Mutex
+	server *httptest.Server
+
+	routes     map[string]http.HandlerFunc
+	conns      []*ws.Conn
+	subscribes chan string
+}
+
+func newFakeNode() *fakeNode {
+	n := &fakeNode{
+		routes:     make(map[string]http.HandlerFunc),
+		subscribes: make(chan string, 100),
+	}
+
+	n.server = httptest.NewServer(http.HandlerFunc(n.serve))
+
+	return n
+}
+
+func (n *fakeNode) handle(method, path string, h http.HandlerFunc) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.routes[method+" "+path] = h
+}
+
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/ws" {
+		n.serveWebsocket(w, r)
+		return
+	}
+
+	n.Lock()
+	h, ok := n.routes[r.Method+" "+r.URL.Path]
+	n.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	h(w, r)
+}
+
+func (n *fakeNode) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&ws.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	if err := conn.WriteJSON(map[string]string{"uid": testWebsocketUid}); err != nil {
+		return
+	}
+
+	n.Lock()
+	n.conns = append(n.conns, conn)
+	n.Unlock()
+
+	for {
+		m := make(map[string]string)
+		if err := conn.ReadJSON(&m); err != nil {
+			return
+		}
+
+		if path, ok := m["subscribe"]; ok {
+			n.subscribes <- path
+		}
+	}
+}
+
+// sends passed message to every opened websocket connection
+func (n *fakeNode) push(message string) {
+	n.Lock()
+	defer n.Unlock()
+
+	for _, conn := range n.conns {
+		conn.WriteMessage(ws.TextMessage, []byte(message))
+	}
+}
+
+// waits until websocket client subscribes to passed path
+func (n *fakeNode) waitSubscription(t *testing.T, path string) {
+	timeout := time.After(time.Second * 5)
+
+	for {
+		select {
+		case p := <-n.subscribes:
+			if p == path {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no subscription to %s", path)
+		}
+	}
+}
+
+func (n *fakeNode) close() {
+	n.Lock()
+	for _, conn := range n.conns {
+		conn.Close()
+	}
+	n.Unlock()
+
+	n.server.Close()
+}
+
+func (n *fakeNode) config(t *testing.T) *sdk.Config {
+	repConfig, err := sdk.NewReputationConfig(10, 0.9)
+	assert.Nil(t, err)
+
+	generationHash, err := sdk.StringToHash(testGenerationHash)
+	assert.Nilf(t, err, "StringToHash returned error: %s", err)
+
+	conf, err := sdk.NewConfigWithReputation(
+		[]string{n.server.URL},
+		sdk.MijinTest,
+		repConfig,
+		time.Millisecond*100,
+		generationHash,
+		sdk.DefaultFeeCalculationStrategy,
+	)
+	assert.Nilf(t, err, "NewConfigWithReputation returned error: %s", err)
+
+	// failures of node should reach workflows
+	conf.RetryPolicy = sdk.NoRetryPolicy()
+
+	return conf
+}
+
+func (n *fakeNode) client(t *testing.T) *sdk.Client {
+	conf := n.config(t)
+
+	return sdk.NewClient(nil, conf)
+}
+
+func (n *fakeNode) websocket(ctx context.Context, t *testing.T, client *sdk.Client) websocket.CatapultClient {
+	conf := n.config(t)
+
+	wsc, err := websocket.NewClient(ctx, conf)
+	assert.Nilf(t, err, "websocket.NewClient returned error: %s", err)
+
+	go wsc.Listen()
+
+	return wsc
+}
+
+func writeJson(w http.ResponseWriter, code int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write([]byte(body))
+}
+
+func readJson(t *testing.T, r *http.Request, v interface{}) {
+	b, err := ioutil.ReadAll(r.Body)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(b, v))
+}
+
+func rawAddress(address *sdk.Address) string {
+	b, err := base32.StdEncoding.DecodeString(address.Address)
+	if err != nil {
+		panic(err)
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+func statusJson(group string, status string, hash *sdk.Hash, height uint32) string {
+	return fmt.Sprintf(`{"group": "%s", "status": "%s", "hash": "%s", "deadline": [1, 0], "height": [%d, 0]}`, group, status, hash, height)
+}
+
+func statusMessage(address *sdk.Address, status string, hash *sdk.Hash) string {
+	return fmt.Sprintf(`{"meta": {"channelName": "status", "address": "%s"}, "status": "%s", "hash": "%s"}`, rawAddress(address), status, hash)
+}
+
+func transactionMessage(channel string, address *sdk.Address, hash *sdk.Hash, height uint32) string {
+	return fmt.Sprintf(testTransactionTpl, channel, rawAddress(address), height, hash, hash)
+}
+
+func signedTransfer(t *testing.T, acc *sdk.Account, deadline time.Duration) *sdk.SignedTransaction {
+	tx, err := sdk.NewTransferTransaction(
+		sdk.NewDeadline(deadline),
+		sdk.NewAddress(testRecipient, sdk.MijinTest),
+		[]*sdk.Mosaic{sdk.Xpx(10)},
+		sdk.NewPlainMessage("test"),
+		sdk.MijinTest,
+	)
+	assert.Nilf(t, err, "NewTransferTransaction returned error: %s", err)
+
+	stx, err := acc.Sign(tx)
+	assert.Nilf(t, err, "Account.Sign returned error: %s", err)
+
+	return stx
+}
+
+func cosignatureMessage(address *sdk.Address, signer *sdk.PublicAccount, parentHash *sdk.Hash) string {
+	return fmt.Sprintf(
+		`{"meta": {"channelName": "cosignature", "address": "%s"}, "signer": "%s", "signature": "%s", "parentHash": "%s"}`,
+		rawAddress(address),
+		signer.PublicKey,
+		strings.Repeat("AB", 64),
+		parentHash,
+	)
+}
+
+// accepts announced transactions and reports them with passed status group
+func (n *fakeNode) accept(t *testing.T, path string, group string, announced chan<- string) {
+	n.handle(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request) {
+		dto := make(map[string]interface{})
+		readJson(t, r, &dto)
+
+		hash, err := sdk.StringToHash(dto["hash"].(string))
+		assert.Nilf(t, err, "StringToHash returned error: %s", err)
+
+		n.handle(http.MethodGet, fmt.Sprintf("/transaction/%s/status", hash), func(w http.ResponseWriter, r *http.Request) {
+			writeJson(w, http.StatusOK, statusJson(group, "Success", hash, 42))
+		})
+
+		writeJson(w, http.StatusAccepted, `{"message": "ok"}`)
+
+		if announced != nil {
+			announced <- hash.String()
+		}
+	})
+}
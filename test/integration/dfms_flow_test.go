@@ -96,7 +96,7 @@ func TestDriveFlowTransaction(t *testing.T) {
 	}, defaultAccount, driveAccount)
 	assert.Nil(t, result.error)
 
-	if err := wsc.AddDriveStateHandlers(driveAccount.Address, func(info *sdk.DriveStateInfo) bool {
+	if _, err := wsc.AddDriveStateHandlers(driveAccount.Address, func(info *sdk.DriveStateInfo) bool {
 		if info.DriveKey != driveAccount.PublicAccount.PublicKey {
 			return false
 		}
@@ -28,7 +28,7 @@ func TestAddConfirmedAddedHandlers(t *testing.T) {
 
 	wg.Add(1)
 
-	err = wsc.AddConfirmedAddedHandlers(testAccount.Address, func(transaction sdk.Transaction) bool {
+	_, err = wsc.AddConfirmedAddedHandlers(testAccount.Address, func(transaction sdk.Transaction) bool {
 		wg.Done()
 		return true
 	})
@@ -60,7 +60,7 @@ func TestAddPartialAddedHandlers(t *testing.T) {
 
 	wg.Add(1)
 
-	err = wsc.AddPartialAddedHandlers(acc1.Address, func(transaction *sdk.AggregateTransaction) bool {
+	_, err = wsc.AddPartialAddedHandlers(acc1.Address, func(transaction *sdk.AggregateTransaction) bool {
 		wg.Done()
 		return false
 	})
@@ -117,7 +117,7 @@ func TestAddCosignatureHandlers(t *testing.T) {
 
 	wg.Add(1)
 
-	err = wsc.AddCosignatureHandlers(acc2.Address, func(info *sdk.SignerInfo) bool {
+	_, err = wsc.AddCosignatureHandlers(acc2.Address, func(info *sdk.SignerInfo) bool {
 		wg.Done()
 		return true
 	})
@@ -88,7 +88,7 @@ func initListeners(t *testing.T, account *sdk.Account, hash *sdk.Hash, tx sdk.Tr
 	out := make(chan Result)
 
 	// Register handlers functions for needed topics
-	if err := wsc.AddConfirmedAddedHandlers(account.Address, func(transaction sdk.Transaction) bool {
+	if _, err := wsc.AddConfirmedAddedHandlers(account.Address, func(transaction sdk.Transaction) bool {
 		if !hash.Equal(transaction.GetAbstractTransaction().TransactionHash) {
 			return false
 		}
@@ -119,7 +119,7 @@ func initListeners(t *testing.T, account *sdk.Account, hash *sdk.Hash, tx sdk.Tr
 		panic(err)
 	}
 
-	if err := wsc.AddStatusHandlers(account.Address, func(info *sdk.StatusInfo) bool {
+	if _, err := wsc.AddStatusHandlers(account.Address, func(info *sdk.StatusInfo) bool {
 		if !hash.Equal(info.Hash) {
 			return false
 		}
@@ -143,7 +143,7 @@ func waitForBlocksCount(t *testing.T, duration int) {
 	m := sync.Mutex{}
 
 	innerCounter := 0
-	err := wsc.AddBlockHandlers(func(*sdk.BlockInfo) bool {
+	_, err := wsc.AddBlockHandlers(func(*sdk.BlockInfo) bool {
 		m.Lock()
 		defer m.Unlock()
 		innerCounter++